@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -9,41 +11,150 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Verce11o/resume-view/echo-service/internal/auth"
 	"github.com/Verce11o/resume-view/echo-service/internal/clients/grpc"
 	"github.com/Verce11o/resume-view/echo-service/internal/config"
+	"github.com/Verce11o/resume-view/echo-service/internal/load"
+	"github.com/Verce11o/resume-view/echo-service/internal/probe"
+	"github.com/Verce11o/resume-view/echo-service/internal/server"
 	pb "github.com/Verce11o/resume-view/protos/gen/go"
 )
 
+const (
+	modeProbe = "probe"
+	modeLoad  = "load"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+var errSLOBreached = errors.New("probe SLO breached")
+
 func main() {
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT, os.Interrupt)
-	defer cancel()
 
 	cfg := config.Load()
 
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: parseLogLevel(cfg.LogLevel)}))
 	slog.SetDefault(logger)
 
-	client, err := grpc.NewViewServiceClient(ctx, logger, cfg)
+	err := run(ctx, logger, cfg)
 
+	cancel()
+
+	if err != nil {
+		slog.Error("echo-service failed", "error", err.Error())
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, logger *slog.Logger, cfg *config.Config) error {
+	client, err := grpc.NewViewServiceClient(ctx, logger, cfg)
 	if err != nil {
-		slog.Error("Error creating new view client", "error", err.Error())
+		return fmt.Errorf("failed to create view client: %w", err)
 	}
 
-	ticker := time.NewTicker(5 * time.Second)
+	switch cfg.Mode {
+	case modeProbe:
+		return runProbe(ctx, logger, cfg, client)
+	case modeLoad:
+		return runLoad(ctx, logger, cfg, client)
+	}
+
+	return fmt.Errorf("unknown mode %q", cfg.Mode)
+}
+
+func runProbe(ctx context.Context, logger *slog.Logger, cfg *config.Config, client pb.ViewServiceClient) error {
+	reporter := probe.NewReporter(probe.SLO{
+		SuccessRatio:    cfg.Probe.SLO.SuccessRatio,
+		Latency:         cfg.Probe.SLO.Latency,
+		LatencyQuantile: cfg.Probe.SLO.LatencyQuantile,
+		Window:          cfg.Probe.SLO.Window,
+	})
+
+	prober, err := probe.NewProber(logger, client, auth.NewSigner(cfg.JWTSignKey),
+		probe.Target{ResumeID: cfg.Probe.ResumeID, CompanyID: cfg.Probe.CompanyID},
+		cfg.Probe.Scenarios, cfg.Probe.Timeout, reporter)
+	if err != nil {
+		return fmt.Errorf("failed to create prober: %w", err)
+	}
 
-	slog.Info("Starting view client")
+	if cfg.Probe.Once {
+		return runOnce(ctx, prober, reporter, cfg.Probe.Rounds)
+	}
 
-	for {
-		select {
-		case <-ticker.C:
-			resp, _ := client.GetResumeViews(ctx, &pb.GetResumeViewsRequest{})
-			slog.Info("Total views amount: ", "views", resp.GetTotal())
-		case <-ctx.Done():
-			slog.Info("Stopping echo-service")
+	srv := server.New(logger, cfg.Server.Port, reporter)
 
-			return
+	go func() {
+		if err := srv.Run(); err != nil {
+			slog.Error("Error running http server", "error", err.Error())
 		}
+	}()
+
+	slog.Info("Starting probe", "scenarios", cfg.Probe.Scenarios, "interval", cfg.Probe.Interval)
+
+	prober.Run(ctx, cfg.Probe.Interval)
+
+	slog.Info("Stopping echo-service")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return srv.Shutdown(shutdownCtx) //nolint:wrapcheck // already wrapped by the server
+}
+
+// runOnce runs the given number of rounds for CI and fails when a scenario breaches the SLO.
+func runOnce(ctx context.Context, prober *probe.Prober, reporter *probe.Reporter, rounds int) error {
+	for range rounds {
+		prober.Round(ctx)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("probe interrupted: %w", err)
 	}
+
+	status := reporter.Status()
+	slog.Info("Probe finished", "status", status)
+
+	if !status.Healthy {
+		return errSLOBreached
+	}
+
+	return nil
+}
+
+// runLoad runs a load test, prints its report and writes it to the report path, if set.
+func runLoad(ctx context.Context, logger *slog.Logger, cfg *config.Config, client pb.ViewServiceClient) error {
+	stages, err := load.ParseStages(cfg.Load.Stages)
+	if err != nil {
+		return fmt.Errorf("failed to parse load stages: %w", err)
+	}
+
+	generator, err := load.NewGenerator(logger, client, auth.NewSigner(cfg.JWTSignKey), load.Config{
+		Executor:       cfg.Load.Executor,
+		Stages:         stages,
+		MaxConcurrency: cfg.Load.MaxConcurrency,
+		ReadRatio:      cfg.Load.ReadRatio,
+		Resumes:        cfg.Load.Resumes,
+		Companies:      cfg.Load.Companies,
+		Skew:           cfg.Load.ZipfSkew,
+		Timeout:        cfg.Load.Timeout,
+		Seed:           cfg.Load.Seed,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create load generator: %w", err)
+	}
+
+	report := generator.Run(ctx)
+
+	if err = report.Print(os.Stdout); err != nil {
+		return err //nolint:wrapcheck // already wrapped by the report
+	}
+
+	if cfg.Load.ReportPath == "" {
+		return nil
+	}
+
+	return report.WriteFile(cfg.Load.ReportPath) //nolint:wrapcheck // already wrapped by the report
 }
 
 func parseLogLevel(level string) slog.Level {
@@ -2,10 +2,13 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
+// Config of echo-service. Mode is "probe", which probes resume-view continuously or once, or "load",
+// which runs a load test and exits.
 type Config struct {
 	Env                 string `env:"env"`
 	Server              Server
@@ -13,12 +16,53 @@ type Config struct {
 	ClientTimeout       string `env:"CLIENT_TIMEOUT" env-default:"5s"`
 	RetriesCount        string `env:"RETRIES_COUNT" env-default:"3"`
 	LogLevel            string `env:"LOG_LEVEL" env-default:"INFO"`
+	JWTSignKey          string `env:"JWT_SIGN_KEY" env-required:"true"`
+	Mode                string `env:"MODE" env-default:"probe"`
+	Probe               Probe
+	Load                LoadGenerator
+}
+
+// Probe configures the synthetic probe. With Once set it runs Rounds rounds back to back and exits
+// instead of running every Interval. ResumeID and CompanyID should be reserved for the probe.
+type Probe struct {
+	Scenarios []string      `env:"PROBE_SCENARIOS" env-separator:"," env-default:"create_read,stats"`
+	Interval  time.Duration `env:"PROBE_INTERVAL" env-default:"5s"`
+	Timeout   time.Duration `env:"PROBE_TIMEOUT" env-default:"10s"`
+	Once      bool          `env:"PROBE_ONCE" env-default:"false"`
+	Rounds    int           `env:"PROBE_ROUNDS" env-default:"20"`
+	ResumeID  string        `env:"PROBE_RESUME_ID" env-default:"000000000000000000000e40"`
+	CompanyID string        `env:"PROBE_COMPANY_ID" env-default:"00000000-0000-4000-8000-0000000000e4"`
+	SLO       SLO
+}
+
+// SLO is the objective each probe scenario is held to over its last Window runs.
+type SLO struct {
+	SuccessRatio    float64       `env:"PROBE_SLO_SUCCESS_RATIO" env-default:"0.99"`
+	Latency         time.Duration `env:"PROBE_SLO_LATENCY" env-default:"500ms"`
+	LatencyQuantile float64       `env:"PROBE_SLO_LATENCY_QUANTILE" env-default:"0.95"`
+	Window          int           `env:"PROBE_SLO_WINDOW" env-default:"100"`
 }
 
 type Server struct {
 	Port string `env:"SERVER_PORT" env-default:"3008"`
 }
 
+// LoadGenerator configures the "load" mode. Stages are duration:target pairs, where the target is
+// requests per second for the "rps" executor and requests in flight for "concurrency", which may not go
+// above MaxConcurrency.
+type LoadGenerator struct {
+	Executor       string        `env:"LOAD_EXECUTOR" env-default:"rps"`
+	Stages         []string      `env:"LOAD_STAGES" env-separator:"," env-default:"30s:50,1m:50,10s:0"`
+	MaxConcurrency int           `env:"LOAD_MAX_CONCURRENCY" env-default:"200"`
+	ReadRatio      float64       `env:"LOAD_READ_RATIO" env-default:"0.2"`
+	Resumes        int           `env:"LOAD_RESUMES" env-default:"10000"`
+	Companies      int           `env:"LOAD_COMPANIES" env-default:"1000"`
+	ZipfSkew       float64       `env:"LOAD_ZIPF_SKEW" env-default:"1.1"`
+	Timeout        time.Duration `env:"LOAD_TIMEOUT" env-default:"5s"`
+	Seed           int64         `env:"LOAD_SEED" env-default:"1"`
+	ReportPath     string        `env:"LOAD_REPORT_PATH"`
+}
+
 func Load() *Config {
 	var cfg Config
 
@@ -0,0 +1,266 @@
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Verce11o/resume-view/echo-service/internal/auth"
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/google/uuid"
+	grpcretry "github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/retry"
+	"google.golang.org/grpc/status"
+)
+
+// Executors drive the load. ExecutorRate sends stage targets as requests per second from a pool of
+// MaxConcurrency workers, ExecutorConcurrency keeps stage targets of requests in flight, up to
+// MaxConcurrency of them.
+const (
+	ExecutorRate        = "rps"
+	ExecutorConcurrency = "concurrency"
+)
+
+const (
+	loadUserID   = "echo-service-load"
+	userAgent    = "echo-service/load"
+	listPageSize = 20
+
+	// tick is how often the executors adjust to the stage target.
+	tick = 10 * time.Millisecond
+)
+
+type Config struct {
+	Executor       string
+	Stages         []Stage
+	MaxConcurrency int
+	ReadRatio      float64
+	Resumes        int
+	Companies      int
+	Skew           float64
+	Timeout        time.Duration
+	Seed           int64
+}
+
+func (c Config) validate() error {
+	switch {
+	case c.Executor != ExecutorRate && c.Executor != ExecutorConcurrency:
+		return fmt.Errorf("unknown load executor %q", c.Executor)
+	case len(c.Stages) == 0:
+		return errors.New("no load stages configured")
+	case c.MaxConcurrency < 1:
+		return errors.New("max concurrency must be at least 1")
+	case c.Executor == ExecutorConcurrency && maxTarget(c.Stages) > c.MaxConcurrency:
+		return fmt.Errorf("stage target %d is above the max concurrency %d", maxTarget(c.Stages), c.MaxConcurrency)
+	case c.ReadRatio < 0 || c.ReadRatio > 1:
+		return errors.New("read ratio must be between 0 and 1")
+	case c.Resumes < 1 || c.Companies < 1:
+		return errors.New("there must be at least one resume and one company")
+	case c.Skew <= 1:
+		return errors.New("zipf skew must be greater than 1")
+	}
+
+	return nil
+}
+
+// Generator sends CreateView and GetResumeViews requests to resume-view following the configured stages.
+// Requests are not retried, so every attempt is measured. Companies share resume-view's rate limit and
+// abuse rules with real traffic, so a heavy load shows up as ResourceExhausted codes and suspicious views.
+type Generator struct {
+	log      *slog.Logger
+	client   pb.ViewServiceClient
+	signer   *auth.Signer
+	cfg      Config
+	requests *requests
+	recorder *recorder
+}
+
+func NewGenerator(log *slog.Logger, client pb.ViewServiceClient, signer *auth.Signer, cfg Config) (*Generator,
+	error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &Generator{
+		log:      log,
+		client:   client,
+		signer:   signer,
+		cfg:      cfg,
+		requests: newRequests(cfg.Seed, cfg.Resumes, cfg.Companies, cfg.Skew, cfg.ReadRatio),
+		recorder: newRecorder(),
+	}, nil
+}
+
+// Run drives the load through every stage, or until ctx is done, and reports the requests it sent.
+// Requests still in flight at the end of the last stage are waited for.
+func (g *Generator) Run(ctx context.Context) Report {
+	start := time.Now()
+	end := start.Add(totalDuration(g.cfg.Stages))
+
+	g.log.Info("Starting load", "executor", g.cfg.Executor, "stages", len(g.cfg.Stages), "until", end)
+
+	if g.cfg.Executor == ExecutorRate {
+		g.runRate(ctx, start, end)
+	} else {
+		g.runConcurrency(ctx, start, end)
+	}
+
+	report := g.recorder.report(time.Since(start))
+	report.StartedAt = start
+	report.Executor = g.cfg.Executor
+
+	for _, stage := range g.cfg.Stages {
+		report.Stages = append(report.Stages, StageReport{Duration: stage.Duration.String(), Target: stage.Target})
+	}
+
+	return report
+}
+
+// runRate sends the requests due at the stage rate to idle workers. A request due while every worker is
+// busy is dropped rather than delayed, so a slow service cannot lower the offered load unnoticed.
+func (g *Generator) runRate(ctx context.Context, start, end time.Time) {
+	jobs := make(chan request)
+
+	var wg sync.WaitGroup
+
+	for range g.cfg.MaxConcurrency {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for req := range jobs {
+				g.send(ctx, req)
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	var (
+		due    float64
+		issued int
+		last   = start
+	)
+
+	for now := start; now.Before(end); {
+		select {
+		case <-ctx.Done():
+			close(jobs)
+			wg.Wait()
+
+			return
+		case now = <-ticker.C:
+		}
+
+		due += targetAt(g.cfg.Stages, now.Sub(start)) * now.Sub(last).Seconds()
+		last = now
+
+		for ; issued < int(math.Floor(due)); issued++ {
+			select {
+			case jobs <- g.requests.next():
+			default:
+				g.recorder.drop()
+			}
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+}
+
+// runConcurrency keeps as many workers sending back to back as the stage target calls for.
+func (g *Generator) runConcurrency(ctx context.Context, start, end time.Time) {
+	var (
+		active atomic.Int64
+		wg     sync.WaitGroup
+	)
+
+	stagesCtx, cancel := context.WithDeadline(ctx, end)
+	defer cancel()
+
+	for i := range int64(maxTarget(g.cfg.Stages)) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for stagesCtx.Err() == nil {
+				if i >= active.Load() {
+					select {
+					case <-time.After(tick):
+					case <-stagesCtx.Done():
+					}
+
+					continue
+				}
+
+				g.send(ctx, g.requests.next())
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+
+	for {
+		active.Store(int64(math.Round(targetAt(g.cfg.Stages, time.Since(start)))))
+
+		select {
+		case <-ticker.C:
+		case <-stagesCtx.Done():
+			wg.Wait()
+
+			return
+		}
+	}
+}
+
+// send sends req and records its outcome. Requests cut short because ctx is done are not recorded.
+func (g *Generator) send(ctx context.Context, req request) {
+	callCtx, cancel := context.WithTimeout(ctx, g.cfg.Timeout)
+	defer cancel()
+
+	callCtx, err := g.signer.Authorize(callCtx, auth.Claims{
+		UserID:    loadUserID,
+		CompanyID: req.companyID,
+		ResumeIDs: []string{req.resumeID},
+	})
+	if err != nil {
+		g.log.Error("Failed to authorize load request", "error", err)
+
+		return
+	}
+
+	start := time.Now()
+
+	switch req.operation {
+	case OperationCreateView:
+		_, err = g.client.CreateView(callCtx, &pb.CreateViewRequest{
+			ResumeId:       req.resumeID,
+			CompanyId:      req.companyID,
+			IdempotencyKey: uuid.NewString(),
+			Source:         pb.ViewSource_VIEW_SOURCE_API,
+			UserAgent:      userAgent,
+		}, grpcretry.Disable())
+	case OperationGetResumeViews:
+		_, err = g.client.GetResumeViews(callCtx, &pb.GetResumeViewsRequest{
+			ResumeId: req.resumeID,
+			Sort:     pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+			PageSize: listPageSize,
+		}, grpcretry.Disable())
+	}
+
+	duration := time.Since(start)
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	g.recorder.record(req.operation, duration, status.Code(err))
+}
@@ -0,0 +1,73 @@
+//go:build !integration
+
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	valid := Config{
+		Executor:       ExecutorConcurrency,
+		Stages:         []Stage{{Duration: time.Second, Target: 10}},
+		MaxConcurrency: 10,
+		ReadRatio:      0.2,
+		Resumes:        10,
+		Companies:      10,
+		Skew:           1.1,
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr bool
+	}{
+		{
+			name:   "Valid",
+			modify: func(*Config) {},
+		},
+		{
+			name: "Concurrency target above the max concurrency",
+			modify: func(cfg *Config) {
+				cfg.Stages = []Stage{{Duration: time.Second, Target: 5}, {Duration: time.Second, Target: 11}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rate target above the max concurrency",
+			modify: func(cfg *Config) {
+				cfg.Executor = ExecutorRate
+				cfg.Stages = []Stage{{Duration: time.Second, Target: 100}}
+			},
+		},
+		{
+			name: "Unknown executor",
+			modify: func(cfg *Config) {
+				cfg.Executor = "open"
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := valid
+			tt.modify(&cfg)
+
+			if tt.wantErr {
+				assert.Error(t, cfg.validate())
+
+				return
+			}
+
+			assert.NoError(t, cfg.validate())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package load
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Latency holds latency quantiles in milliseconds.
+type Latency struct {
+	P50 float64 `json:"p50_ms"`
+	P95 float64 `json:"p95_ms"`
+	P99 float64 `json:"p99_ms"`
+	Max float64 `json:"max_ms"`
+}
+
+// OperationReport sums up the requests of one operation. Latency covers failed requests too, and Codes
+// counts the requests by gRPC status code.
+type OperationReport struct {
+	Requests int            `json:"requests"`
+	Errors   int            `json:"errors"`
+	RPS      float64        `json:"rps"`
+	Latency  Latency        `json:"latency"`
+	Codes    map[string]int `json:"codes"`
+}
+
+type StageReport struct {
+	Duration string `json:"duration"`
+	Target   int    `json:"target"`
+}
+
+// Report is the result of a load run. It is written as JSON with sorted keys, so reports of different
+// releases diff cleanly. Dropped counts the requests the rate executor could not send because every
+// worker was busy.
+type Report struct {
+	StartedAt  time.Time                  `json:"started_at"`
+	Duration   float64                    `json:"duration_seconds"`
+	Executor   string                     `json:"executor"`
+	Stages     []StageReport              `json:"stages"`
+	Requests   int                        `json:"requests"`
+	Errors     int                        `json:"errors"`
+	Dropped    int                        `json:"dropped"`
+	RPS        float64                    `json:"rps"`
+	Operations map[string]OperationReport `json:"operations"`
+}
+
+// WriteFile writes the report as indented JSON to path.
+func (r Report) WriteFile(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode load report: %w", err)
+	}
+
+	if err = os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write load report: %w", err)
+	}
+
+	return nil
+}
+
+// Print writes the latency and error breakdown of every operation as a table.
+func (r Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "%s load for %.1fs: %d requests, %d errors, %d dropped, %.1f rps\n\n",
+		r.Executor, r.Duration, r.Requests, r.Errors, r.Dropped, r.RPS)
+	fmt.Fprintln(tw, "OPERATION\tREQUESTS\tERRORS\tRPS\tP50\tP95\tP99\tMAX\tCODES")
+
+	operations := make([]string, 0, len(r.Operations))
+	for operation := range r.Operations {
+		operations = append(operations, operation)
+	}
+
+	sort.Strings(operations)
+
+	for _, operation := range operations {
+		op := r.Operations[operation]
+
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%.1fms\t%.1fms\t%.1fms\t%.1fms\t%s\n", operation, op.Requests,
+			op.Errors, op.RPS, op.Latency.P50, op.Latency.P95, op.Latency.P99, op.Latency.Max, formatCodes(op.Codes))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("failed to print load report: %w", err)
+	}
+
+	return nil
+}
+
+func formatCodes(counts map[string]int) string {
+	formatted := make([]string, 0, len(counts))
+	for code, n := range counts {
+		formatted = append(formatted, fmt.Sprintf("%s=%d", code, n))
+	}
+
+	sort.Strings(formatted)
+
+	return strings.Join(formatted, " ")
+}
+
+type results struct {
+	durations []time.Duration
+	codes     map[codes.Code]int
+}
+
+// recorder collects the outcome of every request sent.
+type recorder struct {
+	mu         sync.Mutex
+	operations map[string]*results
+	dropped    int
+}
+
+func newRecorder() *recorder {
+	return &recorder{operations: make(map[string]*results)}
+}
+
+func (r *recorder) record(operation string, duration time.Duration, code codes.Code) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	res, ok := r.operations[operation]
+	if !ok {
+		res = &results{codes: make(map[codes.Code]int)}
+		r.operations[operation] = res
+	}
+
+	res.durations = append(res.durations, duration)
+	res.codes[code]++
+}
+
+func (r *recorder) drop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dropped++
+}
+
+// report sums up the recorded requests of a run that took elapsed.
+func (r *recorder) report(elapsed time.Duration) Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := Report{
+		Duration:   elapsed.Seconds(),
+		Dropped:    r.dropped,
+		Operations: make(map[string]OperationReport, len(r.operations)),
+	}
+
+	for operation, res := range r.operations {
+		op := OperationReport{
+			Requests: len(res.durations),
+			RPS:      float64(len(res.durations)) / elapsed.Seconds(),
+			Latency:  latency(res.durations),
+			Codes:    make(map[string]int, len(res.codes)),
+		}
+
+		for code, n := range res.codes {
+			op.Codes[code.String()] = n
+
+			if code != codes.OK {
+				op.Errors += n
+			}
+		}
+
+		report.Requests += op.Requests
+		report.Errors += op.Errors
+		report.Operations[operation] = op
+	}
+
+	report.RPS = float64(report.Requests) / elapsed.Seconds()
+
+	return report
+}
+
+func latency(durations []time.Duration) Latency {
+	sorted := slices.Clone(durations)
+	slices.Sort(sorted)
+
+	return Latency{
+		P50: milliseconds(quantile(sorted, 0.5)),
+		P95: milliseconds(quantile(sorted, 0.95)),
+		P99: milliseconds(quantile(sorted, 0.99)),
+		Max: milliseconds(sorted[len(sorted)-1]),
+	}
+}
+
+// quantile returns the nearest-rank quantile q of sorted durations.
+func quantile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+
+	return sorted[max(rank, 0)]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,88 @@
+//go:build !integration
+
+package load
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestRecorder_Report(t *testing.T) {
+	t.Parallel()
+
+	recorder := newRecorder()
+
+	for i := 1; i <= 100; i++ {
+		code := codes.OK
+		if i%10 == 0 {
+			code = codes.ResourceExhausted
+		}
+
+		recorder.record(OperationCreateView, time.Duration(i)*time.Millisecond, code)
+	}
+
+	recorder.record(OperationGetResumeViews, 3*time.Millisecond, codes.NotFound)
+	recorder.drop()
+
+	report := recorder.report(10 * time.Second)
+
+	assert.Equal(t, 101, report.Requests)
+	assert.Equal(t, 11, report.Errors)
+	assert.Equal(t, 1, report.Dropped)
+	assert.InDelta(t, 10.1, report.RPS, 1e-9)
+	assert.Equal(t, OperationReport{
+		Requests: 100,
+		Errors:   10,
+		RPS:      10,
+		Latency:  Latency{P50: 50, P95: 95, P99: 99, Max: 100},
+		Codes:    map[string]int{"OK": 90, "ResourceExhausted": 10},
+	}, report.Operations[OperationCreateView])
+	assert.Equal(t, map[string]int{"NotFound": 1}, report.Operations[OperationGetResumeViews].Codes)
+
+	var out bytes.Buffer
+	require.NoError(t, report.Print(&out))
+	assert.Regexp(t, `create_view +100 +10 +10.0 +50.0ms +95.0ms +99.0ms +100.0ms +OK=90 ResourceExhausted=10`,
+		out.String())
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, report.WriteFile(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var written Report
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.Equal(t, report.Operations, written.Operations)
+}
+
+func TestRequests_Next(t *testing.T) {
+	t.Parallel()
+
+	requests := newRequests(1, 1000, 100, 1.5, 0.25)
+	resumes := make(map[string]int)
+	reads := 0
+
+	for range 10000 {
+		req := requests.next()
+
+		assert.Regexp(t, `^10ad[0-9a-f]{20}$`, req.resumeID)
+		assert.Regexp(t, `^10ad0000-0000-4000-8000-[0-9a-f]{12}$`, req.companyID)
+
+		resumes[req.resumeID]++
+
+		if req.operation == OperationGetResumeViews {
+			reads++
+		}
+	}
+
+	assert.InDelta(t, 2500, reads, 250)
+	assert.Greater(t, resumes["10ad00000000000000000000"], 1000, "the most popular resume gets the most views")
+}
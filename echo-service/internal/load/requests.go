@@ -0,0 +1,57 @@
+package load
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+)
+
+// Operations the generator sends, as named in the report.
+const (
+	OperationCreateView     = "create_view"
+	OperationGetResumeViews = "get_resume_views"
+)
+
+type request struct {
+	operation string
+	resumeID  string
+	companyID string
+}
+
+// requests draws the generated requests. Resume and company ids follow Zipf distributions, so a few
+// popular resumes get most of the views and a few companies record most of them, as in real traffic.
+// The ids are derived from their rank and start with 10ad, so load data is easy to tell apart.
+type requests struct {
+	mu        sync.Mutex
+	rand      *rand.Rand
+	resumes   *rand.Zipf
+	companies *rand.Zipf
+	readRatio float64
+}
+
+func newRequests(seed int64, resumes, companies int, skew, readRatio float64) *requests {
+	r := rand.New(rand.NewSource(seed)) //nolint:gosec // load shape, not security
+
+	return &requests{
+		rand:      r,
+		resumes:   rand.NewZipf(r, skew, 1, uint64(resumes-1)),
+		companies: rand.NewZipf(r, skew, 1, uint64(companies-1)),
+		readRatio: readRatio,
+	}
+}
+
+func (r *requests) next() request {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	operation := OperationCreateView
+	if r.rand.Float64() < r.readRatio {
+		operation = OperationGetResumeViews
+	}
+
+	return request{
+		operation: operation,
+		resumeID:  fmt.Sprintf("10ad%020x", r.resumes.Uint64()),
+		companyID: fmt.Sprintf("10ad0000-0000-4000-8000-%012x", r.companies.Uint64()),
+	}
+}
@@ -0,0 +1,84 @@
+package load
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Stage moves the load linearly from the previous stage's target, or zero for the first stage, to Target
+// over Duration. A stage with the same target as the one before holds the load steady.
+type Stage struct {
+	Duration time.Duration
+	Target   int
+}
+
+// ParseStages parses stages written as duration:target, such as 30s:100.
+func ParseStages(values []string) ([]Stage, error) {
+	stages := make([]Stage, 0, len(values))
+
+	for _, value := range values {
+		rawDuration, rawTarget, ok := strings.Cut(strings.TrimSpace(value), ":")
+		if !ok {
+			return nil, fmt.Errorf("stage %q is not duration:target", value)
+		}
+
+		duration, err := time.ParseDuration(rawDuration)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("stage %q must have a positive duration", value)
+		}
+
+		target, err := strconv.Atoi(rawTarget)
+		if err != nil || target < 0 {
+			return nil, fmt.Errorf("stage %q must have a target of zero or more", value)
+		}
+
+		stages = append(stages, Stage{Duration: duration, Target: target})
+	}
+
+	if len(stages) == 0 {
+		return nil, errors.New("no load stages configured")
+	}
+
+	return stages, nil
+}
+
+// totalDuration returns how long all stages take together.
+func totalDuration(stages []Stage) time.Duration {
+	var total time.Duration
+	for _, stage := range stages {
+		total += stage.Duration
+	}
+
+	return total
+}
+
+// maxTarget returns the highest target of the stages.
+func maxTarget(stages []Stage) int {
+	var highest int
+	for _, stage := range stages {
+		highest = max(highest, stage.Target)
+	}
+
+	return highest
+}
+
+// targetAt returns the load the stages call for after elapsed.
+func targetAt(stages []Stage, elapsed time.Duration) float64 {
+	var previous float64
+
+	for _, stage := range stages {
+		target := float64(stage.Target)
+
+		if elapsed < stage.Duration {
+			return previous + (target-previous)*float64(elapsed)/float64(stage.Duration)
+		}
+
+		elapsed -= stage.Duration
+		previous = target
+	}
+
+	return previous
+}
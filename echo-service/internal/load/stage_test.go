@@ -0,0 +1,82 @@
+//go:build !integration
+
+package load
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		values  []string
+		stages  []Stage
+		wantErr bool
+	}{
+		{
+			name:   "Ramp up and down",
+			values: []string{"30s:100", " 1m:100", "10s:0"},
+			stages: []Stage{
+				{Duration: 30 * time.Second, Target: 100},
+				{Duration: time.Minute, Target: 100},
+				{Duration: 10 * time.Second, Target: 0},
+			},
+		},
+		{name: "No stages", wantErr: true},
+		{name: "No target", values: []string{"30s"}, wantErr: true},
+		{name: "Malformed duration", values: []string{"soon:10"}, wantErr: true},
+		{name: "Zero duration", values: []string{"0s:10"}, wantErr: true},
+		{name: "Negative target", values: []string{"1s:-1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stages, err := ParseStages(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.stages, stages)
+		})
+	}
+}
+
+func TestTargetAt(t *testing.T) {
+	t.Parallel()
+
+	stages := []Stage{
+		{Duration: 10 * time.Second, Target: 100},
+		{Duration: 20 * time.Second, Target: 100},
+		{Duration: 10 * time.Second, Target: 50},
+	}
+
+	assert.Equal(t, 40*time.Second, totalDuration(stages))
+	assert.Equal(t, 100, maxTarget(stages))
+
+	tests := []struct {
+		elapsed time.Duration
+		target  float64
+	}{
+		{elapsed: 0, target: 0},
+		{elapsed: 5 * time.Second, target: 50},
+		{elapsed: 10 * time.Second, target: 100},
+		{elapsed: 25 * time.Second, target: 100},
+		{elapsed: 35 * time.Second, target: 75},
+		{elapsed: time.Minute, target: 50},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.target, targetAt(stages, tt.elapsed), 1e-9, "after %s", tt.elapsed)
+	}
+}
@@ -0,0 +1,119 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/Verce11o/resume-view/echo-service/internal/auth"
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+)
+
+const probeUserID = "echo-service"
+
+type namedScenario struct {
+	name string
+	run  Scenario
+}
+
+// Prober runs the configured scenarios against resume-view and reports every run.
+type Prober struct {
+	log       *slog.Logger
+	client    pb.ViewServiceClient
+	signer    *auth.Signer
+	target    Target
+	timeout   time.Duration
+	scenarios []namedScenario
+	reporter  *Reporter
+}
+
+// NewProber returns a prober running the named scenarios in order. Each run may take up to timeout.
+func NewProber(log *slog.Logger, client pb.ViewServiceClient, signer *auth.Signer, target Target,
+	names []string, timeout time.Duration, reporter *Reporter) (*Prober, error) {
+	if len(names) == 0 {
+		return nil, errors.New("no probe scenarios configured")
+	}
+
+	named := make([]namedScenario, 0, len(names))
+
+	for _, name := range names {
+		scenario, ok := scenarios[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown probe scenario %q", name)
+		}
+
+		named = append(named, namedScenario{name: name, run: scenario})
+	}
+
+	return &Prober{
+		log:       log,
+		client:    client,
+		signer:    signer,
+		target:    target,
+		timeout:   timeout,
+		scenarios: named,
+		reporter:  reporter,
+	}, nil
+}
+
+// Run runs a round every interval until ctx is done.
+func (p *Prober) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		p.Round(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Round runs every scenario once, one after another.
+func (p *Prober) Round(ctx context.Context) {
+	for _, scenario := range p.scenarios {
+		if ctx.Err() != nil {
+			return
+		}
+
+		start := time.Now()
+		err := p.run(ctx, scenario.run)
+		duration := time.Since(start)
+
+		// A run cut short by shutdown says nothing about the service.
+		if ctx.Err() != nil {
+			return
+		}
+
+		p.reporter.Record(scenario.name, duration, err)
+
+		if err != nil {
+			p.log.Warn("Probe scenario failed", "scenario", scenario.name, "duration", duration, "error", err)
+
+			continue
+		}
+
+		p.log.Debug("Probe scenario passed", "scenario", scenario.name, "duration", duration)
+	}
+}
+
+func (p *Prober) run(ctx context.Context, scenario Scenario) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	ctx, err := p.signer.Authorize(ctx, auth.Claims{
+		UserID:    probeUserID,
+		CompanyID: p.target.CompanyID,
+		ResumeIDs: []string{p.target.ResumeID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to authorize probe: %w", err)
+	}
+
+	return scenario(ctx, p.client, p.target)
+}
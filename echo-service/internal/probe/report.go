@@ -0,0 +1,251 @@
+package probe
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Results of a scenario run, as labelled in probe_runs_total.
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+)
+
+// SLO is the objective every scenario is held to over its last Window runs.
+type SLO struct {
+	SuccessRatio    float64
+	Latency         time.Duration
+	LatencyQuantile float64
+	Window          int
+}
+
+// Latency holds latency quantiles in milliseconds.
+type Latency struct {
+	P50 float64 `json:"p50_ms"`
+	P95 float64 `json:"p95_ms"`
+	P99 float64 `json:"p99_ms"`
+}
+
+// ScenarioStatus describes a scenario over the SLO window, apart from Runs and Failures, which count
+// every run since start.
+type ScenarioStatus struct {
+	Runs         int       `json:"runs"`
+	Failures     int       `json:"failures"`
+	SuccessRatio float64   `json:"success_ratio"`
+	Latency      Latency   `json:"latency"`
+	Breaches     []string  `json:"breaches,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+	LastRunAt    time.Time `json:"last_run_at"`
+}
+
+// Status is served on the status page. Healthy is false when any scenario breaches the SLO.
+type Status struct {
+	Healthy   bool                      `json:"healthy"`
+	SLO       SLOStatus                 `json:"slo"`
+	Scenarios map[string]ScenarioStatus `json:"scenarios"`
+}
+
+type SLOStatus struct {
+	SuccessRatio    float64 `json:"success_ratio"`
+	LatencyMS       float64 `json:"latency_ms"`
+	LatencyQuantile float64 `json:"latency_quantile"`
+	Window          int     `json:"window"`
+}
+
+// window is a ring of the last runs of a scenario.
+type window struct {
+	durations []time.Duration
+	failed    []bool
+	next      int
+	runs      int
+	failures  int
+	lastError string
+	lastRunAt time.Time
+}
+
+func (w *window) add(size int, duration time.Duration, err error) {
+	if len(w.durations) < size {
+		w.durations = append(w.durations, duration)
+		w.failed = append(w.failed, err != nil)
+	} else {
+		w.durations[w.next] = duration
+		w.failed[w.next] = err != nil
+	}
+
+	w.next = (w.next + 1) % size
+	w.runs++
+	w.lastRunAt = time.Now()
+	w.lastError = ""
+
+	if err != nil {
+		w.failures++
+		w.lastError = err.Error()
+	}
+}
+
+func (w *window) successRatio() float64 {
+	var failed int
+
+	for _, f := range w.failed {
+		if f {
+			failed++
+		}
+	}
+
+	return 1 - float64(failed)/float64(len(w.failed))
+}
+
+// quantile returns the nearest-rank quantile q of the durations in the window.
+func (w *window) quantile(q float64) time.Duration {
+	sorted := slices.Clone(w.durations)
+	slices.Sort(sorted)
+
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+
+	return sorted[max(rank, 0)]
+}
+
+// Reporter records scenario runs as Prometheus metrics and evaluates them against the SLO.
+type Reporter struct {
+	slo          SLO
+	registry     *prometheus.Registry
+	runs         *prometheus.CounterVec
+	duration     *prometheus.HistogramVec
+	successRatio *prometheus.GaugeVec
+	breached     *prometheus.GaugeVec
+
+	mu      sync.Mutex
+	windows map[string]*window
+}
+
+// NewReporter returns a reporter holding scenarios to slo. A window smaller than one run is raised to one.
+func NewReporter(slo SLO) *Reporter {
+	slo.Window = max(slo.Window, 1)
+
+	r := &Reporter{
+		slo:      slo,
+		registry: prometheus.NewRegistry(),
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "probe_runs_total",
+			Help: "Total number of probe scenario runs by result",
+		}, []string{"scenario", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "probe_duration_seconds",
+			Help:    "Latency of probe scenario runs",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"scenario"}),
+		successRatio: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "probe_success_ratio",
+			Help: "Ratio of successful probe scenario runs within the SLO window",
+		}, []string{"scenario"}),
+		breached: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "probe_slo_breached",
+			Help: "Whether a probe scenario breaches its SLO",
+		}, []string{"scenario"}),
+		windows: make(map[string]*window),
+	}
+
+	r.registry.MustRegister(r.runs, r.duration, r.successRatio, r.breached)
+
+	return r
+}
+
+// Registry returns the registry holding the probe metrics.
+func (r *Reporter) Registry() *prometheus.Registry {
+	return r.registry
+}
+
+// Record adds a run of scenario that took duration and failed with err, if not nil.
+func (r *Reporter) Record(scenario string, duration time.Duration, err error) {
+	result := resultSuccess
+	if err != nil {
+		result = resultFailure
+	}
+
+	r.runs.WithLabelValues(scenario, result).Inc()
+	r.duration.WithLabelValues(scenario).Observe(duration.Seconds())
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.windows[scenario]
+	if !ok {
+		w = &window{}
+		r.windows[scenario] = w
+	}
+
+	w.add(r.slo.Window, duration, err)
+
+	r.successRatio.WithLabelValues(scenario).Set(w.successRatio())
+
+	breached := 0.0
+	if len(r.breaches(w)) > 0 {
+		breached = 1
+	}
+
+	r.breached.WithLabelValues(scenario).Set(breached)
+}
+
+// Status reports every scenario that has run so far.
+func (r *Reporter) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := Status{
+		Healthy: true,
+		SLO: SLOStatus{
+			SuccessRatio:    r.slo.SuccessRatio,
+			LatencyMS:       milliseconds(r.slo.Latency),
+			LatencyQuantile: r.slo.LatencyQuantile,
+			Window:          r.slo.Window,
+		},
+		Scenarios: make(map[string]ScenarioStatus, len(r.windows)),
+	}
+
+	for scenario, w := range r.windows {
+		breaches := r.breaches(w)
+		if len(breaches) > 0 {
+			status.Healthy = false
+		}
+
+		status.Scenarios[scenario] = ScenarioStatus{
+			Runs:         w.runs,
+			Failures:     w.failures,
+			SuccessRatio: w.successRatio(),
+			Latency: Latency{
+				P50: milliseconds(w.quantile(0.5)),
+				P95: milliseconds(w.quantile(0.95)),
+				P99: milliseconds(w.quantile(0.99)),
+			},
+			Breaches:  breaches,
+			LastError: w.lastError,
+			LastRunAt: w.lastRunAt,
+		}
+	}
+
+	return status
+}
+
+func (r *Reporter) breaches(w *window) []string {
+	var breaches []string
+
+	if ratio := w.successRatio(); ratio < r.slo.SuccessRatio {
+		breaches = append(breaches, fmt.Sprintf("success ratio %.3f is below %.3f", ratio, r.slo.SuccessRatio))
+	}
+
+	if latency := w.quantile(r.slo.LatencyQuantile); latency > r.slo.Latency {
+		breaches = append(breaches, fmt.Sprintf("p%g latency %s is above %s",
+			r.slo.LatencyQuantile*100, latency, r.slo.Latency))
+	}
+
+	return breaches
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
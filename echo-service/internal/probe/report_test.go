@@ -0,0 +1,103 @@
+//go:build !integration
+
+package probe
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReporter_Status(t *testing.T) {
+	t.Parallel()
+
+	slo := SLO{SuccessRatio: 0.75, Latency: 100 * time.Millisecond, LatencyQuantile: 0.5, Window: 4}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		failures  []bool
+		healthy   bool
+		breaches  int
+	}{
+		{
+			name:      "Within the SLO",
+			durations: []time.Duration{10, 20, 30, 200},
+			failures:  []bool{false, true, false, false},
+			healthy:   true,
+		},
+		{
+			name:      "Too many failures",
+			durations: []time.Duration{10, 20, 30, 40},
+			failures:  []bool{true, true, false, false},
+			breaches:  1,
+		},
+		{
+			name:      "Too slow",
+			durations: []time.Duration{10, 200, 300, 400},
+			failures:  []bool{false, false, false, false},
+			breaches:  1,
+		},
+		{
+			name:      "Only the window counts",
+			durations: []time.Duration{500, 500, 500, 10, 20, 30, 40},
+			failures:  []bool{true, true, true, false, false, false, false},
+			healthy:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := NewReporter(slo)
+
+			for i, duration := range tt.durations {
+				var err error
+				if tt.failures[i] {
+					err = assert.AnError
+				}
+
+				reporter.Record(ScenarioCreateRead, duration*time.Millisecond, err)
+			}
+
+			status := reporter.Status()
+
+			assert.Equal(t, tt.healthy, status.Healthy)
+			require.Contains(t, status.Scenarios, ScenarioCreateRead)
+
+			scenario := status.Scenarios[ScenarioCreateRead]
+			assert.Len(t, scenario.Breaches, tt.breaches)
+			assert.Equal(t, len(tt.durations), scenario.Runs)
+
+			breached := 1.0
+			if tt.healthy {
+				breached = 0
+			}
+
+			assert.InDelta(t, breached, testutil.ToFloat64(reporter.breached.WithLabelValues(ScenarioCreateRead)), 0)
+			assert.InDelta(t, float64(len(tt.durations)), testutil.ToFloat64(reporter.runs.WithLabelValues(
+				ScenarioCreateRead, resultSuccess))+testutil.ToFloat64(reporter.runs.WithLabelValues(
+				ScenarioCreateRead, resultFailure)), 0)
+		})
+	}
+}
+
+func TestReporter_StatusLatency(t *testing.T) {
+	t.Parallel()
+
+	reporter := NewReporter(SLO{SuccessRatio: 1, Latency: time.Second, LatencyQuantile: 0.99, Window: 100})
+
+	for i := 1; i <= 100; i++ {
+		reporter.Record(ScenarioStats, time.Duration(i)*time.Millisecond, nil)
+	}
+
+	status := reporter.Status()
+
+	assert.True(t, status.Healthy)
+	assert.Equal(t, Latency{P50: 50, P95: 95, P99: 99}, status.Scenarios[ScenarioStats].Latency)
+	assert.InDelta(t, 1, status.Scenarios[ScenarioStats].SuccessRatio, 0)
+}
@@ -0,0 +1,135 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Names of the scenarios PROBE_SCENARIOS selects from.
+const (
+	ScenarioCreateRead = "create_read"
+	ScenarioStats      = "stats"
+)
+
+const (
+	userAgent   = "echo-service/probe"
+	statsWindow = 24 * time.Hour
+)
+
+// Target is the resume and company the probe records and reads views for. Both should be reserved for
+// the probe, so no other traffic moves the totals it checks.
+type Target struct {
+	ResumeID  string
+	CompanyID string
+}
+
+// Scenario is one check against resume-view. It returns an error when a call fails or the answers
+// are inconsistent.
+type Scenario func(ctx context.Context, client pb.ViewServiceClient, target Target) error
+
+var scenarios = map[string]Scenario{
+	ScenarioCreateRead: createRead,
+	ScenarioStats:      stats,
+}
+
+// createRead records a view, then reads it back and checks the company's total moved by exactly one.
+// A view collapsed into an earlier one must leave the total as it was.
+func createRead(ctx context.Context, client pb.ViewServiceClient, target Target) error {
+	before, _, err := latestView(ctx, client, target)
+	if err != nil {
+		return err
+	}
+
+	created, err := client.CreateView(ctx, &pb.CreateViewRequest{
+		ResumeId:       target.ResumeID,
+		CompanyId:      target.CompanyID,
+		IdempotencyKey: uuid.NewString(),
+		Source:         pb.ViewSource_VIEW_SOURCE_API,
+		UserAgent:      userAgent,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create view: %w", err)
+	}
+
+	after, latest, err := latestView(ctx, client, target)
+	if err != nil {
+		return err
+	}
+
+	want := before + 1
+	if created.GetCollapsed() {
+		want = before
+	}
+
+	if after != want {
+		return fmt.Errorf("total is %d after creating view %s, want %d", after, created.GetViewId(), want)
+	}
+
+	if !created.GetCollapsed() && latest != created.GetViewId() {
+		return fmt.Errorf("latest view is %q, want the created view %s", latest, created.GetViewId())
+	}
+
+	return nil
+}
+
+// latestView returns the total of the target company's views of the resume and the id of the newest one.
+// A resume without views yet has a total of zero.
+func latestView(ctx context.Context, client pb.ViewServiceClient, target Target) (int32, string, error) {
+	resp, err := client.GetResumeViews(ctx, &pb.GetResumeViewsRequest{
+		ResumeId:  target.ResumeID,
+		CompanyId: target.CompanyID,
+		Sort:      pb.SortOrder_SORT_ORDER_NEWEST_FIRST,
+		PageSize:  1,
+	})
+	if status.Code(err) == codes.NotFound {
+		return 0, "", nil
+	}
+
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to get resume views: %w", err)
+	}
+
+	if len(resp.GetViews()) == 0 {
+		return resp.GetTotal(), "", nil
+	}
+
+	return resp.GetTotal(), resp.GetViews()[0].GetViewId(), nil
+}
+
+// stats reads the hourly stats of the last day and checks the buckets add up to the total.
+func stats(ctx context.Context, client pb.ViewServiceClient, target Target) error {
+	to := time.Now()
+
+	resp, err := client.GetResumeViewStats(ctx, &pb.GetResumeViewStatsRequest{
+		ResumeId: target.ResumeID,
+		From:     timestamppb.New(to.Add(-statsWindow)),
+		To:       timestamppb.New(to),
+		Interval: pb.StatsInterval_STATS_INTERVAL_HOUR,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get resume view stats: %w", err)
+	}
+
+	var sum int32
+	for _, bucket := range resp.GetBuckets() {
+		sum += bucket.GetCount()
+	}
+
+	if sum != resp.GetTotal() {
+		return fmt.Errorf("buckets add up to %d, want the total %d", sum, resp.GetTotal())
+	}
+
+	if resp.GetUniqueCompanies() > resp.GetTotal() {
+		return errors.New("more unique companies than views")
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+//go:build !integration
+
+package probe
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeViewClient keeps the views of one company and resume. count overrides how many views a new view
+// adds to the total, to fake a service that loses or double counts views.
+type fakeViewClient struct {
+	pb.ViewServiceClient
+	views     []string
+	collapsed bool
+	count     int
+	buckets   []int32
+	total     int32
+}
+
+func (c *fakeViewClient) CreateView(_ context.Context, _ *pb.CreateViewRequest,
+	_ ...grpc.CallOption) (*pb.CreateViewResponse, error) {
+	if c.collapsed && len(c.views) > 0 {
+		return &pb.CreateViewResponse{ViewId: c.views[0], Collapsed: true}, nil
+	}
+
+	id := uuid.NewString()
+	for range c.count {
+		c.views = append([]string{id}, c.views...)
+	}
+
+	return &pb.CreateViewResponse{ViewId: id}, nil
+}
+
+func (c *fakeViewClient) GetResumeViews(_ context.Context, _ *pb.GetResumeViewsRequest,
+	_ ...grpc.CallOption) (*pb.GetResumeViewsResponse, error) {
+	if len(c.views) == 0 {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+
+	return &pb.GetResumeViewsResponse{
+		Views: []*pb.View{{ViewId: c.views[0]}},
+		Total: int32(len(c.views)),
+	}, nil
+}
+
+func (c *fakeViewClient) GetResumeViewStats(_ context.Context, _ *pb.GetResumeViewStatsRequest,
+	_ ...grpc.CallOption) (*pb.GetResumeViewStatsResponse, error) {
+	buckets := make([]*pb.ViewBucket, 0, len(c.buckets))
+	for _, count := range c.buckets {
+		buckets = append(buckets, &pb.ViewBucket{Count: count})
+	}
+
+	return &pb.GetResumeViewStatsResponse{Buckets: buckets, Total: c.total}, nil
+}
+
+func TestCreateRead(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		client  *fakeViewClient
+		wantErr bool
+	}{
+		{name: "First view", client: &fakeViewClient{count: 1}},
+		{name: "Next view", client: &fakeViewClient{views: []string{"a", "b"}, count: 1}},
+		{name: "Collapsed view", client: &fakeViewClient{views: []string{"a"}, collapsed: true}},
+		{name: "Lost view", client: &fakeViewClient{views: []string{"a"}}, wantErr: true},
+		{name: "Double counted view", client: &fakeViewClient{count: 2}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := createRead(context.Background(), tt.client, Target{ResumeID: "r", CompanyID: "c"})
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+
+	target := Target{ResumeID: "r", CompanyID: "c"}
+
+	assert.NoError(t, stats(context.Background(), &fakeViewClient{buckets: []int32{1, 0, 2}, total: 3}, target))
+	assert.Error(t, stats(context.Background(), &fakeViewClient{buckets: []int32{1, 0, 2}, total: 4}, target))
+}
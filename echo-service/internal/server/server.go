@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Verce11o/resume-view/echo-service/internal/probe"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server exposes the probe metrics on /metrics and the probe status as JSON on /status.
+type Server struct {
+	log      *slog.Logger
+	server   *http.Server
+	reporter *probe.Reporter
+}
+
+func New(log *slog.Logger, port string, reporter *probe.Reporter) *Server {
+	s := &Server{log: log, reporter: reporter}
+
+	s.server = &http.Server{
+		Addr:         ":" + port,
+		Handler:      s.routes(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+func (s *Server) Run() error {
+	if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("http server: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for the active requests until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down http server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /metrics", promhttp.HandlerFor(s.reporter.Registry(), promhttp.HandlerOpts{}))
+	mux.HandleFunc("GET /status", s.status)
+
+	return mux
+}
+
+// status answers 503 while a scenario breaches the SLO, so the page doubles as a health check.
+func (s *Server) status(w http.ResponseWriter, _ *http.Request) {
+	status := s.reporter.Status()
+
+	code := http.StatusOK
+	if !status.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.log.Warn("Failed to write probe status", "error", err)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE view_idempotency_keys;
\ No newline at end of file
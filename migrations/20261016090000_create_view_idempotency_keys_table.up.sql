@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS view_idempotency_keys
+(
+    company_id UUID NOT NULL,
+    idempotency_key TEXT NOT NULL,
+    view_id UUID NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT view_idempotency_keys_unique UNIQUE (company_id, idempotency_key)
+);
+
+CREATE INDEX IF NOT EXISTS view_idempotency_keys_created_at_idx ON view_idempotency_keys (created_at);
@@ -0,0 +1 @@
+DROP TABLE view_dedup;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS view_dedup
+(
+    resume_id CHAR(24) NOT NULL,
+    company_id UUID NOT NULL,
+    view_id UUID NOT NULL,
+    counted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (resume_id, company_id)
+);
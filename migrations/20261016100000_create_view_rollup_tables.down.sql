@@ -0,0 +1,2 @@
+DROP TABLE view_daily_counts;
+DROP TABLE view_totals;
\ No newline at end of file
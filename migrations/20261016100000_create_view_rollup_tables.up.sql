@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS view_totals
+(
+    resume_id CHAR(24) PRIMARY KEY,
+    total BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS view_daily_counts
+(
+    resume_id CHAR(24) NOT NULL,
+    day DATE NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (resume_id, day)
+);
+
+INSERT INTO view_totals (resume_id, total)
+SELECT resume_id, COUNT(*) FROM views GROUP BY resume_id;
+
+INSERT INTO view_daily_counts (resume_id, day, count)
+SELECT resume_id, (viewed_at AT TIME ZONE 'UTC')::date, COUNT(*) FROM views GROUP BY 1, 2;
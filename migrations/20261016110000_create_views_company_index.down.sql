@@ -0,0 +1 @@
+DROP INDEX IF EXISTS views_company_id_resume_id_viewed_at_idx;
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS views_company_id_resume_id_viewed_at_idx ON views (company_id, resume_id, viewed_at);
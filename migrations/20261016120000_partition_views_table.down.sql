@@ -0,0 +1,17 @@
+ALTER TABLE views RENAME TO views_partitioned;
+ALTER TABLE views_partitioned RENAME CONSTRAINT views_pkey TO views_partitioned_pkey;
+
+CREATE TABLE views
+(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resume_id CHAR(24) NOT NULL,
+    company_id UUID NOT NULL,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO views (id, resume_id, company_id, viewed_at)
+SELECT id, resume_id, company_id, viewed_at FROM views_partitioned;
+
+DROP TABLE views_partitioned;
+
+CREATE INDEX IF NOT EXISTS views_company_id_resume_id_viewed_at_idx ON views (company_id, resume_id, viewed_at);
@@ -0,0 +1,41 @@
+ALTER TABLE views RENAME TO views_unpartitioned;
+ALTER TABLE views_unpartitioned RENAME CONSTRAINT views_pkey TO views_unpartitioned_pkey;
+DROP INDEX IF EXISTS views_company_id_resume_id_viewed_at_idx;
+
+CREATE TABLE views
+(
+    id UUID NOT NULL DEFAULT uuid_generate_v4(),
+    resume_id CHAR(24) NOT NULL,
+    company_id UUID NOT NULL,
+    viewed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (id, viewed_at)
+) PARTITION BY RANGE (viewed_at);
+
+CREATE INDEX IF NOT EXISTS views_resume_id_viewed_at_idx ON views (resume_id, viewed_at, id);
+CREATE INDEX IF NOT EXISTS views_company_id_resume_id_viewed_at_idx ON views (company_id, resume_id, viewed_at);
+
+-- Catches rows of months the maintenance job has not created yet.
+CREATE TABLE IF NOT EXISTS views_default PARTITION OF views DEFAULT;
+
+-- Monthly UTC partitions from the oldest view up to two months ahead.
+DO $$
+DECLARE
+    partition_start TIMESTAMP;
+BEGIN
+    SELECT date_trunc('month', COALESCE(MIN(viewed_at), NOW()) AT TIME ZONE 'UTC')
+    INTO partition_start FROM views_unpartitioned;
+
+    WHILE partition_start <= date_trunc('month', NOW() AT TIME ZONE 'UTC') + INTERVAL '2 months' LOOP
+        EXECUTE format('CREATE TABLE IF NOT EXISTS %I PARTITION OF views FOR VALUES FROM (%L) TO (%L)',
+            'views_' || to_char(partition_start, 'YYYY_MM'),
+            partition_start::text || '+00',
+            (partition_start + INTERVAL '1 month')::text || '+00');
+
+        partition_start := partition_start + INTERVAL '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO views (id, resume_id, company_id, viewed_at)
+SELECT id, resume_id, company_id, viewed_at FROM views_unpartitioned;
+
+DROP TABLE views_unpartitioned;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS view_outbox
+(
+    id BIGSERIAL PRIMARY KEY,
+    resume_id CHAR(24) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS view_notifications;
+DROP TABLE IF EXISTS view_digests;
+DROP TABLE IF EXISTS view_companies;
@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS view_companies
+(
+    resume_id CHAR(24) NOT NULL,
+    company_id UUID NOT NULL,
+    first_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (resume_id, company_id)
+);
+
+CREATE TABLE IF NOT EXISTS view_digests
+(
+    resume_id CHAR(24) PRIMARY KEY,
+    views BIGINT NOT NULL DEFAULT 0,
+    new_companies BIGINT NOT NULL DEFAULT 0,
+    first_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS view_digests_first_viewed_at_idx ON view_digests (first_viewed_at);
+
+CREATE TABLE IF NOT EXISTS view_notifications
+(
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    resume_id CHAR(24) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Companies that viewed a resume before notifications existed are not new.
+INSERT INTO view_companies (resume_id, company_id, first_viewed_at)
+SELECT resume_id, company_id, MIN(viewed_at) FROM views GROUP BY resume_id, company_id;
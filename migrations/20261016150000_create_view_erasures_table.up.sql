@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS view_erasures
+(
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    resume_id CHAR(24),
+    company_id UUID,
+    requested_by TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    views_erased BIGINT NOT NULL,
+    erased_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT view_erasures_subject CHECK ((resume_id IS NULL) <> (company_id IS NULL))
+);
@@ -0,0 +1,6 @@
+ALTER TABLE views
+    DROP COLUMN IF EXISTS metadata,
+    DROP COLUMN IF EXISTS referrer,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS viewer_user_id,
+    DROP COLUMN IF EXISTS source;
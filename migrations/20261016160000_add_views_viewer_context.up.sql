@@ -0,0 +1,7 @@
+-- Constant defaults keep existing rows valid without rewriting the partitions.
+ALTER TABLE views
+    ADD COLUMN IF NOT EXISTS source TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS viewer_user_id TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS referrer TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS metadata JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1 @@
+ALTER TABLE views DROP COLUMN IF EXISTS suspicious;
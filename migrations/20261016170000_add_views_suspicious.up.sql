@@ -0,0 +1,2 @@
+-- Suspicious views are kept for review but left out of the rollups, so existing rows count as regular views.
+ALTER TABLE views ADD COLUMN IF NOT EXISTS suspicious BOOLEAN NOT NULL DEFAULT FALSE;
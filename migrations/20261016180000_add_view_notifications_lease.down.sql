@@ -0,0 +1 @@
+ALTER TABLE view_notifications DROP COLUMN IF EXISTS locked_until;
@@ -0,0 +1,2 @@
+-- A relay leases the notifications it delivers instead of holding row locks while it calls the notifier.
+ALTER TABLE view_notifications ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
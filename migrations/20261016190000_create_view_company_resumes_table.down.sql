@@ -0,0 +1 @@
+DROP TABLE view_company_resumes;
@@ -0,0 +1,18 @@
+-- Per-company rollup of the views that are not suspicious, so listing the resumes a company viewed does not
+-- aggregate its raw views on every page.
+CREATE TABLE IF NOT EXISTS view_company_resumes
+(
+    company_id UUID NOT NULL,
+    resume_id CHAR(24) NOT NULL,
+    views BIGINT NOT NULL DEFAULT 0,
+    first_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_viewed_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (company_id, resume_id)
+);
+
+CREATE INDEX IF NOT EXISTS view_company_resumes_company_id_last_viewed_at_idx
+    ON view_company_resumes (company_id, last_viewed_at, resume_id);
+
+INSERT INTO view_company_resumes (company_id, resume_id, views, first_viewed_at, last_viewed_at)
+SELECT company_id, resume_id, COUNT(*), MIN(viewed_at), MAX(viewed_at) FROM views WHERE NOT suspicious
+GROUP BY company_id, resume_id;
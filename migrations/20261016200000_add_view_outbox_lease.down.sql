@@ -0,0 +1 @@
+ALTER TABLE view_outbox DROP COLUMN IF EXISTS locked_until;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- The relay leases the events it publishes instead of keeping a transaction open while it calls Kafka.
+ALTER TABLE view_outbox ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS view_notifications_dead;
\ No newline at end of file
@@ -0,0 +1,11 @@
+-- Notifications that failed every delivery attempt, kept for inspection instead of being retried forever.
+CREATE TABLE IF NOT EXISTS view_notifications_dead
+(
+    id BIGINT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    resume_id CHAR(24) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    dead_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
@@ -21,13 +21,227 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ViewSource int32
+
+const (
+	ViewSource_VIEW_SOURCE_UNSPECIFIED    ViewSource = 0
+	ViewSource_VIEW_SOURCE_DIRECT         ViewSource = 1
+	ViewSource_VIEW_SOURCE_SEARCH         ViewSource = 2
+	ViewSource_VIEW_SOURCE_RECOMMENDATION ViewSource = 3
+	ViewSource_VIEW_SOURCE_NOTIFICATION   ViewSource = 4
+	ViewSource_VIEW_SOURCE_API            ViewSource = 5
+)
+
+// Enum value maps for ViewSource.
+var (
+	ViewSource_name = map[int32]string{
+		0: "VIEW_SOURCE_UNSPECIFIED",
+		1: "VIEW_SOURCE_DIRECT",
+		2: "VIEW_SOURCE_SEARCH",
+		3: "VIEW_SOURCE_RECOMMENDATION",
+		4: "VIEW_SOURCE_NOTIFICATION",
+		5: "VIEW_SOURCE_API",
+	}
+	ViewSource_value = map[string]int32{
+		"VIEW_SOURCE_UNSPECIFIED":    0,
+		"VIEW_SOURCE_DIRECT":         1,
+		"VIEW_SOURCE_SEARCH":         2,
+		"VIEW_SOURCE_RECOMMENDATION": 3,
+		"VIEW_SOURCE_NOTIFICATION":   4,
+		"VIEW_SOURCE_API":            5,
+	}
+)
+
+func (x ViewSource) Enum() *ViewSource {
+	p := new(ViewSource)
+	*p = x
+	return p
+}
+
+func (x ViewSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ViewSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_proto_enumTypes[0].Descriptor()
+}
+
+func (ViewSource) Type() protoreflect.EnumType {
+	return &file_view_proto_enumTypes[0]
+}
+
+func (x ViewSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ViewSource.Descriptor instead.
+func (ViewSource) EnumDescriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{0}
+}
+
+type SortOrder int32
+
+const (
+	SortOrder_SORT_ORDER_UNSPECIFIED  SortOrder = 0
+	SortOrder_SORT_ORDER_NEWEST_FIRST SortOrder = 1
+	SortOrder_SORT_ORDER_OLDEST_FIRST SortOrder = 2
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "SORT_ORDER_UNSPECIFIED",
+		1: "SORT_ORDER_NEWEST_FIRST",
+		2: "SORT_ORDER_OLDEST_FIRST",
+	}
+	SortOrder_value = map[string]int32{
+		"SORT_ORDER_UNSPECIFIED":  0,
+		"SORT_ORDER_NEWEST_FIRST": 1,
+		"SORT_ORDER_OLDEST_FIRST": 2,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_proto_enumTypes[1].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_view_proto_enumTypes[1]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{1}
+}
+
+type StatsInterval int32
+
+const (
+	StatsInterval_STATS_INTERVAL_UNSPECIFIED StatsInterval = 0
+	StatsInterval_STATS_INTERVAL_HOUR        StatsInterval = 1
+	StatsInterval_STATS_INTERVAL_DAY         StatsInterval = 2
+	StatsInterval_STATS_INTERVAL_WEEK        StatsInterval = 3
+)
+
+// Enum value maps for StatsInterval.
+var (
+	StatsInterval_name = map[int32]string{
+		0: "STATS_INTERVAL_UNSPECIFIED",
+		1: "STATS_INTERVAL_HOUR",
+		2: "STATS_INTERVAL_DAY",
+		3: "STATS_INTERVAL_WEEK",
+	}
+	StatsInterval_value = map[string]int32{
+		"STATS_INTERVAL_UNSPECIFIED": 0,
+		"STATS_INTERVAL_HOUR":        1,
+		"STATS_INTERVAL_DAY":         2,
+		"STATS_INTERVAL_WEEK":        3,
+	}
+)
+
+func (x StatsInterval) Enum() *StatsInterval {
+	p := new(StatsInterval)
+	*p = x
+	return p
+}
+
+func (x StatsInterval) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StatsInterval) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_proto_enumTypes[2].Descriptor()
+}
+
+func (StatsInterval) Type() protoreflect.EnumType {
+	return &file_view_proto_enumTypes[2]
+}
+
+func (x StatsInterval) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StatsInterval.Descriptor instead.
+func (StatsInterval) EnumDescriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{2}
+}
+
+type ExportFormat int32
+
+const (
+	ExportFormat_EXPORT_FORMAT_UNSPECIFIED ExportFormat = 0
+	ExportFormat_EXPORT_FORMAT_NDJSON      ExportFormat = 1
+	ExportFormat_EXPORT_FORMAT_CSV         ExportFormat = 2
+)
+
+// Enum value maps for ExportFormat.
+var (
+	ExportFormat_name = map[int32]string{
+		0: "EXPORT_FORMAT_UNSPECIFIED",
+		1: "EXPORT_FORMAT_NDJSON",
+		2: "EXPORT_FORMAT_CSV",
+	}
+	ExportFormat_value = map[string]int32{
+		"EXPORT_FORMAT_UNSPECIFIED": 0,
+		"EXPORT_FORMAT_NDJSON":      1,
+		"EXPORT_FORMAT_CSV":         2,
+	}
+)
+
+func (x ExportFormat) Enum() *ExportFormat {
+	p := new(ExportFormat)
+	*p = x
+	return p
+}
+
+func (x ExportFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExportFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_view_proto_enumTypes[3].Descriptor()
+}
+
+func (ExportFormat) Type() protoreflect.EnumType {
+	return &file_view_proto_enumTypes[3]
+}
+
+func (x ExportFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExportFormat.Descriptor instead.
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{3}
+}
+
 type CreateViewRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ResumeId  string `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
-	CompanyId string `protobuf:"bytes,2,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	ResumeId       string            `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	CompanyId      string            `protobuf:"bytes,2,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	IdempotencyKey string            `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Source         ViewSource        `protobuf:"varint,4,opt,name=source,proto3,enum=resume_view.ViewSource" json:"source,omitempty"`
+	ViewerUserId   string            `protobuf:"bytes,5,opt,name=viewer_user_id,json=viewerUserId,proto3" json:"viewer_user_id,omitempty"`
+	UserAgent      string            `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Referrer       string            `protobuf:"bytes,7,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Metadata       map[string]string `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *CreateViewRequest) Reset() {
@@ -76,12 +290,55 @@ func (x *CreateViewRequest) GetCompanyId() string {
 	return ""
 }
 
+func (x *CreateViewRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetSource() ViewSource {
+	if x != nil {
+		return x.Source
+	}
+	return ViewSource_VIEW_SOURCE_UNSPECIFIED
+}
+
+func (x *CreateViewRequest) GetViewerUserId() string {
+	if x != nil {
+		return x.ViewerUserId
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *CreateViewRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
 type CreateViewResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ViewId string `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	ViewId    string `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	Collapsed bool   `protobuf:"varint,2,opt,name=collapsed,proto3" json:"collapsed,omitempty"`
 }
 
 func (x *CreateViewResponse) Reset() {
@@ -123,17 +380,23 @@ func (x *CreateViewResponse) GetViewId() string {
 	return ""
 }
 
-type GetResumeViewsRequest struct {
+func (x *CreateViewResponse) GetCollapsed() bool {
+	if x != nil {
+		return x.Collapsed
+	}
+	return false
+}
+
+type BatchCreateViewsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Cursor   string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	ResumeId string `protobuf:"bytes,2,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	Views []*CreateViewRequest `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
 }
 
-func (x *GetResumeViewsRequest) Reset() {
-	*x = GetResumeViewsRequest{}
+func (x *BatchCreateViewsRequest) Reset() {
+	*x = BatchCreateViewsRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_view_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -141,13 +404,13 @@ func (x *GetResumeViewsRequest) Reset() {
 	}
 }
 
-func (x *GetResumeViewsRequest) String() string {
+func (x *BatchCreateViewsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResumeViewsRequest) ProtoMessage() {}
+func (*BatchCreateViewsRequest) ProtoMessage() {}
 
-func (x *GetResumeViewsRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateViewsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_view_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -159,37 +422,30 @@ func (x *GetResumeViewsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResumeViewsRequest.ProtoReflect.Descriptor instead.
-func (*GetResumeViewsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateViewsRequest.ProtoReflect.Descriptor instead.
+func (*BatchCreateViewsRequest) Descriptor() ([]byte, []int) {
 	return file_view_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetResumeViewsRequest) GetCursor() string {
-	if x != nil {
-		return x.Cursor
-	}
-	return ""
-}
-
-func (x *GetResumeViewsRequest) GetResumeId() string {
+func (x *BatchCreateViewsRequest) GetViews() []*CreateViewRequest {
 	if x != nil {
-		return x.ResumeId
+		return x.Views
 	}
-	return ""
+	return nil
 }
 
-type GetResumeViewsResponse struct {
+type BatchCreateViewsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Views  []*View `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
-	Cursor string  `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
-	Total  int32   `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Results []*CreateViewResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Created int32               `protobuf:"varint,2,opt,name=created,proto3" json:"created,omitempty"`
+	Failed  int32               `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
 }
 
-func (x *GetResumeViewsResponse) Reset() {
-	*x = GetResumeViewsResponse{}
+func (x *BatchCreateViewsResponse) Reset() {
+	*x = BatchCreateViewsResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_view_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -197,13 +453,13 @@ func (x *GetResumeViewsResponse) Reset() {
 	}
 }
 
-func (x *GetResumeViewsResponse) String() string {
+func (x *BatchCreateViewsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetResumeViewsResponse) ProtoMessage() {}
+func (*BatchCreateViewsResponse) ProtoMessage() {}
 
-func (x *GetResumeViewsResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchCreateViewsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_view_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -215,45 +471,46 @@ func (x *GetResumeViewsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetResumeViewsResponse.ProtoReflect.Descriptor instead.
-func (*GetResumeViewsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchCreateViewsResponse.ProtoReflect.Descriptor instead.
+func (*BatchCreateViewsResponse) Descriptor() ([]byte, []int) {
 	return file_view_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetResumeViewsResponse) GetViews() []*View {
+func (x *BatchCreateViewsResponse) GetResults() []*CreateViewResult {
 	if x != nil {
-		return x.Views
+		return x.Results
 	}
 	return nil
 }
 
-func (x *GetResumeViewsResponse) GetCursor() string {
+func (x *BatchCreateViewsResponse) GetCreated() int32 {
 	if x != nil {
-		return x.Cursor
+		return x.Created
 	}
-	return ""
+	return 0
 }
 
-func (x *GetResumeViewsResponse) GetTotal() int32 {
+func (x *BatchCreateViewsResponse) GetFailed() int32 {
 	if x != nil {
-		return x.Total
+		return x.Failed
 	}
 	return 0
 }
 
-type View struct {
+type CreateViewResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ViewId    string                 `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
-	ResumeId  string                 `protobuf:"bytes,2,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
-	CompanyId string                 `protobuf:"bytes,3,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
-	ViewedAt  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	Index     int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	ViewId    string `protobuf:"bytes,2,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	Collapsed bool   `protobuf:"varint,3,opt,name=collapsed,proto3" json:"collapsed,omitempty"`
+	Code      int32  `protobuf:"varint,4,opt,name=code,proto3" json:"code,omitempty"`
+	Error     string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *View) Reset() {
-	*x = View{}
+func (x *CreateViewResult) Reset() {
+	*x = CreateViewResult{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_view_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -261,13 +518,13 @@ func (x *View) Reset() {
 	}
 }
 
-func (x *View) String() string {
+func (x *CreateViewResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*View) ProtoMessage() {}
+func (*CreateViewResult) ProtoMessage() {}
 
-func (x *View) ProtoReflect() protoreflect.Message {
+func (x *CreateViewResult) ProtoReflect() protoreflect.Message {
 	mi := &file_view_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -279,151 +536,1558 @@ func (x *View) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use View.ProtoReflect.Descriptor instead.
-func (*View) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateViewResult.ProtoReflect.Descriptor instead.
+func (*CreateViewResult) Descriptor() ([]byte, []int) {
 	return file_view_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *View) GetViewId() string {
+func (x *CreateViewResult) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *CreateViewResult) GetViewId() string {
 	if x != nil {
 		return x.ViewId
 	}
 	return ""
 }
 
-func (x *View) GetResumeId() string {
+func (x *CreateViewResult) GetCollapsed() bool {
 	if x != nil {
-		return x.ResumeId
+		return x.Collapsed
 	}
-	return ""
+	return false
 }
 
-func (x *View) GetCompanyId() string {
+func (x *CreateViewResult) GetCode() int32 {
 	if x != nil {
-		return x.CompanyId
+		return x.Code
 	}
-	return ""
+	return 0
 }
 
-func (x *View) GetViewedAt() *timestamppb.Timestamp {
+func (x *CreateViewResult) GetError() string {
 	if x != nil {
-		return x.ViewedAt
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-var File_view_proto protoreflect.FileDescriptor
+type GetResumeViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
 
-var file_view_proto_rawDesc = []byte{
-	0x0a, 0x0a, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x72, 0x65,
-	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
-	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
-	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4f, 0x0a, 0x11, 0x43, 0x72,
-	0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
-	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x22, 0x2d, 0x0a, 0x12, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x22, 0x4c, 0x0a, 0x15, 0x47, 0x65,
-	0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72,
-	0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x6f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52,
-	0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e,
-	0x56, 0x69, 0x65, 0x77, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63,
-	0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72,
-	0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x22, 0x94, 0x01, 0x0a, 0x04, 0x56, 0x69,
-	0x65, 0x77, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72,
-	0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
-	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
-	0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
-	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x76, 0x69, 0x65, 0x77, 0x65,
-	0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x41, 0x74,
-	0x32, 0xb7, 0x01, 0x0a, 0x0b, 0x56, 0x69, 0x65, 0x77, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x4d, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x1e,
-	0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
-	0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x59, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77,
-	0x73, 0x12, 0x22, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e,
-	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76,
-	0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65,
-	0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x56, 0x65, 0x72, 0x63, 0x65, 0x31, 0x31,
-	0x6f, 0x2f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x2d, 0x76, 0x69, 0x65, 0x77, 0x2f, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	Cursor            string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	ResumeId          string                 `protobuf:"bytes,2,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	From              *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	CompanyId         string                 `protobuf:"bytes,5,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Sort              SortOrder              `protobuf:"varint,6,opt,name=sort,proto3,enum=resume_view.SortOrder" json:"sort,omitempty"`
+	PageSize          int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Source            ViewSource             `protobuf:"varint,8,opt,name=source,proto3,enum=resume_view.ViewSource" json:"source,omitempty"`
+	ViewerUserId      string                 `protobuf:"bytes,9,opt,name=viewer_user_id,json=viewerUserId,proto3" json:"viewer_user_id,omitempty"`
+	UserAgent         string                 `protobuf:"bytes,10,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Referrer          string                 `protobuf:"bytes,11,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Metadata          map[string]string      `protobuf:"bytes,12,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	IncludeSuspicious bool                   `protobuf:"varint,13,opt,name=include_suspicious,json=includeSuspicious,proto3" json:"include_suspicious,omitempty"`
 }
 
-var (
-	file_view_proto_rawDescOnce sync.Once
-	file_view_proto_rawDescData = file_view_proto_rawDesc
-)
+func (x *GetResumeViewsRequest) Reset() {
+	*x = GetResumeViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
 
-func file_view_proto_rawDescGZIP() []byte {
-	file_view_proto_rawDescOnce.Do(func() {
-		file_view_proto_rawDescData = protoimpl.X.CompressGZIP(file_view_proto_rawDescData)
-	})
-	return file_view_proto_rawDescData
+func (x *GetResumeViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-var file_view_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
-var file_view_proto_goTypes = []interface{}{
-	(*CreateViewRequest)(nil),      // 0: resume_view.CreateViewRequest
-	(*CreateViewResponse)(nil),     // 1: resume_view.CreateViewResponse
-	(*GetResumeViewsRequest)(nil),  // 2: resume_view.GetResumeViewsRequest
-	(*GetResumeViewsResponse)(nil), // 3: resume_view.GetResumeViewsResponse
-	(*View)(nil),                   // 4: resume_view.View
-	(*timestamppb.Timestamp)(nil),  // 5: google.protobuf.Timestamp
+func (*GetResumeViewsRequest) ProtoMessage() {}
+
+func (x *GetResumeViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
-var file_view_proto_depIdxs = []int32{
-	4, // 0: resume_view.GetResumeViewsResponse.views:type_name -> resume_view.View
-	5, // 1: resume_view.View.viewed_at:type_name -> google.protobuf.Timestamp
-	0, // 2: resume_view.ViewService.CreateView:input_type -> resume_view.CreateViewRequest
-	2, // 3: resume_view.ViewService.GetResumeViews:input_type -> resume_view.GetResumeViewsRequest
-	1, // 4: resume_view.ViewService.CreateView:output_type -> resume_view.CreateViewResponse
-	3, // 5: resume_view.ViewService.GetResumeViews:output_type -> resume_view.GetResumeViewsResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+// Deprecated: Use GetResumeViewsRequest.ProtoReflect.Descriptor instead.
+func (*GetResumeViewsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{5}
 }
 
-func init() { file_view_proto_init() }
-func file_view_proto_init() {
-	if File_view_proto != nil {
-		return
+func (x *GetResumeViewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_view_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateViewRequest); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
-			case 2:
-				return &v.unknownFields
-			default:
-				return nil
-			}
-		}
-		file_view_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateViewResponse); i {
-			case 0:
-				return &v.state
-			case 1:
-				return &v.sizeCache
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetResumeViewsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetResumeViewsRequest) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetSort() SortOrder {
+	if x != nil {
+		return x.Sort
+	}
+	return SortOrder_SORT_ORDER_UNSPECIFIED
+}
+
+func (x *GetResumeViewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetResumeViewsRequest) GetSource() ViewSource {
+	if x != nil {
+		return x.Source
+	}
+	return ViewSource_VIEW_SOURCE_UNSPECIFIED
+}
+
+func (x *GetResumeViewsRequest) GetViewerUserId() string {
+	if x != nil {
+		return x.ViewerUserId
+	}
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *GetResumeViewsRequest) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *GetResumeViewsRequest) GetIncludeSuspicious() bool {
+	if x != nil {
+		return x.IncludeSuspicious
+	}
+	return false
+}
+
+type GetResumeViewsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Views  []*View `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	Cursor string  `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Total  int32   `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+}
+
+func (x *GetResumeViewsResponse) Reset() {
+	*x = GetResumeViewsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResumeViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResumeViewsResponse) ProtoMessage() {}
+
+func (x *GetResumeViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResumeViewsResponse.ProtoReflect.Descriptor instead.
+func (*GetResumeViewsResponse) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetResumeViewsResponse) GetViews() []*View {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *GetResumeViewsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetResumeViewsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type GetCompanyViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompanyId string `protobuf:"bytes,1,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Cursor    string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	PageSize  int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+}
+
+func (x *GetCompanyViewsRequest) Reset() {
+	*x = GetCompanyViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCompanyViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompanyViewsRequest) ProtoMessage() {}
+
+func (x *GetCompanyViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompanyViewsRequest.ProtoReflect.Descriptor instead.
+func (*GetCompanyViewsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetCompanyViewsRequest) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *GetCompanyViewsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetCompanyViewsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type GetCompanyViewsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Resumes []*ViewedResume `protobuf:"bytes,1,rep,name=resumes,proto3" json:"resumes,omitempty"`
+	Cursor  string          `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+}
+
+func (x *GetCompanyViewsResponse) Reset() {
+	*x = GetCompanyViewsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCompanyViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCompanyViewsResponse) ProtoMessage() {}
+
+func (x *GetCompanyViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCompanyViewsResponse.ProtoReflect.Descriptor instead.
+func (*GetCompanyViewsResponse) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetCompanyViewsResponse) GetResumes() []*ViewedResume {
+	if x != nil {
+		return x.Resumes
+	}
+	return nil
+}
+
+func (x *GetCompanyViewsResponse) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ViewedResume struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResumeId      string                 `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	FirstViewedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_viewed_at,json=firstViewedAt,proto3" json:"first_viewed_at,omitempty"`
+	LastViewedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_viewed_at,json=lastViewedAt,proto3" json:"last_viewed_at,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ViewedResume) Reset() {
+	*x = ViewedResume{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewedResume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewedResume) ProtoMessage() {}
+
+func (x *ViewedResume) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewedResume.ProtoReflect.Descriptor instead.
+func (*ViewedResume) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ViewedResume) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *ViewedResume) GetFirstViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstViewedAt
+	}
+	return nil
+}
+
+func (x *ViewedResume) GetLastViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastViewedAt
+	}
+	return nil
+}
+
+func (x *ViewedResume) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type WatchResumeViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResumeId string `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+}
+
+func (x *WatchResumeViewsRequest) Reset() {
+	*x = WatchResumeViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchResumeViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResumeViewsRequest) ProtoMessage() {}
+
+func (x *WatchResumeViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResumeViewsRequest.ProtoReflect.Descriptor instead.
+func (*WatchResumeViewsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WatchResumeViewsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+type View struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ViewId       string                 `protobuf:"bytes,1,opt,name=view_id,json=viewId,proto3" json:"view_id,omitempty"`
+	ResumeId     string                 `protobuf:"bytes,2,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	CompanyId    string                 `protobuf:"bytes,3,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	ViewedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	Source       ViewSource             `protobuf:"varint,5,opt,name=source,proto3,enum=resume_view.ViewSource" json:"source,omitempty"`
+	ViewerUserId string                 `protobuf:"bytes,6,opt,name=viewer_user_id,json=viewerUserId,proto3" json:"viewer_user_id,omitempty"`
+	UserAgent    string                 `protobuf:"bytes,7,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Referrer     string                 `protobuf:"bytes,8,opt,name=referrer,proto3" json:"referrer,omitempty"`
+	Metadata     map[string]string      `protobuf:"bytes,9,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Suspicious   bool                   `protobuf:"varint,10,opt,name=suspicious,proto3" json:"suspicious,omitempty"`
+}
+
+func (x *View) Reset() {
+	*x = View{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *View) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*View) ProtoMessage() {}
+
+func (x *View) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use View.ProtoReflect.Descriptor instead.
+func (*View) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *View) GetViewId() string {
+	if x != nil {
+		return x.ViewId
+	}
+	return ""
+}
+
+func (x *View) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *View) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *View) GetViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ViewedAt
+	}
+	return nil
+}
+
+func (x *View) GetSource() ViewSource {
+	if x != nil {
+		return x.Source
+	}
+	return ViewSource_VIEW_SOURCE_UNSPECIFIED
+}
+
+func (x *View) GetViewerUserId() string {
+	if x != nil {
+		return x.ViewerUserId
+	}
+	return ""
+}
+
+func (x *View) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *View) GetReferrer() string {
+	if x != nil {
+		return x.Referrer
+	}
+	return ""
+}
+
+func (x *View) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *View) GetSuspicious() bool {
+	if x != nil {
+		return x.Suspicious
+	}
+	return false
+}
+
+type GetResumeViewStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResumeId          string                 `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	From              *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To                *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Interval          StatsInterval          `protobuf:"varint,4,opt,name=interval,proto3,enum=resume_view.StatsInterval" json:"interval,omitempty"`
+	TopCompanies      int32                  `protobuf:"varint,5,opt,name=top_companies,json=topCompanies,proto3" json:"top_companies,omitempty"`
+	IncludeSuspicious bool                   `protobuf:"varint,6,opt,name=include_suspicious,json=includeSuspicious,proto3" json:"include_suspicious,omitempty"`
+}
+
+func (x *GetResumeViewStatsRequest) Reset() {
+	*x = GetResumeViewStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResumeViewStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResumeViewStatsRequest) ProtoMessage() {}
+
+func (x *GetResumeViewStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResumeViewStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetResumeViewStatsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetResumeViewStatsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *GetResumeViewStatsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetResumeViewStatsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetResumeViewStatsRequest) GetInterval() StatsInterval {
+	if x != nil {
+		return x.Interval
+	}
+	return StatsInterval_STATS_INTERVAL_UNSPECIFIED
+}
+
+func (x *GetResumeViewStatsRequest) GetTopCompanies() int32 {
+	if x != nil {
+		return x.TopCompanies
+	}
+	return 0
+}
+
+func (x *GetResumeViewStatsRequest) GetIncludeSuspicious() bool {
+	if x != nil {
+		return x.IncludeSuspicious
+	}
+	return false
+}
+
+type GetResumeViewStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Buckets         []*ViewBucket   `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Total           int32           `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	UniqueCompanies int32           `protobuf:"varint,3,opt,name=unique_companies,json=uniqueCompanies,proto3" json:"unique_companies,omitempty"`
+	TopCompanies    []*CompanyViews `protobuf:"bytes,4,rep,name=top_companies,json=topCompanies,proto3" json:"top_companies,omitempty"`
+}
+
+func (x *GetResumeViewStatsResponse) Reset() {
+	*x = GetResumeViewStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetResumeViewStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetResumeViewStatsResponse) ProtoMessage() {}
+
+func (x *GetResumeViewStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetResumeViewStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetResumeViewStatsResponse) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetResumeViewStatsResponse) GetBuckets() []*ViewBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *GetResumeViewStatsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetResumeViewStatsResponse) GetUniqueCompanies() int32 {
+	if x != nil {
+		return x.UniqueCompanies
+	}
+	return 0
+}
+
+func (x *GetResumeViewStatsResponse) GetTopCompanies() []*CompanyViews {
+	if x != nil {
+		return x.TopCompanies
+	}
+	return nil
+}
+
+type ViewBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Start *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start,proto3" json:"start,omitempty"`
+	Count int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *ViewBucket) Reset() {
+	*x = ViewBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ViewBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ViewBucket) ProtoMessage() {}
+
+func (x *ViewBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ViewBucket.ProtoReflect.Descriptor instead.
+func (*ViewBucket) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ViewBucket) GetStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Start
+	}
+	return nil
+}
+
+func (x *ViewBucket) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CompanyViews struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CompanyId    string                 `protobuf:"bytes,1,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Count        int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	LastViewedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_viewed_at,json=lastViewedAt,proto3" json:"last_viewed_at,omitempty"`
+}
+
+func (x *CompanyViews) Reset() {
+	*x = CompanyViews{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CompanyViews) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompanyViews) ProtoMessage() {}
+
+func (x *CompanyViews) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompanyViews.ProtoReflect.Descriptor instead.
+func (*CompanyViews) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CompanyViews) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *CompanyViews) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *CompanyViews) GetLastViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastViewedAt
+	}
+	return nil
+}
+
+type EraseViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResumeId  string `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	CompanyId string `protobuf:"bytes,2,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Reason    string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *EraseViewsRequest) Reset() {
+	*x = EraseViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseViewsRequest) ProtoMessage() {}
+
+func (x *EraseViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseViewsRequest.ProtoReflect.Descriptor instead.
+func (*EraseViewsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EraseViewsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *EraseViewsRequest) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *EraseViewsRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type EraseViewsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ErasureId string                 `protobuf:"bytes,1,opt,name=erasure_id,json=erasureId,proto3" json:"erasure_id,omitempty"`
+	Erased    int64                  `protobuf:"varint,2,opt,name=erased,proto3" json:"erased,omitempty"`
+	ErasedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=erased_at,json=erasedAt,proto3" json:"erased_at,omitempty"`
+}
+
+func (x *EraseViewsResponse) Reset() {
+	*x = EraseViewsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EraseViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EraseViewsResponse) ProtoMessage() {}
+
+func (x *EraseViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EraseViewsResponse.ProtoReflect.Descriptor instead.
+func (*EraseViewsResponse) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EraseViewsResponse) GetErasureId() string {
+	if x != nil {
+		return x.ErasureId
+	}
+	return ""
+}
+
+func (x *EraseViewsResponse) GetErased() int64 {
+	if x != nil {
+		return x.Erased
+	}
+	return 0
+}
+
+func (x *EraseViewsResponse) GetErasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ErasedAt
+	}
+	return nil
+}
+
+type ExportViewsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResumeId  string       `protobuf:"bytes,1,opt,name=resume_id,json=resumeId,proto3" json:"resume_id,omitempty"`
+	CompanyId string       `protobuf:"bytes,2,opt,name=company_id,json=companyId,proto3" json:"company_id,omitempty"`
+	Format    ExportFormat `protobuf:"varint,3,opt,name=format,proto3,enum=resume_view.ExportFormat" json:"format,omitempty"`
+}
+
+func (x *ExportViewsRequest) Reset() {
+	*x = ExportViewsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportViewsRequest) ProtoMessage() {}
+
+func (x *ExportViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportViewsRequest.ProtoReflect.Descriptor instead.
+func (*ExportViewsRequest) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ExportViewsRequest) GetResumeId() string {
+	if x != nil {
+		return x.ResumeId
+	}
+	return ""
+}
+
+func (x *ExportViewsRequest) GetCompanyId() string {
+	if x != nil {
+		return x.CompanyId
+	}
+	return ""
+}
+
+func (x *ExportViewsRequest) GetFormat() ExportFormat {
+	if x != nil {
+		return x.Format
+	}
+	return ExportFormat_EXPORT_FORMAT_UNSPECIFIED
+}
+
+type ExportViewsChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *ExportViewsChunk) Reset() {
+	*x = ExportViewsChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_view_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportViewsChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportViewsChunk) ProtoMessage() {}
+
+func (x *ExportViewsChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_view_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportViewsChunk.ProtoReflect.Descriptor instead.
+func (*ExportViewsChunk) Descriptor() ([]byte, []int) {
+	return file_view_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ExportViewsChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_view_proto protoreflect.FileDescriptor
+
+var file_view_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x91, 0x03, 0x0a, 0x11, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f,
+	0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6b, 0x65, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x69, 0x64, 0x65, 0x6d, 0x70, 0x6f, 0x74, 0x65, 0x6e,
+	0x63, 0x79, 0x4b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76,
+	0x69, 0x65, 0x77, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72,
+	0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x75, 0x73, 0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x48, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61,
+	0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x4b,
+	0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x63, 0x6f, 0x6c, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x22, 0x4f, 0x0a, 0x17, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76,
+	0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x76, 0x69, 0x65, 0x77, 0x73, 0x22, 0x85, 0x01, 0x0a,
+	0x18, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x37, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x22, 0x89, 0x01, 0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6c, 0x6c,
+	0x61, 0x70, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x63, 0x6f, 0x6c,
+	0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x22, 0xdc, 0x04, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69,
+	0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75,
+	0x72, 0x73, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73,
+	0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12,
+	0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12,
+	0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x63,
+	0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x2a, 0x0a, 0x04, 0x73, 0x6f,
+	0x72, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72,
+	0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65,
+	0x77, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0e, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x69,
+	0x65, 0x77, 0x65, 0x72, 0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x61, 0x67, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x75, 0x73, 0x65, 0x72, 0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x72, 0x65, 0x72, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x72, 0x65, 0x72, 0x12, 0x4c, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x30, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4d, 0x65, 0x74, 0x61,
+	0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73,
+	0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x11, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f,
+	0x75, 0x73, 0x1a, 0x3b, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x6f, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x05, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x52, 0x05, 0x76, 0x69, 0x65,
+	0x77, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x22, 0x6c, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x56, 0x69,
+	0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72,
+	0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x22, 0x66,
+	0x0a, 0x17, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x56, 0x69, 0x65, 0x77,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x33, 0x0a, 0x07, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x52,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0xc7, 0x01, 0x0a, 0x0c, 0x56, 0x69, 0x65, 0x77, 0x65,
+	0x64, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x49, 0x64, 0x12, 0x42, 0x0a, 0x0f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x76, 0x69,
+	0x65, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x41, 0x74, 0x12, 0x40, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74,
+	0x5f, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0c, 0x6c, 0x61,
+	0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x41, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x36, 0x0a, 0x17, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x22, 0xc0, 0x03, 0x0a, 0x04, 0x56, 0x69, 0x65,
+	0x77, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x69, 0x65, 0x77, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x76, 0x69, 0x65, 0x77, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x41, 0x74, 0x12,
+	0x2f, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x17, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x56, 0x69,
+	0x65, 0x77, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x12, 0x24, 0x0a, 0x0e, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x76, 0x69, 0x65, 0x77, 0x65, 0x72,
+	0x55, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x61,
+	0x67, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x75, 0x73, 0x65, 0x72,
+	0x41, 0x67, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
+	0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x66, 0x65, 0x72, 0x72, 0x65,
+	0x72, 0x12, 0x3b, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x09, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65,
+	0x77, 0x2e, 0x56, 0x69, 0x65, 0x77, 0x2e, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1e,
+	0x0a, 0x0a, 0x73, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0a, 0x73, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x1a, 0x3b,
+	0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xa0, 0x02, 0x0a, 0x19,
+	0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x02,
+	0x74, 0x6f, 0x12, 0x36, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69,
+	0x65, 0x77, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x6f,
+	0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0c, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x12,
+	0x2d, 0x0a, 0x12, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x73, 0x75, 0x73, 0x70, 0x69,
+	0x63, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x6e, 0x63,
+	0x6c, 0x75, 0x64, 0x65, 0x53, 0x75, 0x73, 0x70, 0x69, 0x63, 0x69, 0x6f, 0x75, 0x73, 0x22, 0xd0,
+	0x01, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a,
+	0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x56, 0x69, 0x65,
+	0x77, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65,
+	0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0f, 0x75, 0x6e, 0x69, 0x71, 0x75, 0x65, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65,
+	0x73, 0x12, 0x3e, 0x0a, 0x0d, 0x74, 0x6f, 0x70, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x56, 0x69,
+	0x65, 0x77, 0x73, 0x52, 0x0c, 0x74, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x69, 0x65,
+	0x73, 0x22, 0x54, 0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x30, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x85, 0x01, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x40, 0x0a,
+	0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x56, 0x69, 0x65, 0x77, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x67, 0x0a, 0x11, 0x45, 0x72, 0x61, 0x73, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x49,
+	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x49, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x12, 0x45, 0x72, 0x61,
+	0x73, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x72, 0x61, 0x73, 0x75, 0x72, 0x65, 0x49, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06,
+	0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x65, 0x72, 0x61, 0x73, 0x65, 0x64, 0x41, 0x74, 0x22,
+	0x83, 0x01, 0x0a, 0x12, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79,
+	0x49, 0x64, 0x12, 0x31, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x19, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77,
+	0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x26, 0x0a, 0x10, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74,
+	0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x2a, 0xac, 0x01,
+	0x0a, 0x0a, 0x56, 0x69, 0x65, 0x77, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x17,
+	0x56, 0x49, 0x45, 0x57, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x45,
+	0x57, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x44, 0x49, 0x52, 0x45, 0x43, 0x54, 0x10,
+	0x01, 0x12, 0x16, 0x0a, 0x12, 0x56, 0x49, 0x45, 0x57, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45,
+	0x5f, 0x53, 0x45, 0x41, 0x52, 0x43, 0x48, 0x10, 0x02, 0x12, 0x1e, 0x0a, 0x1a, 0x56, 0x49, 0x45,
+	0x57, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x4d, 0x4d, 0x45,
+	0x4e, 0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x56, 0x49, 0x45,
+	0x57, 0x5f, 0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x4e, 0x4f, 0x54, 0x49, 0x46, 0x49, 0x43,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x13, 0x0a, 0x0f, 0x56, 0x49, 0x45, 0x57, 0x5f,
+	0x53, 0x4f, 0x55, 0x52, 0x43, 0x45, 0x5f, 0x41, 0x50, 0x49, 0x10, 0x05, 0x2a, 0x61, 0x0a, 0x09,
+	0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x4f, 0x52,
+	0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46,
+	0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52,
+	0x44, 0x45, 0x52, 0x5f, 0x4e, 0x45, 0x57, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54,
+	0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x4f, 0x52, 0x54, 0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52,
+	0x5f, 0x4f, 0x4c, 0x44, 0x45, 0x53, 0x54, 0x5f, 0x46, 0x49, 0x52, 0x53, 0x54, 0x10, 0x02, 0x2a,
+	0x79, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x73, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c,
+	0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56,
+	0x41, 0x4c, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x17, 0x0a, 0x13, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56,
+	0x41, 0x4c, 0x5f, 0x48, 0x4f, 0x55, 0x52, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x54, 0x41,
+	0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52, 0x56, 0x41, 0x4c, 0x5f, 0x44, 0x41, 0x59, 0x10,
+	0x02, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x54, 0x41, 0x54, 0x53, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52,
+	0x56, 0x41, 0x4c, 0x5f, 0x57, 0x45, 0x45, 0x4b, 0x10, 0x03, 0x2a, 0x5e, 0x0a, 0x0c, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1d, 0x0a, 0x19, 0x45, 0x58,
+	0x50, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x58, 0x50,
+	0x4f, 0x52, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x4e, 0x44, 0x4a, 0x53, 0x4f,
+	0x4e, 0x10, 0x01, 0x12, 0x15, 0x0a, 0x11, 0x45, 0x58, 0x50, 0x4f, 0x52, 0x54, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x43, 0x53, 0x56, 0x10, 0x02, 0x32, 0xa4, 0x06, 0x0a, 0x0b, 0x56,
+	0x69, 0x65, 0x77, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x12, 0x1e, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65,
+	0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x22, 0x2e, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x23, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x65, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x56, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x2e, 0x72, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x27, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77,
+	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5f, 0x0a, 0x10, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12,
+	0x24, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76,
+	0x69, 0x65, 0x77, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x56, 0x0a, 0x0b,
+	0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x56, 0x69, 0x65, 0x77, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x28, 0x01, 0x12, 0x4d, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
+	0x75, 0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x24, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d,
+	0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11,
+	0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x56, 0x69, 0x65,
+	0x77, 0x30, 0x01, 0x12, 0x5c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e,
+	0x79, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12, 0x23, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f,
+	0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x6e, 0x79, 0x56,
+	0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x72, 0x65,
+	0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x6e, 0x79, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x4d, 0x0a, 0x0a, 0x45, 0x72, 0x61, 0x73, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12,
+	0x1e, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x45, 0x72,
+	0x61, 0x73, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1f, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x45, 0x72,
+	0x61, 0x73, 0x65, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x4f, 0x0a, 0x0b, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x12,
+	0x1f, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x45, 0x78,
+	0x70, 0x6f, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1d, 0x2e, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x5f, 0x76, 0x69, 0x65, 0x77, 0x2e, 0x45,
+	0x78, 0x70, 0x6f, 0x72, 0x74, 0x56, 0x69, 0x65, 0x77, 0x73, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30,
+	0x01, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x56, 0x65, 0x72, 0x63, 0x65, 0x31, 0x31, 0x6f, 0x2f, 0x72, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x2d,
+	0x76, 0x69, 0x65, 0x77, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_view_proto_rawDescOnce sync.Once
+	file_view_proto_rawDescData = file_view_proto_rawDesc
+)
+
+func file_view_proto_rawDescGZIP() []byte {
+	file_view_proto_rawDescOnce.Do(func() {
+		file_view_proto_rawDescData = protoimpl.X.CompressGZIP(file_view_proto_rawDescData)
+	})
+	return file_view_proto_rawDescData
+}
+
+var file_view_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_view_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_view_proto_goTypes = []interface{}{
+	(ViewSource)(0),                    // 0: resume_view.ViewSource
+	(SortOrder)(0),                     // 1: resume_view.SortOrder
+	(StatsInterval)(0),                 // 2: resume_view.StatsInterval
+	(ExportFormat)(0),                  // 3: resume_view.ExportFormat
+	(*CreateViewRequest)(nil),          // 4: resume_view.CreateViewRequest
+	(*CreateViewResponse)(nil),         // 5: resume_view.CreateViewResponse
+	(*BatchCreateViewsRequest)(nil),    // 6: resume_view.BatchCreateViewsRequest
+	(*BatchCreateViewsResponse)(nil),   // 7: resume_view.BatchCreateViewsResponse
+	(*CreateViewResult)(nil),           // 8: resume_view.CreateViewResult
+	(*GetResumeViewsRequest)(nil),      // 9: resume_view.GetResumeViewsRequest
+	(*GetResumeViewsResponse)(nil),     // 10: resume_view.GetResumeViewsResponse
+	(*GetCompanyViewsRequest)(nil),     // 11: resume_view.GetCompanyViewsRequest
+	(*GetCompanyViewsResponse)(nil),    // 12: resume_view.GetCompanyViewsResponse
+	(*ViewedResume)(nil),               // 13: resume_view.ViewedResume
+	(*WatchResumeViewsRequest)(nil),    // 14: resume_view.WatchResumeViewsRequest
+	(*View)(nil),                       // 15: resume_view.View
+	(*GetResumeViewStatsRequest)(nil),  // 16: resume_view.GetResumeViewStatsRequest
+	(*GetResumeViewStatsResponse)(nil), // 17: resume_view.GetResumeViewStatsResponse
+	(*ViewBucket)(nil),                 // 18: resume_view.ViewBucket
+	(*CompanyViews)(nil),               // 19: resume_view.CompanyViews
+	(*EraseViewsRequest)(nil),          // 20: resume_view.EraseViewsRequest
+	(*EraseViewsResponse)(nil),         // 21: resume_view.EraseViewsResponse
+	(*ExportViewsRequest)(nil),         // 22: resume_view.ExportViewsRequest
+	(*ExportViewsChunk)(nil),           // 23: resume_view.ExportViewsChunk
+	nil,                                // 24: resume_view.CreateViewRequest.MetadataEntry
+	nil,                                // 25: resume_view.GetResumeViewsRequest.MetadataEntry
+	nil,                                // 26: resume_view.View.MetadataEntry
+	(*timestamppb.Timestamp)(nil),      // 27: google.protobuf.Timestamp
+}
+var file_view_proto_depIdxs = []int32{
+	0,  // 0: resume_view.CreateViewRequest.source:type_name -> resume_view.ViewSource
+	24, // 1: resume_view.CreateViewRequest.metadata:type_name -> resume_view.CreateViewRequest.MetadataEntry
+	4,  // 2: resume_view.BatchCreateViewsRequest.views:type_name -> resume_view.CreateViewRequest
+	8,  // 3: resume_view.BatchCreateViewsResponse.results:type_name -> resume_view.CreateViewResult
+	27, // 4: resume_view.GetResumeViewsRequest.from:type_name -> google.protobuf.Timestamp
+	27, // 5: resume_view.GetResumeViewsRequest.to:type_name -> google.protobuf.Timestamp
+	1,  // 6: resume_view.GetResumeViewsRequest.sort:type_name -> resume_view.SortOrder
+	0,  // 7: resume_view.GetResumeViewsRequest.source:type_name -> resume_view.ViewSource
+	25, // 8: resume_view.GetResumeViewsRequest.metadata:type_name -> resume_view.GetResumeViewsRequest.MetadataEntry
+	15, // 9: resume_view.GetResumeViewsResponse.views:type_name -> resume_view.View
+	13, // 10: resume_view.GetCompanyViewsResponse.resumes:type_name -> resume_view.ViewedResume
+	27, // 11: resume_view.ViewedResume.first_viewed_at:type_name -> google.protobuf.Timestamp
+	27, // 12: resume_view.ViewedResume.last_viewed_at:type_name -> google.protobuf.Timestamp
+	27, // 13: resume_view.View.viewed_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: resume_view.View.source:type_name -> resume_view.ViewSource
+	26, // 15: resume_view.View.metadata:type_name -> resume_view.View.MetadataEntry
+	27, // 16: resume_view.GetResumeViewStatsRequest.from:type_name -> google.protobuf.Timestamp
+	27, // 17: resume_view.GetResumeViewStatsRequest.to:type_name -> google.protobuf.Timestamp
+	2,  // 18: resume_view.GetResumeViewStatsRequest.interval:type_name -> resume_view.StatsInterval
+	18, // 19: resume_view.GetResumeViewStatsResponse.buckets:type_name -> resume_view.ViewBucket
+	19, // 20: resume_view.GetResumeViewStatsResponse.top_companies:type_name -> resume_view.CompanyViews
+	27, // 21: resume_view.ViewBucket.start:type_name -> google.protobuf.Timestamp
+	27, // 22: resume_view.CompanyViews.last_viewed_at:type_name -> google.protobuf.Timestamp
+	27, // 23: resume_view.EraseViewsResponse.erased_at:type_name -> google.protobuf.Timestamp
+	3,  // 24: resume_view.ExportViewsRequest.format:type_name -> resume_view.ExportFormat
+	4,  // 25: resume_view.ViewService.CreateView:input_type -> resume_view.CreateViewRequest
+	9,  // 26: resume_view.ViewService.GetResumeViews:input_type -> resume_view.GetResumeViewsRequest
+	16, // 27: resume_view.ViewService.GetResumeViewStats:input_type -> resume_view.GetResumeViewStatsRequest
+	6,  // 28: resume_view.ViewService.BatchCreateViews:input_type -> resume_view.BatchCreateViewsRequest
+	4,  // 29: resume_view.ViewService.StreamViews:input_type -> resume_view.CreateViewRequest
+	14, // 30: resume_view.ViewService.WatchResumeViews:input_type -> resume_view.WatchResumeViewsRequest
+	11, // 31: resume_view.ViewService.GetCompanyViews:input_type -> resume_view.GetCompanyViewsRequest
+	20, // 32: resume_view.ViewService.EraseViews:input_type -> resume_view.EraseViewsRequest
+	22, // 33: resume_view.ViewService.ExportViews:input_type -> resume_view.ExportViewsRequest
+	5,  // 34: resume_view.ViewService.CreateView:output_type -> resume_view.CreateViewResponse
+	10, // 35: resume_view.ViewService.GetResumeViews:output_type -> resume_view.GetResumeViewsResponse
+	17, // 36: resume_view.ViewService.GetResumeViewStats:output_type -> resume_view.GetResumeViewStatsResponse
+	7,  // 37: resume_view.ViewService.BatchCreateViews:output_type -> resume_view.BatchCreateViewsResponse
+	7,  // 38: resume_view.ViewService.StreamViews:output_type -> resume_view.BatchCreateViewsResponse
+	15, // 39: resume_view.ViewService.WatchResumeViews:output_type -> resume_view.View
+	12, // 40: resume_view.ViewService.GetCompanyViews:output_type -> resume_view.GetCompanyViewsResponse
+	21, // 41: resume_view.ViewService.EraseViews:output_type -> resume_view.EraseViewsResponse
+	23, // 42: resume_view.ViewService.ExportViews:output_type -> resume_view.ExportViewsChunk
+	34, // [34:43] is the sub-list for method output_type
+	25, // [25:34] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
+}
+
+func init() { file_view_proto_init() }
+func file_view_proto_init() {
+	if File_view_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_view_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateViewRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateViewResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
 			default:
@@ -431,7 +2095,7 @@ func file_view_proto_init() {
 			}
 		}
 		file_view_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResumeViewsRequest); i {
+			switch v := v.(*BatchCreateViewsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -443,7 +2107,7 @@ func file_view_proto_init() {
 			}
 		}
 		file_view_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetResumeViewsResponse); i {
+			switch v := v.(*BatchCreateViewsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -455,6 +2119,90 @@ func file_view_proto_init() {
 			}
 		}
 		file_view_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateViewResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResumeViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResumeViewsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCompanyViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCompanyViewsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewedResume); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchResumeViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*View); i {
 			case 0:
 				return &v.state
@@ -466,19 +2214,116 @@ func file_view_proto_init() {
 				return nil
 			}
 		}
+		file_view_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResumeViewStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetResumeViewStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ViewBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CompanyViews); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EraseViewsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportViewsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_view_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportViewsChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_view_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   5,
+			NumEnums:      4,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_view_proto_goTypes,
 		DependencyIndexes: file_view_proto_depIdxs,
+		EnumInfos:         file_view_proto_enumTypes,
 		MessageInfos:      file_view_proto_msgTypes,
 	}.Build()
 	File_view_proto = out.File
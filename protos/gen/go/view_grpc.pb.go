@@ -19,8 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ViewService_CreateView_FullMethodName     = "/resume_view.ViewService/CreateView"
-	ViewService_GetResumeViews_FullMethodName = "/resume_view.ViewService/GetResumeViews"
+	ViewService_CreateView_FullMethodName         = "/resume_view.ViewService/CreateView"
+	ViewService_GetResumeViews_FullMethodName     = "/resume_view.ViewService/GetResumeViews"
+	ViewService_GetResumeViewStats_FullMethodName = "/resume_view.ViewService/GetResumeViewStats"
+	ViewService_BatchCreateViews_FullMethodName   = "/resume_view.ViewService/BatchCreateViews"
+	ViewService_StreamViews_FullMethodName        = "/resume_view.ViewService/StreamViews"
+	ViewService_WatchResumeViews_FullMethodName   = "/resume_view.ViewService/WatchResumeViews"
+	ViewService_GetCompanyViews_FullMethodName    = "/resume_view.ViewService/GetCompanyViews"
+	ViewService_EraseViews_FullMethodName         = "/resume_view.ViewService/EraseViews"
+	ViewService_ExportViews_FullMethodName        = "/resume_view.ViewService/ExportViews"
 )
 
 // ViewServiceClient is the client API for ViewService service.
@@ -29,6 +36,13 @@ const (
 type ViewServiceClient interface {
 	CreateView(ctx context.Context, in *CreateViewRequest, opts ...grpc.CallOption) (*CreateViewResponse, error)
 	GetResumeViews(ctx context.Context, in *GetResumeViewsRequest, opts ...grpc.CallOption) (*GetResumeViewsResponse, error)
+	GetResumeViewStats(ctx context.Context, in *GetResumeViewStatsRequest, opts ...grpc.CallOption) (*GetResumeViewStatsResponse, error)
+	BatchCreateViews(ctx context.Context, in *BatchCreateViewsRequest, opts ...grpc.CallOption) (*BatchCreateViewsResponse, error)
+	StreamViews(ctx context.Context, opts ...grpc.CallOption) (ViewService_StreamViewsClient, error)
+	WatchResumeViews(ctx context.Context, in *WatchResumeViewsRequest, opts ...grpc.CallOption) (ViewService_WatchResumeViewsClient, error)
+	GetCompanyViews(ctx context.Context, in *GetCompanyViewsRequest, opts ...grpc.CallOption) (*GetCompanyViewsResponse, error)
+	EraseViews(ctx context.Context, in *EraseViewsRequest, opts ...grpc.CallOption) (*EraseViewsResponse, error)
+	ExportViews(ctx context.Context, in *ExportViewsRequest, opts ...grpc.CallOption) (ViewService_ExportViewsClient, error)
 }
 
 type viewServiceClient struct {
@@ -57,12 +71,153 @@ func (c *viewServiceClient) GetResumeViews(ctx context.Context, in *GetResumeVie
 	return out, nil
 }
 
+func (c *viewServiceClient) GetResumeViewStats(ctx context.Context, in *GetResumeViewStatsRequest, opts ...grpc.CallOption) (*GetResumeViewStatsResponse, error) {
+	out := new(GetResumeViewStatsResponse)
+	err := c.cc.Invoke(ctx, ViewService_GetResumeViewStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) BatchCreateViews(ctx context.Context, in *BatchCreateViewsRequest, opts ...grpc.CallOption) (*BatchCreateViewsResponse, error) {
+	out := new(BatchCreateViewsResponse)
+	err := c.cc.Invoke(ctx, ViewService_BatchCreateViews_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) StreamViews(ctx context.Context, opts ...grpc.CallOption) (ViewService_StreamViewsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ViewService_ServiceDesc.Streams[0], ViewService_StreamViews_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &viewServiceStreamViewsClient{stream}
+	return x, nil
+}
+
+type ViewService_StreamViewsClient interface {
+	Send(*CreateViewRequest) error
+	CloseAndRecv() (*BatchCreateViewsResponse, error)
+	grpc.ClientStream
+}
+
+type viewServiceStreamViewsClient struct {
+	grpc.ClientStream
+}
+
+func (x *viewServiceStreamViewsClient) Send(m *CreateViewRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *viewServiceStreamViewsClient) CloseAndRecv() (*BatchCreateViewsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(BatchCreateViewsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *viewServiceClient) WatchResumeViews(ctx context.Context, in *WatchResumeViewsRequest, opts ...grpc.CallOption) (ViewService_WatchResumeViewsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ViewService_ServiceDesc.Streams[1], ViewService_WatchResumeViews_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &viewServiceWatchResumeViewsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ViewService_WatchResumeViewsClient interface {
+	Recv() (*View, error)
+	grpc.ClientStream
+}
+
+type viewServiceWatchResumeViewsClient struct {
+	grpc.ClientStream
+}
+
+func (x *viewServiceWatchResumeViewsClient) Recv() (*View, error) {
+	m := new(View)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *viewServiceClient) GetCompanyViews(ctx context.Context, in *GetCompanyViewsRequest, opts ...grpc.CallOption) (*GetCompanyViewsResponse, error) {
+	out := new(GetCompanyViewsResponse)
+	err := c.cc.Invoke(ctx, ViewService_GetCompanyViews_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) EraseViews(ctx context.Context, in *EraseViewsRequest, opts ...grpc.CallOption) (*EraseViewsResponse, error) {
+	out := new(EraseViewsResponse)
+	err := c.cc.Invoke(ctx, ViewService_EraseViews_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *viewServiceClient) ExportViews(ctx context.Context, in *ExportViewsRequest, opts ...grpc.CallOption) (ViewService_ExportViewsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ViewService_ServiceDesc.Streams[2], ViewService_ExportViews_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &viewServiceExportViewsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ViewService_ExportViewsClient interface {
+	Recv() (*ExportViewsChunk, error)
+	grpc.ClientStream
+}
+
+type viewServiceExportViewsClient struct {
+	grpc.ClientStream
+}
+
+func (x *viewServiceExportViewsClient) Recv() (*ExportViewsChunk, error) {
+	m := new(ExportViewsChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ViewServiceServer is the server API for ViewService service.
 // All implementations must embed UnimplementedViewServiceServer
 // for forward compatibility
 type ViewServiceServer interface {
 	CreateView(context.Context, *CreateViewRequest) (*CreateViewResponse, error)
 	GetResumeViews(context.Context, *GetResumeViewsRequest) (*GetResumeViewsResponse, error)
+	GetResumeViewStats(context.Context, *GetResumeViewStatsRequest) (*GetResumeViewStatsResponse, error)
+	BatchCreateViews(context.Context, *BatchCreateViewsRequest) (*BatchCreateViewsResponse, error)
+	StreamViews(ViewService_StreamViewsServer) error
+	WatchResumeViews(*WatchResumeViewsRequest, ViewService_WatchResumeViewsServer) error
+	GetCompanyViews(context.Context, *GetCompanyViewsRequest) (*GetCompanyViewsResponse, error)
+	EraseViews(context.Context, *EraseViewsRequest) (*EraseViewsResponse, error)
+	ExportViews(*ExportViewsRequest, ViewService_ExportViewsServer) error
 	mustEmbedUnimplementedViewServiceServer()
 }
 
@@ -76,6 +231,27 @@ func (UnimplementedViewServiceServer) CreateView(context.Context, *CreateViewReq
 func (UnimplementedViewServiceServer) GetResumeViews(context.Context, *GetResumeViewsRequest) (*GetResumeViewsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetResumeViews not implemented")
 }
+func (UnimplementedViewServiceServer) GetResumeViewStats(context.Context, *GetResumeViewStatsRequest) (*GetResumeViewStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResumeViewStats not implemented")
+}
+func (UnimplementedViewServiceServer) BatchCreateViews(context.Context, *BatchCreateViewsRequest) (*BatchCreateViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateViews not implemented")
+}
+func (UnimplementedViewServiceServer) StreamViews(ViewService_StreamViewsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamViews not implemented")
+}
+func (UnimplementedViewServiceServer) WatchResumeViews(*WatchResumeViewsRequest, ViewService_WatchResumeViewsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchResumeViews not implemented")
+}
+func (UnimplementedViewServiceServer) GetCompanyViews(context.Context, *GetCompanyViewsRequest) (*GetCompanyViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCompanyViews not implemented")
+}
+func (UnimplementedViewServiceServer) EraseViews(context.Context, *EraseViewsRequest) (*EraseViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EraseViews not implemented")
+}
+func (UnimplementedViewServiceServer) ExportViews(*ExportViewsRequest, ViewService_ExportViewsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportViews not implemented")
+}
 func (UnimplementedViewServiceServer) mustEmbedUnimplementedViewServiceServer() {}
 
 // UnsafeViewServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -125,6 +301,146 @@ func _ViewService_GetResumeViews_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ViewService_GetResumeViewStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResumeViewStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).GetResumeViewStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ViewService_GetResumeViewStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).GetResumeViewStats(ctx, req.(*GetResumeViewStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_BatchCreateViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).BatchCreateViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ViewService_BatchCreateViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).BatchCreateViews(ctx, req.(*BatchCreateViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_StreamViews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ViewServiceServer).StreamViews(&viewServiceStreamViewsServer{stream})
+}
+
+type ViewService_StreamViewsServer interface {
+	SendAndClose(*BatchCreateViewsResponse) error
+	Recv() (*CreateViewRequest, error)
+	grpc.ServerStream
+}
+
+type viewServiceStreamViewsServer struct {
+	grpc.ServerStream
+}
+
+func (x *viewServiceStreamViewsServer) SendAndClose(m *BatchCreateViewsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *viewServiceStreamViewsServer) Recv() (*CreateViewRequest, error) {
+	m := new(CreateViewRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ViewService_WatchResumeViews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchResumeViewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ViewServiceServer).WatchResumeViews(m, &viewServiceWatchResumeViewsServer{stream})
+}
+
+type ViewService_WatchResumeViewsServer interface {
+	Send(*View) error
+	grpc.ServerStream
+}
+
+type viewServiceWatchResumeViewsServer struct {
+	grpc.ServerStream
+}
+
+func (x *viewServiceWatchResumeViewsServer) Send(m *View) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ViewService_GetCompanyViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCompanyViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).GetCompanyViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ViewService_GetCompanyViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).GetCompanyViews(ctx, req.(*GetCompanyViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_EraseViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EraseViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ViewServiceServer).EraseViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ViewService_EraseViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ViewServiceServer).EraseViews(ctx, req.(*EraseViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ViewService_ExportViews_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportViewsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ViewServiceServer).ExportViews(m, &viewServiceExportViewsServer{stream})
+}
+
+type ViewService_ExportViewsServer interface {
+	Send(*ExportViewsChunk) error
+	grpc.ServerStream
+}
+
+type viewServiceExportViewsServer struct {
+	grpc.ServerStream
+}
+
+func (x *viewServiceExportViewsServer) Send(m *ExportViewsChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // ViewService_ServiceDesc is the grpc.ServiceDesc for ViewService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -140,7 +456,39 @@ var ViewService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetResumeViews",
 			Handler:    _ViewService_GetResumeViews_Handler,
 		},
+		{
+			MethodName: "GetResumeViewStats",
+			Handler:    _ViewService_GetResumeViewStats_Handler,
+		},
+		{
+			MethodName: "BatchCreateViews",
+			Handler:    _ViewService_BatchCreateViews_Handler,
+		},
+		{
+			MethodName: "GetCompanyViews",
+			Handler:    _ViewService_GetCompanyViews_Handler,
+		},
+		{
+			MethodName: "EraseViews",
+			Handler:    _ViewService_EraseViews_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamViews",
+			Handler:       _ViewService_StreamViews_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "WatchResumeViews",
+			Handler:       _ViewService_WatchResumeViews_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportViews",
+			Handler:       _ViewService_ExportViews_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "view.proto",
 }
@@ -24,11 +24,13 @@ func main() {
 		return
 	}
 
-	errCh := make(chan error, 1)
+	if err := application.Start(ctx); err != nil {
+		log.Errorf("failed to start application: %v", err)
 
-	application.Run(ctx, errCh)
+		return
+	}
 
-	application.Wait(cancel, errCh)
+	application.Wait()
 
 	if err := application.Stop(); err != nil {
 		log.Errorf("failed to stop application: %v", err)
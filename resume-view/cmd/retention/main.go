@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/app"
+	"github.com/Verce11o/resume-view/resume-view/internal/config"
+	"github.com/Verce11o/resume-view/resume-view/internal/repositories"
+	"github.com/Verce11o/resume-view/resume-view/internal/services"
+	postgresLib "github.com/Verce11o/resume-view/shared/db/postgres"
+	"github.com/Verce11o/resume-view/shared/logger"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+// Runs view retention once: creates upcoming partitions, then archives and removes expired ones
+// with the same VIEW_RETENTION_* settings the service uses.
+func main() {
+	cfg := config.Load()
+
+	log := logger.NewLogger(cfg.LogLevel)
+
+	if err := run(context.Background(), cfg, log); err != nil {
+		log.Errorf("retention failed: %v", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger) error {
+	db, err := postgresLib.New(ctx, postgresLib.Config{
+		User:     cfg.DB.User,
+		Password: cfg.DB.Password,
+		Host:     cfg.DB.Host,
+		Port:     cfg.DB.Port,
+		Database: cfg.DB.Name,
+		SSLMode:  cfg.DB.SSLMode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init db: %w", err)
+	}
+	defer db.Close()
+
+	tracer := noop.NewTracerProvider().Tracer("retention")
+	repo := repositories.NewViewRepository(db, tracer, cfg.Views.IdempotencyKeyTTL)
+	service := services.NewRetentionService(log, tracer, repo, app.RetentionConfig(cfg))
+
+	report, err := service.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("run: %w", err)
+	}
+
+	if report.Skipped {
+		log.Info("skipped, another retention run is in progress")
+
+		return nil
+	}
+
+	log.Infof("created %v, archived %v, removed %v", report.Created, report.Archived, report.Removed)
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/config"
+	"github.com/Verce11o/resume-view/resume-view/internal/repositories"
+	"github.com/Verce11o/resume-view/resume-view/internal/services"
+	postgresLib "github.com/Verce11o/resume-view/shared/db/postgres"
+	"github.com/Verce11o/resume-view/shared/logger"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+var errRollupsInconsistent = errors.New("rollups are inconsistent")
+
+// Maintains the view rollup tables: "backfill" rebuilds them from the views table,
+// "check" reports rows that disagree with it and exits non-zero if there are any.
+func main() {
+	cfg := config.Load()
+
+	log := logger.NewLogger(cfg.LogLevel)
+
+	if len(os.Args) != 2 {
+		log.Errorf("usage: %s backfill|check", os.Args[0])
+		os.Exit(2)
+	}
+
+	if err := run(context.Background(), cfg, log, os.Args[1]); err != nil {
+		log.Errorf("rollup %s failed: %v", os.Args[1], err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger, command string) error {
+	db, err := postgresLib.New(ctx, postgresLib.Config{
+		User:     cfg.DB.User,
+		Password: cfg.DB.Password,
+		Host:     cfg.DB.Host,
+		Port:     cfg.DB.Port,
+		Database: cfg.DB.Name,
+		SSLMode:  cfg.DB.SSLMode,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to init db: %w", err)
+	}
+	defer db.Close()
+
+	tracer := noop.NewTracerProvider().Tracer("rollup")
+	repo := repositories.NewViewRepository(db, tracer, cfg.Views.IdempotencyKeyTTL)
+	service := services.NewRollupService(log, tracer, repo)
+
+	switch command {
+	case "backfill":
+		resumes, err := service.Backfill(ctx)
+		if err != nil {
+			return fmt.Errorf("backfill: %w", err)
+		}
+
+		log.Infof("rebuilt rollups for %d resumes", resumes)
+	case "check":
+		mismatches, err := service.Check(ctx)
+		if err != nil {
+			return fmt.Errorf("check: %w", err)
+		}
+
+		if len(mismatches) > 0 {
+			return fmt.Errorf("%w: %d mismatches", errRollupsInconsistent, len(mismatches))
+		}
+
+		log.Info("rollups are consistent")
+	default:
+		return fmt.Errorf("unknown command %q, expected backfill or check", command)
+	}
+
+	return nil
+}
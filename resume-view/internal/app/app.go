@@ -2,35 +2,76 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
 	"github.com/Verce11o/resume-view/resume-view/internal/config"
 	viewgrpc "github.com/Verce11o/resume-view/resume-view/internal/handler/grpc"
 	metricsHandler "github.com/Verce11o/resume-view/resume-view/internal/handler/http"
 	kafkaHandler "github.com/Verce11o/resume-view/resume-view/internal/handler/kafka"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/abuse"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/healthcheck"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/lifecycle"
 	"github.com/Verce11o/resume-view/resume-view/internal/lib/metrics"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/notify"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/ratelimit"
 	"github.com/Verce11o/resume-view/resume-view/internal/repositories"
 	"github.com/Verce11o/resume-view/resume-view/internal/services"
 	postgresLib "github.com/Verce11o/resume-view/shared/db/postgres"
+	redisLib "github.com/Verce11o/resume-view/shared/db/redis"
 	kafkaLib "github.com/Verce11o/resume-view/shared/kafka"
 	"github.com/Verce11o/resume-view/shared/tracer"
-	"github.com/segmentio/kafka-go"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel/propagation"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	rateLimitBackendMemory = "memory"
+	rateLimitBackendRedis  = "redis"
+
+	abuseBackendMemory = "memory"
+	abuseBackendRedis  = "redis"
+
+	notifierLog     = "log"
+	notifierWebhook = "webhook"
+
+	maxWebhookBackoff = 30 * time.Second
 )
 
 type App struct {
 	cfg           *config.Config
 	log           *zap.SugaredLogger
+	trace         *tracer.JaegerTracing
+	db            *pgxpool.Pool
+	redis         *redis.Client
 	grpcServer    *grpc.Server
+	listener      net.Listener
 	metricsServer *metricsHandler.Server
+	gatewayServer *metricsHandler.GatewayServer
 	consumer      *kafkaHandler.Consumer
+	viewHandler   *kafkaHandler.ViewHandler
+	viewService   *services.ViewService
+	retention     *services.RetentionService
+	publisher     *kafkaHandler.Publisher
+	outbox        *services.OutboxRelay
+	notifications *services.NotificationService
+	health        *healthcheck.Monitor
+	healthServer  *health.Server
+	lifecycle     *lifecycle.Manager
 }
 
 func New(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger) (*App, error) {
@@ -51,10 +92,9 @@ func New(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger) (*App,
 		return nil, fmt.Errorf("failed to init db: %w", err)
 	}
 
-	kafkaClient, err := kafkaLib.New(ctx, kafkaLib.Config{
-		Host: cfg.Kafka.Host,
-		Port: cfg.Kafka.Port,
-	})
+	kafkaCfg := kafkaLib.Config{Host: cfg.Kafka.Host, Port: cfg.Kafka.Port}
+
+	kafkaClient, err := kafkaLib.New(ctx, kafkaCfg)
 
 	if err != nil {
 		return nil, fmt.Errorf("could not connect to kafka: %w", err)
@@ -66,8 +106,40 @@ func New(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger) (*App,
 		return nil, fmt.Errorf("failed to init metrics: %w", err)
 	}
 
-	repo := repositories.NewViewRepository(db, trace)
-	service := services.NewViewService(log, trace, repo, metric)
+	redisClient, err := newRedisClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []services.Option{
+		services.WithDedupWindow(cfg.Views.DedupWindow),
+		services.WithFeed(feed.NewHub(cfg.Views.FeedBufferSize, cfg.Views.FeedMaxSubscribers)),
+		services.WithArchiveDir(cfg.Retention.ArchiveDir),
+	}
+
+	limiter, err := newRateLimiter(cfg, redisClient)
+	if err != nil {
+		return nil, err
+	}
+
+	if limiter != nil {
+		opts = append(opts, services.WithRateLimiter(limiter))
+	}
+
+	if detector := newAbuseDetector(cfg, redisClient); detector != nil {
+		opts = append(opts, services.WithAbuseDetector(detector))
+	}
+
+	repo := repositories.NewViewRepository(db, trace, cfg.Views.IdempotencyKeyTTL)
+	service := services.NewViewService(log, trace, repo, metric, opts...)
+
+	if err := metrics.RegisterPool(db); err != nil {
+		return nil, fmt.Errorf("failed to init pool metrics: %w", err)
+	}
+
+	authenticator := auth.NewAuthenticator(cfg.Auth.JWTSignKey)
+	authInterceptor := viewgrpc.NewAuthInterceptor(authenticator,
+		healthpb.Health_Check_FullMethodName, healthpb.Health_Watch_FullMethodName)
 
 	server := grpc.NewServer(
 		grpc.StatsHandler(
@@ -75,83 +147,467 @@ func New(ctx context.Context, cfg *config.Config, log *zap.SugaredLogger) (*App,
 				otelgrpc.WithTracerProvider(trace.Provider),
 				otelgrpc.WithPropagators(propagation.TraceContext{}),
 			),
-		))
+		),
+		grpc.ChainUnaryInterceptor(metric.UnaryServerInterceptor(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(metric.StreamServerInterceptor(), authInterceptor.Stream()),
+	)
+
+	consumer := kafkaHandler.NewConsumer(log, kafkaClient, metric, kafkaHandler.ConsumerConfig{
+		Topic:           cfg.Kafka.Topic,
+		GroupID:         cfg.Kafka.GroupID,
+		DeadLetterTopic: cfg.Kafka.DeadLetterTopic,
+		Retry: kafkaHandler.RetryPolicy{
+			MaxRetries:     cfg.Kafka.MaxRetries,
+			InitialBackoff: cfg.Kafka.RetryBackoff,
+			MaxBackoff:     cfg.Kafka.MaxRetryBackoff,
+		},
+	})
 
-	consumer := kafkaHandler.NewConsumer(log, kafkaClient, cfg.Kafka.Topic, cfg.Kafka.GroupID)
+	if err := metrics.RegisterKafkaReader(consumer.Stats); err != nil {
+		return nil, fmt.Errorf("failed to init kafka metrics: %w", err)
+	}
+
+	viewHandler := kafkaHandler.NewViewHandler(log, trace.Tracer, service, metric)
 
-	metricsServer := metricsHandler.NewServer(log, cfg.HTTPServer.Port)
+	retention := services.NewRetentionService(log, trace.Tracer, repo, RetentionConfig(cfg))
+
+	publisher := kafkaHandler.NewPublisher(kafkaClient, cfg.Outbox.Topic)
+	outbox := services.NewOutboxRelay(trace.Tracer, repo, publisher, cfg.Outbox.BatchSize, cfg.Outbox.Lease)
+
+	notifier, err := newNotifier(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := services.NewNotificationService(log, trace.Tracer, repo, notifier, metric,
+		services.NotificationConfig{
+			DigestInterval: cfg.Notifications.DigestInterval,
+			BatchSize:      cfg.Notifications.BatchSize,
+			MaxAttempts:    cfg.Notifications.MaxAttempts,
+			Lease:          cfg.Notifications.Lease,
+		})
+
+	checks := []healthcheck.Check{
+		{Name: "postgres", Ping: db.Ping},
+		{Name: "kafka", Ping: func(ctx context.Context) error {
+			return kafkaLib.Ping(ctx, kafkaCfg)
+		}},
+	}
+
+	if redisClient != nil {
+		checks = append(checks, healthcheck.Check{Name: "redis", Ping: func(ctx context.Context) error {
+			if err := redisClient.Ping(ctx).Err(); err != nil {
+				return fmt.Errorf("failed to ping redis: %w", err)
+			}
+
+			return nil
+		}})
+	}
+
+	healthServer := health.NewServer()
+	monitor := healthcheck.NewMonitor(log, healthServer, cfg.Health.Interval, cfg.Health.Timeout,
+		[]string{pb.ViewService_ServiceDesc.ServiceName}, checks...)
+
+	metricsServer := metricsHandler.NewServer(log, cfg.HTTPServer.Port, monitor)
+	gatewayServer := metricsHandler.NewGatewayServer(cfg.GatewayServer.Port,
+		metricsHandler.NewGateway(log, trace.Tracer, service, authenticator), metricsHandler.CORS{
+			AllowedOrigins: cfg.GatewayServer.CORS.AllowedOrigins,
+			MaxAge:         cfg.GatewayServer.CORS.MaxAge,
+		})
 
 	viewgrpc.Register(log, service, server, trace.Tracer)
+	healthpb.RegisterHealthServer(server, healthServer)
 
-	return &App{
+	app := &App{
 		cfg:           cfg,
 		log:           log,
+		trace:         trace,
+		db:            db,
+		redis:         redisClient,
 		grpcServer:    server,
 		consumer:      consumer,
+		viewHandler:   viewHandler,
+		viewService:   service,
+		retention:     retention,
+		publisher:     publisher,
+		outbox:        outbox,
+		notifications: notifications,
+		health:        monitor,
+		healthServer:  healthServer,
 		metricsServer: metricsServer,
-	}, nil
+		gatewayServer: gatewayServer,
+		lifecycle:     lifecycle.NewManager(log, cfg.ShutdownTimeout),
+	}
+
+	app.addComponents()
+
+	return app, nil
 }
 
-func (a *App) Run(ctx context.Context, errCh chan error) {
-	l, err := net.Listen("tcp", fmt.Sprintf(":%s", a.cfg.GRPCServer.Port))
+// addComponents registers the components in dependency order, so they are stopped in reverse: the gRPC
+// server first, then the Kafka consumer, the outbox relay and notifications, the background jobs, the REST
+// gateway, the metrics and health server, the pool and the tracer.
+func (a *App) addComponents() {
+	a.lifecycle.Add(
+		lifecycle.Component{
+			Name: "tracer",
+			Stop: a.trace.Provider.Shutdown,
+		},
+		lifecycle.Component{
+			Name: "postgres",
+			Stop: func(_ context.Context) error {
+				a.db.Close()
+
+				return nil
+			},
+		},
+	)
+
+	if a.redis != nil {
+		a.lifecycle.Add(lifecycle.Component{
+			Name: "redis",
+			Stop: func(_ context.Context) error {
+				if err := a.redis.Close(); err != nil {
+					return fmt.Errorf("failed to close redis: %w", err)
+				}
+
+				return nil
+			},
+		})
+	}
 
-	if err != nil {
-		errCh <- fmt.Errorf("failed to listen tcp: %w", err)
+	a.lifecycle.Add(
+		lifecycle.Component{
+			Name: "http server",
+			Run: func(_ context.Context) error {
+				return a.metricsServer.Run()
+			},
+			Stop: a.metricsServer.Shutdown,
+		},
+		lifecycle.Component{
+			Name: "gateway server",
+			Run: func(_ context.Context) error {
+				return a.gatewayServer.Run()
+			},
+			Stop: a.gatewayServer.Shutdown,
+		},
+		lifecycle.Component{
+			Name: "health monitor",
+			Run: func(ctx context.Context) error {
+				a.health.Run(ctx)
+
+				return nil
+			},
+		},
+		lifecycle.Component{
+			Name: "idempotency key cleanup",
+			Run: func(ctx context.Context) error {
+				a.cleanupIdempotencyKeys(ctx)
+
+				return nil
+			},
+		},
+		lifecycle.Component{
+			Name: "view retention",
+			Run: func(ctx context.Context) error {
+				a.maintainPartitions(ctx)
+
+				return nil
+			},
+		},
+		lifecycle.Component{
+			Name: "notifications",
+			Run: func(ctx context.Context) error {
+				a.deliverNotifications(ctx)
+
+				return nil
+			},
+		},
+		lifecycle.Component{
+			Name: "outbox relay",
+			Run: func(ctx context.Context) error {
+				a.relayOutbox(ctx)
+
+				return nil
+			},
+			Stop: func(_ context.Context) error {
+				return a.publisher.Close()
+			},
+		},
+		lifecycle.Component{
+			Name: "kafka consumer",
+			Run: func(ctx context.Context) error {
+				return a.consumer.Consume(ctx, a.viewHandler.Handle)
+			},
+			Stop: func(ctx context.Context) error {
+				return errors.Join(a.consumer.Stop(ctx), a.consumer.Close())
+			},
+		},
+		lifecycle.Component{
+			Name:  "grpc server",
+			Start: a.listen,
+			Run: func(_ context.Context) error {
+				return a.grpcServer.Serve(a.listener)
+			},
+			Stop: a.stopGRPC,
+		},
+	)
+}
 
-		return
+func (a *App) listen(_ context.Context) error {
+	l, err := net.Listen("tcp", fmt.Sprintf(":%s", a.cfg.GRPCServer.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen tcp: %w", err)
 	}
 
+	a.listener = l
+
+	return nil
+}
+
+// stopGRPC reports the service as not serving, then waits for the active RPCs until ctx is done
+// and cancels the rest.
+func (a *App) stopGRPC(ctx context.Context) error {
+	a.healthServer.Shutdown()
+
+	stopped := make(chan struct{})
+
 	go func() {
-		if err = a.consumer.Consume(ctx, func(_ context.Context, message *kafka.Message) error {
-			a.log.Debugf("message on offset %d: %s", message.Offset, message.Value)
+		a.grpcServer.GracefulStop()
+		close(stopped)
+	}()
 
-			return nil
-		}); err != nil {
-			errCh <- fmt.Errorf("failed to consume: %w", err)
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		a.grpcServer.Stop()
 
+		return fmt.Errorf("failed to stop grpc server gracefully: %w", ctx.Err())
+	}
+}
+
+// Start starts every component. If one fails, the ones already started are stopped.
+func (a *App) Start(ctx context.Context) error {
+	if err := a.lifecycle.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start: %w", err)
+	}
+
+	return nil
+}
+
+func (a *App) cleanupIdempotencyKeys(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Views.IdempotencyCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			deleted, err := a.viewService.DeleteExpiredIdempotencyKeys(ctx)
+			if err != nil {
+				a.log.Errorf("failed to clean up idempotency keys: %v", err)
+
+				continue
+			}
+
+			a.log.Debugf("deleted %d expired idempotency keys", deleted)
+		case <-ctx.Done():
 			return
 		}
-	}()
+	}
+}
 
-	go func() {
-		if err = a.grpcServer.Serve(l); err != nil {
-			errCh <- fmt.Errorf("failed to serve grpc: %w", err)
+// relayOutbox publishes the pending outbox events on every interval. Events left by a failed run are
+// retried on the next one.
+func (a *App) relayOutbox(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Outbox.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			published, err := a.outbox.Relay(ctx)
+			if err != nil {
+				a.log.Errorf("failed to relay outbox: %v", err)
+
+				continue
+			}
+
+			if published > 0 {
+				a.log.Debugf("published %d outbox events", published)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
+func (a *App) deliverNotifications(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Notifications.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			delivered, err := a.notifications.Deliver(ctx)
+			if err != nil {
+				a.log.Errorf("failed to deliver notifications: %v", err)
+
+				continue
+			}
+
+			if delivered > 0 {
+				a.log.Debugf("delivered %d notifications", delivered)
+			}
+		case <-ctx.Done():
 			return
 		}
-	}()
+	}
+}
 
-	go func() {
-		if err = a.metricsServer.Run(); err != nil {
-			errCh <- fmt.Errorf("failed to serve http: %w", err)
+// maintainPartitions runs retention at startup, so the partitions of the current month exist, and then
+// on every interval. Runs are skipped while another replica holds the retention lock.
+func (a *App) maintainPartitions(ctx context.Context) {
+	ticker := time.NewTicker(a.cfg.Retention.Interval)
+	defer ticker.Stop()
+
+	for {
+		report, err := a.retention.Run(ctx)
+
+		switch {
+		case err != nil:
+			a.log.Errorf("failed to run view retention: %v", err)
+		case report.Skipped:
+			a.log.Info("view retention skipped, another replica is running it")
+		default:
+			a.log.Infof("view retention: created %v, archived %v, removed %v, expired %d default partition views",
+				report.Created, report.Archived, report.Removed, report.Expired)
+		}
 
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
 			return
 		}
-	}()
+	}
+}
+
+// newRedisClient connects to Redis when the rate limiter or the abuse detector keep their state there,
+// and returns nil otherwise.
+func newRedisClient(ctx context.Context, cfg *config.Config) (*redis.Client, error) {
+	if cfg.RateLimit.Backend != rateLimitBackendRedis && cfg.Abuse.Backend != abuseBackendRedis {
+		return nil, nil //nolint:nilnil // Redis is optional
+	}
+
+	client, err := redisLib.New(ctx, redisLib.Config{
+		Host:     cfg.Redis.Host,
+		Port:     cfg.Redis.Port,
+		Password: cfg.Redis.Password,
+		Database: cfg.Redis.Database,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to redis: %w", err)
+	}
+
+	return client, nil
 }
 
-func (a *App) Wait(cancel context.CancelFunc, errCh chan error) {
+// newRateLimiter returns the limiter of the configured backend, or nil when views are not limited.
+func newRateLimiter(cfg *config.Config, client *redis.Client) (services.RateLimiter, error) {
+	limit := ratelimit.Limit{Rate: cfg.RateLimit.Rate, Burst: cfg.RateLimit.Burst}
+
+	var (
+		limiter services.RateLimiter
+		err     error
+	)
+
+	switch cfg.RateLimit.Backend {
+	case rateLimitBackendMemory:
+		limiter, err = ratelimit.NewMemoryLimiter(limit)
+	case rateLimitBackendRedis:
+		limiter, err = ratelimit.NewRedisLimiter(client, limit)
+	default:
+		return nil, nil //nolint:nilnil // views are not limited
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit: %w", err)
+	}
+
+	return limiter, nil
+}
+
+// newAbuseDetector returns the detector of the configured backend, or nil when views are not checked.
+func newAbuseDetector(cfg *config.Config, client *redis.Client) services.AbuseDetector {
+	rules := abuse.Rules{
+		MaxViews:         cfg.Abuse.MaxViews,
+		ViewWindow:       cfg.Abuse.ViewWindow,
+		MaxResumes:       cfg.Abuse.MaxResumes,
+		ResumeWindow:     cfg.Abuse.ResumeWindow,
+		BlockedCompanies: cfg.Abuse.BlockedCompanies,
+	}
+
+	switch cfg.Abuse.Backend {
+	case abuseBackendMemory:
+		return abuse.NewDetector(abuse.NewMemoryWindows(), rules)
+	case abuseBackendRedis:
+		return abuse.NewDetector(abuse.NewRedisWindows(client), rules)
+	}
+
+	return nil
+}
+
+func newNotifier(cfg *config.Config, log *zap.SugaredLogger) (services.Notifier, error) {
+	switch cfg.Notifications.Notifier {
+	case notifierLog:
+		return notify.NewLogNotifier(log), nil
+	case notifierWebhook:
+		if cfg.Notifications.WebhookURL == "" || cfg.Notifications.WebhookSecret == "" {
+			return nil, errors.New("webhook notifier requires NOTIFICATION_WEBHOOK_URL and NOTIFICATION_WEBHOOK_SECRET")
+		}
+
+		return notify.NewWebhookNotifier(notify.WebhookConfig{
+			URL:            cfg.Notifications.WebhookURL,
+			Secret:         cfg.Notifications.WebhookSecret,
+			Timeout:        cfg.Notifications.WebhookTimeout,
+			MaxRetries:     cfg.Notifications.WebhookRetries,
+			InitialBackoff: cfg.Notifications.WebhookBackoff,
+			MaxBackoff:     maxWebhookBackoff,
+		}), nil
+	}
+
+	return nil, fmt.Errorf("unknown notifier %q", cfg.Notifications.Notifier)
+}
+
+// RetentionConfig maps the retention settings for services.RetentionService.
+func RetentionConfig(cfg *config.Config) services.RetentionConfig {
+	return services.RetentionConfig{
+		Period:        cfg.Retention.Period,
+		PremakeMonths: cfg.Retention.PremakeMonths,
+		ArchiveDir:    cfg.Retention.ArchiveDir,
+		DetachOnly:    cfg.Retention.DetachOnly,
+	}
+}
+
+// Wait blocks until a termination signal is received or a component fails.
+func (a *App) Wait() {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 	defer signal.Stop(quit)
-	defer cancel()
 
 	select {
-	case err := <-errCh:
+	case err := <-a.lifecycle.Errors():
 		a.log.Errorf("application terminated with error: %v", err)
 	case <-quit:
 	}
 }
 
+// Stop stops the components in reverse order within the shutdown timeout.
 func (a *App) Stop() error {
-	if err := a.consumer.Close(); err != nil {
-		a.log.Errorf("failed to close consumer: %v", err)
-
-		return fmt.Errorf("failed to close consumer: %w", err)
+	if err := a.lifecycle.Stop(); err != nil {
+		return fmt.Errorf("failed to stop: %w", err)
 	}
 
-	a.grpcServer.GracefulStop()
-
 	return nil
 }
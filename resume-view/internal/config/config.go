@@ -2,17 +2,29 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type Config struct {
-	LogLevel   string `env:"LOG_LEVEL" env-default:"DEBUG"`
-	GRPCServer GRPCServer
-	HTTPServer HTTPServer
-	DB         DB
-	Kafka      Kafka
-	Jaeger     Jaeger
+	LogLevel        string        `env:"LOG_LEVEL" env-default:"DEBUG"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" env-default:"15s"`
+	GRPCServer      GRPCServer
+	HTTPServer      HTTPServer
+	GatewayServer   GatewayServer
+	DB              DB
+	Kafka           Kafka
+	Jaeger          Jaeger
+	Views           Views
+	Retention       Retention
+	Health          Health
+	Auth            Auth
+	RateLimit       RateLimit
+	Abuse           Abuse
+	Redis           Redis
+	Outbox          Outbox
+	Notifications   Notifications
 }
 
 type GRPCServer struct {
@@ -23,6 +35,16 @@ type HTTPServer struct {
 	Port string `env:"HTTP_SERVER_PORT" env-default:":3030"`
 }
 
+type GatewayServer struct {
+	Port string `env:"GATEWAY_SERVER_PORT" env-default:":3031"`
+	CORS CORS
+}
+
+type CORS struct {
+	AllowedOrigins []string      `env:"CORS_ALLOWED_ORIGINS" env-separator:"," env-default:"http://localhost:5174"`
+	MaxAge         time.Duration `env:"CORS_MAX_AGE" env-default:"10m"`
+}
+
 type DB struct {
 	User     string `env:"POSTGRES_USER" env-default:"postgres"`
 	Password string `env:"POSTGRES_PASSWORD" env-default:"vercello"`
@@ -33,10 +55,87 @@ type DB struct {
 }
 
 type Kafka struct {
-	Host    string `env:"KAFKA_HOST" env-default:"localhost"`
-	Port    string `env:"KAFKA_PORT" env-default:"9092"`
-	Topic   string `env:"KAFKA_TOPIC" env-default:"employees-events"`
-	GroupID string `env:"KAFKA_GROUP_ID" env-default:"Group1"`
+	Host            string        `env:"KAFKA_HOST" env-default:"localhost"`
+	Port            string        `env:"KAFKA_PORT" env-default:"9092"`
+	Topic           string        `env:"KAFKA_TOPIC" env-default:"resume-views"`
+	GroupID         string        `env:"KAFKA_GROUP_ID" env-default:"Group1"`
+	DeadLetterTopic string        `env:"KAFKA_DEAD_LETTER_TOPIC" env-default:"resume-views-dlq"`
+	MaxRetries      int           `env:"KAFKA_MAX_RETRIES" env-default:"3"`
+	RetryBackoff    time.Duration `env:"KAFKA_RETRY_BACKOFF" env-default:"200ms"`
+	MaxRetryBackoff time.Duration `env:"KAFKA_MAX_RETRY_BACKOFF" env-default:"5s"`
+}
+
+type Views struct {
+	DedupWindow                time.Duration `env:"VIEW_DEDUP_WINDOW" env-default:"0s"`
+	IdempotencyKeyTTL          time.Duration `env:"VIEW_IDEMPOTENCY_KEY_TTL" env-default:"24h"`
+	IdempotencyCleanupInterval time.Duration `env:"VIEW_IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"1h"`
+	FeedBufferSize             int           `env:"VIEW_FEED_BUFFER_SIZE" env-default:"64"`
+	FeedMaxSubscribers         int           `env:"VIEW_FEED_MAX_SUBSCRIBERS" env-default:"1000"`
+}
+
+type Retention struct {
+	Period        time.Duration `env:"VIEW_RETENTION_PERIOD" env-default:"0s"`
+	Interval      time.Duration `env:"VIEW_RETENTION_INTERVAL" env-default:"24h"`
+	PremakeMonths int           `env:"VIEW_PARTITION_PREMAKE_MONTHS" env-default:"2"`
+	ArchiveDir    string        `env:"VIEW_ARCHIVE_DIR" env-default:"archive"`
+	DetachOnly    bool          `env:"VIEW_RETENTION_DETACH_ONLY" env-default:"false"`
+}
+
+type Health struct {
+	Interval time.Duration `env:"HEALTH_CHECK_INTERVAL" env-default:"5s"`
+	Timeout  time.Duration `env:"HEALTH_CHECK_TIMEOUT" env-default:"2s"`
+}
+
+type Auth struct {
+	JWTSignKey string `env:"JWT_SIGN_KEY" env-required:"true"`
+}
+
+// RateLimit limits the views each company may record. Backend is "memory", "redis" or "none".
+type RateLimit struct {
+	Backend string  `env:"VIEW_RATE_LIMIT_BACKEND" env-default:"memory"`
+	Rate    float64 `env:"VIEW_RATE_LIMIT_RATE" env-default:"50"`
+	Burst   int     `env:"VIEW_RATE_LIMIT_BURST" env-default:"100"`
+}
+
+// Abuse flags the views of companies breaking an abuse rule as suspicious. Backend keeps the sliding
+// windows and is "memory", "redis" or "none". A zero limit disables its rule.
+type Abuse struct {
+	Backend          string        `env:"ABUSE_DETECTION_BACKEND" env-default:"memory"`
+	MaxViews         int           `env:"ABUSE_MAX_VIEWS" env-default:"120"`
+	ViewWindow       time.Duration `env:"ABUSE_VIEW_WINDOW" env-default:"1m"`
+	MaxResumes       int           `env:"ABUSE_MAX_RESUMES" env-default:"200"`
+	ResumeWindow     time.Duration `env:"ABUSE_RESUME_WINDOW" env-default:"10m"`
+	BlockedCompanies []string      `env:"ABUSE_BLOCKED_COMPANIES" env-separator:","`
+}
+
+type Redis struct {
+	Host     string `env:"REDIS_HOST" env-default:"localhost"`
+	Port     string `env:"REDIS_PORT" env-default:"6379"`
+	Password string `env:"REDIS_PASSWORD" env-default:""`
+	Database int    `env:"REDIS_DB" env-default:"0"`
+}
+
+// Outbox configures the relay that publishes "resume viewed" events to Topic.
+type Outbox struct {
+	Topic     string        `env:"OUTBOX_TOPIC" env-default:"resume-viewed"`
+	Interval  time.Duration `env:"OUTBOX_RELAY_INTERVAL" env-default:"1s"`
+	BatchSize int           `env:"OUTBOX_BATCH_SIZE" env-default:"100"`
+	Lease     time.Duration `env:"OUTBOX_LEASE" env-default:"1m"`
+}
+
+// Notifications configures how resume owners are notified. Notifier is "log" or "webhook".
+type Notifications struct {
+	Notifier       string        `env:"NOTIFIER" env-default:"log"`
+	Interval       time.Duration `env:"NOTIFICATION_INTERVAL" env-default:"10s"`
+	DigestInterval time.Duration `env:"NOTIFICATION_DIGEST_INTERVAL" env-default:"24h"`
+	BatchSize      int           `env:"NOTIFICATION_BATCH_SIZE" env-default:"100"`
+	MaxAttempts    int           `env:"NOTIFICATION_MAX_ATTEMPTS" env-default:"10"`
+	Lease          time.Duration `env:"NOTIFICATION_LEASE" env-default:"10m"`
+	WebhookURL     string        `env:"NOTIFICATION_WEBHOOK_URL" env-default:""`
+	WebhookSecret  string        `env:"NOTIFICATION_WEBHOOK_SECRET" env-default:""`
+	WebhookTimeout time.Duration `env:"NOTIFICATION_WEBHOOK_TIMEOUT" env-default:"5s"`
+	WebhookRetries int           `env:"NOTIFICATION_WEBHOOK_RETRIES" env-default:"3"`
+	WebhookBackoff time.Duration `env:"NOTIFICATION_WEBHOOK_BACKOFF" env-default:"500ms"`
 }
 
 type Jaeger struct {
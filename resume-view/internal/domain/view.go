@@ -0,0 +1,124 @@
+package domain
+
+import "time"
+
+type CreateView struct {
+	ResumeID       string
+	CompanyID      string
+	IdempotencyKey string
+	Viewer         ViewerContext
+	// DedupWindow collapses the view into the last counted one of the same company within the window.
+	DedupWindow time.Duration
+	// Suspicious stores the view without counting it, because it broke an abuse rule.
+	Suspicious bool
+}
+
+type ViewSource string
+
+const (
+	ViewSourceDirect         ViewSource = "direct"
+	ViewSourceSearch         ViewSource = "search"
+	ViewSourceRecommendation ViewSource = "recommendation"
+	ViewSourceNotification   ViewSource = "notification"
+	ViewSourceAPI            ViewSource = "api"
+)
+
+func (s ViewSource) Valid() bool {
+	switch s {
+	case ViewSourceDirect, ViewSourceSearch, ViewSourceRecommendation, ViewSourceNotification, ViewSourceAPI:
+		return true
+	default:
+		return false
+	}
+}
+
+// ViewerContext tells where a view came from. Every field is optional.
+type ViewerContext struct {
+	Source       ViewSource
+	ViewerUserID string
+	UserAgent    string
+	Referrer     string
+	Metadata     map[string]string
+}
+
+type SortOrder string
+
+const (
+	SortNewestFirst SortOrder = "desc"
+	SortOldestFirst SortOrder = "asc"
+)
+
+// ListViews selects a page of a resume's views. Zero filters do not filter. Viewer matches its fields
+// exactly, except Metadata, which matches views whose metadata contains every given entry. Suspicious
+// views are left out unless IncludeSuspicious is set.
+type ListViews struct {
+	ResumeID          string
+	Cursor            string
+	CompanyID         string
+	Viewer            ViewerContext
+	From              time.Time
+	To                time.Time
+	Sort              SortOrder
+	PageSize          int
+	IncludeSuspicious bool
+}
+
+type ListCompanyViews struct {
+	CompanyID string
+	Cursor    string
+	PageSize  int
+}
+
+type StatsInterval string
+
+const (
+	StatsIntervalHour StatsInterval = "hour"
+	StatsIntervalDay  StatsInterval = "day"
+	StatsIntervalWeek StatsInterval = "week"
+)
+
+func (i StatsInterval) Duration() time.Duration {
+	switch i {
+	case StatsIntervalHour:
+		return time.Hour
+	case StatsIntervalWeek:
+		return 7 * 24 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// ViewStats counts the views of a resume. Suspicious views are left out unless IncludeSuspicious is set.
+type ViewStats struct {
+	ResumeID          string
+	From              time.Time
+	To                time.Time
+	Interval          StatsInterval
+	TopCompanies      int
+	IncludeSuspicious bool
+}
+
+// ViewSubject selects the views of a resume or the views of a company. Exactly one of the ids is set.
+type ViewSubject struct {
+	ResumeID  string
+	CompanyID string
+}
+
+// EraseViews erases the views of a subject. RequestedBy and Reason are kept in the erasure audit.
+type EraseViews struct {
+	Subject     ViewSubject
+	RequestedBy string
+	Reason      string
+}
+
+type ExportFormat string
+
+const (
+	ExportFormatNDJSON ExportFormat = "ndjson"
+	ExportFormatCSV    ExportFormat = "csv"
+)
+
+type ExportViews struct {
+	Subject ViewSubject
+	Format  ExportFormat
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const authorizationHeader = "authorization"
+
+// AuthInterceptor authenticates callers with a bearer token and authorizes every request message against
+// its claims: views may only be recorded and listed for the caller's company, resume views may only be
+// read by the owner of the resume, and views may only be erased and exported by administrators.
+type AuthInterceptor struct {
+	authenticator *auth.Authenticator
+	public        map[string]bool
+}
+
+// NewAuthInterceptor creates an AuthInterceptor. publicMethods, such as the health checks, are served
+// without a token.
+func NewAuthInterceptor(authenticator *auth.Authenticator, publicMethods ...string) *AuthInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+
+	return &AuthInterceptor{authenticator: authenticator, public: public}
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if i.public[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		claims, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, customerrors.GRPCError("authInterceptor", err)
+		}
+
+		if err := authorize(claims, req); err != nil {
+			return nil, customerrors.GRPCError("authInterceptor", err)
+		}
+
+		return handler(auth.WithClaims(ctx, claims), req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if i.public[info.FullMethod] {
+			return handler(srv, stream)
+		}
+
+		claims, err := i.authenticate(stream.Context())
+		if err != nil {
+			return customerrors.GRPCError("authInterceptor", err)
+		}
+
+		return handler(srv, &authorizedStream{
+			ServerStream: stream,
+			ctx:          auth.WithClaims(stream.Context(), claims),
+			claims:       claims,
+		})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context) (*auth.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var header string
+	if values := md.Get(authorizationHeader); len(values) > 0 {
+		header = values[0]
+	}
+
+	claims, err := i.authenticator.ParseAuthorization(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	return claims, nil
+}
+
+// authorizedStream authorizes every message the client sends, so a stream cannot switch to another
+// company or resume after it was opened.
+type authorizedStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	claims *auth.Claims
+}
+
+func (s *authorizedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err //nolint:wrapcheck // io.EOF must reach the handler as is
+	}
+
+	if err := authorize(s.claims, m); err != nil {
+		return customerrors.GRPCError("authInterceptor", err)
+	}
+
+	return nil
+}
+
+// authorize checks a request message against the caller's claims. Unknown messages are denied, so a new
+// RPC stays closed until it is added here.
+func authorize(claims *auth.Claims, req any) error {
+	switch r := req.(type) {
+	case *pb.CreateViewRequest:
+		return actsFor(claims, r.GetCompanyId())
+	case *pb.BatchCreateViewsRequest:
+		for _, view := range r.GetViews() {
+			if err := actsFor(claims, view.GetCompanyId()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	case *pb.GetCompanyViewsRequest:
+		return actsFor(claims, r.GetCompanyId())
+	case *pb.GetResumeViewsRequest:
+		return ownsResume(claims, r.GetResumeId())
+	case *pb.GetResumeViewStatsRequest:
+		return ownsResume(claims, r.GetResumeId())
+	case *pb.WatchResumeViewsRequest:
+		return ownsResume(claims, r.GetResumeId())
+	case *pb.EraseViewsRequest, *pb.ExportViewsRequest:
+		return isAdmin(claims)
+	}
+
+	return fmt.Errorf("%w: unsupported request %T", customerrors.ErrPermissionDenied, req)
+}
+
+func actsFor(claims *auth.Claims, companyID string) error {
+	if !claims.ActsFor(companyID) {
+		return fmt.Errorf("%w: caller does not act for company %q", customerrors.ErrPermissionDenied, companyID)
+	}
+
+	return nil
+}
+
+func ownsResume(claims *auth.Claims, resumeID string) error {
+	if !claims.OwnsResume(resumeID) {
+		return fmt.Errorf("%w: caller does not own resume %q", customerrors.ErrPermissionDenied, resumeID)
+	}
+
+	return nil
+}
+
+func isAdmin(claims *auth.Claims) error {
+	if !claims.Admin {
+		return fmt.Errorf("%w: caller is not an administrator", customerrors.ErrPermissionDenied)
+	}
+
+	return nil
+}
@@ -0,0 +1,324 @@
+//go:build !integration
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSignKey = "test-sign-key"
+
+// authViewService answers the RPCs used by the auth tests and records the claims the handlers saw.
+type authViewService struct {
+	fakeViewService
+	claims chan *auth.Claims
+}
+
+func (s *authViewService) CreateView(ctx context.Context, _ domain.CreateView) (models.CreatedView, error) {
+	claims, _ := auth.ClaimsFromContext(ctx)
+	s.claims <- claims
+
+	return models.CreatedView{ID: uuid.New()}, nil
+}
+
+func (s *authViewService) ListResumeView(_ context.Context, _ domain.ListViews) (models.ViewList, error) {
+	return models.ViewList{}, nil
+}
+
+func (s *authViewService) EraseViews(ctx context.Context, _ domain.EraseViews) (models.Erasure, error) {
+	claims, _ := auth.ClaimsFromContext(ctx)
+	s.claims <- claims
+
+	return models.Erasure{ID: uuid.New()}, nil
+}
+
+func newAuthTestConn(t *testing.T, service ViewService) *grpc.ClientConn {
+	t.Helper()
+
+	interceptor := NewAuthInterceptor(auth.NewAuthenticator(testSignKey),
+		healthpb.Health_Check_FullMethodName, healthpb.Health_Watch_FullMethodName)
+
+	return newTestConn(t, service,
+		grpc.ChainUnaryInterceptor(interceptor.Unary()),
+		grpc.ChainStreamInterceptor(interceptor.Stream()))
+}
+
+func withToken(t *testing.T, signKey string, claims auth.Claims, ttl time.Duration) context.Context {
+	t.Helper()
+
+	token, err := auth.NewAuthenticator(signKey).GenerateToken(claims, ttl)
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	claims := auth.Claims{UserID: "user", CompanyID: companyID, ResumeIDs: []string{resumeID}}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		call func(ctx context.Context, client pb.ViewServiceClient) error
+		code codes.Code
+	}{
+		{
+			name: "Missing token",
+			ctx:  context.Background(),
+			call: createView(companyID),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "Malformed header",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Token abc"),
+			call: createView(companyID),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "Foreign signature",
+			ctx:  withToken(t, "other-key", claims, time.Hour),
+			call: createView(companyID),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "Expired token",
+			ctx:  withToken(t, testSignKey, claims, -time.Minute),
+			call: createView(companyID),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "View for own company",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: createView(companyID),
+			code: codes.OK,
+		},
+		{
+			name: "View for another company",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: createView(uuid.NewString()),
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "View without company claim",
+			ctx:  withToken(t, testSignKey, auth.Claims{UserID: "user"}, time.Hour),
+			call: createView(companyID),
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "Views of own resume",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: getResumeViews(resumeID),
+			code: codes.OK,
+		},
+		{
+			name: "Views of another resume",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: getResumeViews("6630e5f1a6b1f2c3d4e5f6a8"),
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "Batch with a foreign company",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: func(ctx context.Context, client pb.ViewServiceClient) error {
+				_, err := client.BatchCreateViews(ctx, &pb.BatchCreateViewsRequest{Views: []*pb.CreateViewRequest{
+					{ResumeId: resumeID, CompanyId: companyID},
+					{ResumeId: resumeID, CompanyId: uuid.NewString()},
+				}})
+
+				return err
+			},
+			code: codes.PermissionDenied,
+		},
+		{
+			name: "Erasure by an administrator",
+			ctx:  withToken(t, testSignKey, auth.Claims{UserID: "admin", Admin: true}, time.Hour),
+			call: eraseViews(resumeID),
+			code: codes.OK,
+		},
+		{
+			name: "Erasure by the resume owner",
+			ctx:  withToken(t, testSignKey, claims, time.Hour),
+			call: eraseViews(resumeID),
+			code: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &authViewService{claims: make(chan *auth.Claims, 1)}
+			client := pb.NewViewServiceClient(newAuthTestConn(t, service))
+
+			err := tt.call(tt.ctx, client)
+
+			assert.Equal(t, tt.code, status.Code(err))
+		})
+	}
+}
+
+// TestAuthInterceptor_EmployeeServiceToken pins the issuer contract: the sign-in tokens of employee-service
+// carry only user_id, so they authenticate but authorize nothing.
+func TestAuthInterceptor_EmployeeServiceToken(t *testing.T) {
+	t.Parallel()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": uuid.NewString(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+		"iat":     time.Now().Unix(),
+	}).SignedString([]byte(testSignKey))
+	require.NoError(t, err)
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+
+	tests := []struct {
+		name string
+		call func(ctx context.Context, client pb.ViewServiceClient) error
+	}{
+		{name: "Create view", call: createView(uuid.NewString())},
+		{name: "Resume views", call: getResumeViews(resumeID)},
+		{name: "Erase views", call: eraseViews(resumeID)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := pb.NewViewServiceClient(newAuthTestConn(t, &authViewService{claims: make(chan *auth.Claims, 1)}))
+
+			assert.Equal(t, codes.PermissionDenied, status.Code(tt.call(ctx, client)))
+		})
+	}
+}
+
+func TestAuthInterceptor_ClaimsReachHandler(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+	service := &authViewService{claims: make(chan *auth.Claims, 1)}
+	client := pb.NewViewServiceClient(newAuthTestConn(t, service))
+
+	ctx := withToken(t, testSignKey, auth.Claims{UserID: "user", CompanyID: companyID}, time.Hour)
+	require.NoError(t, createView(companyID)(ctx, client))
+
+	claims := <-service.claims
+	require.NotNil(t, claims)
+	assert.Equal(t, "user", claims.UserID)
+}
+
+func TestAuthInterceptor_HealthIsPublic(t *testing.T) {
+	t.Parallel()
+
+	client := healthpb.NewHealthClient(newAuthTestConn(t, &authViewService{}))
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	claims := auth.Claims{UserID: "user", CompanyID: companyID, ResumeIDs: []string{resumeID}}
+
+	t.Run("Every streamed view is authorized", func(t *testing.T) {
+		t.Parallel()
+
+		service := &authViewService{}
+		client := pb.NewViewServiceClient(newAuthTestConn(t, service))
+
+		stream, err := client.StreamViews(withToken(t, testSignKey, claims, time.Hour))
+		require.NoError(t, err)
+
+		require.NoError(t, stream.Send(&pb.CreateViewRequest{ResumeId: resumeID, CompanyId: companyID}))
+
+		err = stream.Send(&pb.CreateViewRequest{ResumeId: resumeID, CompanyId: uuid.NewString()})
+		if err == nil || errors.Is(err, io.EOF) {
+			_, err = stream.CloseAndRecv()
+		}
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Empty(t, service.batches)
+	})
+
+	t.Run("Watching another resume", func(t *testing.T) {
+		t.Parallel()
+
+		service := &authViewService{fakeViewService: fakeViewService{
+			hub:        feed.NewHub(1, 1),
+			subscribed: make(chan struct{}),
+		}}
+		client := pb.NewViewServiceClient(newAuthTestConn(t, service))
+
+		stream, err := client.WatchResumeViews(withToken(t, testSignKey, claims, time.Hour),
+			&pb.WatchResumeViewsRequest{ResumeId: "6630e5f1a6b1f2c3d4e5f6a8"})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+
+	t.Run("Missing token", func(t *testing.T) {
+		t.Parallel()
+
+		client := pb.NewViewServiceClient(newAuthTestConn(t, &authViewService{}))
+
+		stream, err := client.WatchResumeViews(context.Background(), &pb.WatchResumeViewsRequest{ResumeId: resumeID})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
+
+func createView(companyID string) func(ctx context.Context, client pb.ViewServiceClient) error {
+	return func(ctx context.Context, client pb.ViewServiceClient) error {
+		_, err := client.CreateView(ctx, &pb.CreateViewRequest{ResumeId: "6630e5f1a6b1f2c3d4e5f6a7", CompanyId: companyID})
+
+		return err
+	}
+}
+
+func getResumeViews(resumeID string) func(ctx context.Context, client pb.ViewServiceClient) error {
+	return func(ctx context.Context, client pb.ViewServiceClient) error {
+		_, err := client.GetResumeViews(ctx, &pb.GetResumeViewsRequest{ResumeId: resumeID})
+
+		return err
+	}
+}
+
+func eraseViews(resumeID string) func(ctx context.Context, client pb.ViewServiceClient) error {
+	return func(ctx context.Context, client pb.ViewServiceClient) error {
+		_, err := client.EraseViews(ctx, &pb.EraseViewsRequest{ResumeId: resumeID})
+
+		return err
+	}
+}
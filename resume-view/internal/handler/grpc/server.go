@@ -2,23 +2,35 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"io"
 
 	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
 	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
 	"github.com/Verce11o/resume-view/resume-view/internal/models"
-	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
 )
 
 type ViewService interface {
-	CreateView(ctx context.Context, resumeID, companyID string) (uuid.UUID, error)
-	ListResumeView(ctx context.Context, cursor, resumeID string) (models.ViewList, error)
+	CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error)
+	BatchCreateViews(ctx context.Context, reqs []domain.CreateView) ([]models.CreateViewResult, error)
+	ListResumeView(ctx context.Context, req domain.ListViews) (models.ViewList, error)
+	ListCompanyViews(ctx context.Context, req domain.ListCompanyViews) (models.CompanyViewList, error)
+	GetResumeViewStats(ctx context.Context, req domain.ViewStats) (models.ViewStats, error)
+	WatchResumeViews(ctx context.Context, resumeID string) (*feed.Subscription, error)
+	EraseViews(ctx context.Context, req domain.EraseViews) (models.Erasure, error)
+	ExportViews(ctx context.Context, req domain.ExportViews, send func(chunk []byte) error) (int64, error)
 }
 
+// streamBatchSize is how many streamed views are buffered before they are written as one batch.
+const streamBatchSize = 100
+
 type Server struct {
 	log     *zap.SugaredLogger
 	service ViewService
@@ -34,15 +46,93 @@ func (s *Server) CreateView(ctx context.Context, request *pb.CreateViewRequest)
 	ctx, span := s.tracer.Start(ctx, "viewHandler.CreateView")
 	defer span.End()
 
-	viewID, err := s.service.CreateView(ctx, request.GetResumeId(), request.GetCompanyId())
+	view, err := s.service.CreateView(ctx, createViewFromProto(request))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, customerrors.GRPCError("viewHandler.CreateView", err)
+	}
+
+	return &pb.CreateViewResponse{ViewId: view.ID.String(), Collapsed: view.Collapsed}, nil
+}
+
+func (s *Server) BatchCreateViews(ctx context.Context,
+	request *pb.BatchCreateViewsRequest) (*pb.BatchCreateViewsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "viewHandler.BatchCreateViews")
+	defer span.End()
+
+	reqs := make([]domain.CreateView, 0, len(request.GetViews()))
+	for _, view := range request.GetViews() {
+		reqs = append(reqs, createViewFromProto(view))
+	}
+
+	results, err := s.service.BatchCreateViews(ctx, reqs)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return nil, status.Errorf(customerrors.ParseGRPCErrStatusCode(err), "viewHandler.CreateView: %v", err)
+		return nil, customerrors.GRPCError("viewHandler.BatchCreateViews", err)
 	}
 
-	return &pb.CreateViewResponse{ViewId: viewID.String()}, nil
+	resp := &pb.BatchCreateViewsResponse{}
+	appendResults(resp, results, 0)
+
+	return resp, nil
+}
+
+// StreamViews writes the streamed views in batches of streamBatchSize and replies with the results
+// of all of them once the client closes the stream.
+func (s *Server) StreamViews(stream pb.ViewService_StreamViewsServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "viewHandler.StreamViews")
+	defer span.End()
+
+	resp := &pb.BatchCreateViewsResponse{}
+	batch := make([]domain.CreateView, 0, streamBatchSize)
+	offset := 0
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := s.service.BatchCreateViews(ctx, batch)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return customerrors.GRPCError("viewHandler.StreamViews", err)
+		}
+
+		appendResults(resp, results, offset)
+		offset += len(batch)
+		batch = batch[:0]
+
+		return nil
+	}
+
+	for {
+		request, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if err = flush(); err != nil {
+				return err
+			}
+
+			return stream.SendAndClose(resp)
+		}
+
+		if err != nil {
+			return customerrors.GRPCError("viewHandler.StreamViews", err)
+		}
+
+		batch = append(batch, createViewFromProto(request))
+
+		if len(batch) == streamBatchSize {
+			if err = flush(); err != nil {
+				return err
+			}
+		}
+	}
 }
 
 func (s *Server) GetResumeViews(ctx context.Context,
@@ -50,13 +140,262 @@ func (s *Server) GetResumeViews(ctx context.Context,
 	ctx, span := s.tracer.Start(ctx, "viewHandler.GetResumeViews")
 	defer span.End()
 
-	viewList, err := s.service.ListResumeView(ctx, request.GetCursor(), request.GetResumeId())
+	req := domain.ListViews{
+		ResumeID:  request.GetResumeId(),
+		Cursor:    request.GetCursor(),
+		CompanyID: request.GetCompanyId(),
+		Viewer: domain.ViewerContext{
+			Source:       viewSourceFromProto(request.GetSource()),
+			ViewerUserID: request.GetViewerUserId(),
+			UserAgent:    request.GetUserAgent(),
+			Referrer:     request.GetReferrer(),
+			Metadata:     request.GetMetadata(),
+		},
+		Sort:              sortOrderFromProto(request.GetSort()),
+		PageSize:          int(request.GetPageSize()),
+		IncludeSuspicious: request.GetIncludeSuspicious(),
+	}
+
+	if request.GetFrom() != nil {
+		req.From = request.GetFrom().AsTime()
+	}
+
+	if request.GetTo() != nil {
+		req.To = request.GetTo().AsTime()
+	}
+
+	viewList, err := s.service.ListResumeView(ctx, req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return nil, status.Errorf(customerrors.ParseGRPCErrStatusCode(err), "viewHandler.ListResumeView: %v", err)
+		return nil, customerrors.GRPCError("viewHandler.ListResumeView", err)
 	}
 
 	return viewList.ToProto(), nil
 }
+
+func (s *Server) GetCompanyViews(ctx context.Context,
+	request *pb.GetCompanyViewsRequest) (*pb.GetCompanyViewsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "viewHandler.GetCompanyViews")
+	defer span.End()
+
+	list, err := s.service.ListCompanyViews(ctx, domain.ListCompanyViews{
+		CompanyID: request.GetCompanyId(),
+		Cursor:    request.GetCursor(),
+		PageSize:  int(request.GetPageSize()),
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, customerrors.GRPCError("viewHandler.GetCompanyViews", err)
+	}
+
+	return list.ToProto(), nil
+}
+
+func (s *Server) GetResumeViewStats(ctx context.Context,
+	request *pb.GetResumeViewStatsRequest) (*pb.GetResumeViewStatsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "viewHandler.GetResumeViewStats")
+	defer span.End()
+
+	req := domain.ViewStats{
+		ResumeID:          request.GetResumeId(),
+		Interval:          statsIntervalFromProto(request.GetInterval()),
+		TopCompanies:      int(request.GetTopCompanies()),
+		IncludeSuspicious: request.GetIncludeSuspicious(),
+	}
+
+	if request.GetFrom() != nil {
+		req.From = request.GetFrom().AsTime()
+	}
+
+	if request.GetTo() != nil {
+		req.To = request.GetTo().AsTime()
+	}
+
+	stats, err := s.service.GetResumeViewStats(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, customerrors.GRPCError("viewHandler.GetResumeViewStats", err)
+	}
+
+	return stats.ToProto(), nil
+}
+
+// WatchResumeViews pushes every view counted for the resume until the client goes away. A client that
+// cannot keep up is evicted and the stream ends with ResourceExhausted.
+func (s *Server) WatchResumeViews(request *pb.WatchResumeViewsRequest,
+	stream pb.ViewService_WatchResumeViewsServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "viewHandler.WatchResumeViews")
+	defer span.End()
+
+	sub, err := s.service.WatchResumeViews(ctx, request.GetResumeId())
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return customerrors.GRPCError("viewHandler.WatchResumeViews", err)
+	}
+
+	defer sub.Close()
+
+	for {
+		select {
+		case view, ok := <-sub.Views():
+			if !ok {
+				err = customerrors.ErrSubscriberEvicted
+				s.log.Infof("evicted slow watcher of resume %s", request.GetResumeId())
+
+				return customerrors.GRPCError("viewHandler.WatchResumeViews", err)
+			}
+
+			if err = stream.Send(view.ToProto()); err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				return customerrors.GRPCError("viewHandler.WatchResumeViews", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// EraseViews erases the views of a resume or a company on behalf of the calling administrator, who is
+// recorded in the erasure audit.
+func (s *Server) EraseViews(ctx context.Context, request *pb.EraseViewsRequest) (*pb.EraseViewsResponse, error) {
+	ctx, span := s.tracer.Start(ctx, "viewHandler.EraseViews")
+	defer span.End()
+
+	req := domain.EraseViews{
+		Subject: domain.ViewSubject{ResumeID: request.GetResumeId(), CompanyID: request.GetCompanyId()},
+		Reason:  request.GetReason(),
+	}
+
+	if claims, ok := auth.ClaimsFromContext(ctx); ok {
+		req.RequestedBy = claims.UserID
+	}
+
+	erasure, err := s.service.EraseViews(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, customerrors.GRPCError("viewHandler.EraseViews", err)
+	}
+
+	return erasure.ToProto(), nil
+}
+
+// ExportViews streams every view of a resume or a company as NDJSON, the default, or CSV. Chunks end at
+// record boundaries, so the client can process them as they arrive.
+func (s *Server) ExportViews(request *pb.ExportViewsRequest, stream pb.ViewService_ExportViewsServer) error {
+	ctx, span := s.tracer.Start(stream.Context(), "viewHandler.ExportViews")
+	defer span.End()
+
+	req := domain.ExportViews{
+		Subject: domain.ViewSubject{ResumeID: request.GetResumeId(), CompanyID: request.GetCompanyId()},
+		Format:  exportFormatFromProto(request.GetFormat()),
+	}
+
+	_, err := s.service.ExportViews(ctx, req, func(chunk []byte) error {
+		return stream.Send(&pb.ExportViewsChunk{Data: chunk}) //nolint:wrapcheck // wrapped by the service
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return customerrors.GRPCError("viewHandler.ExportViews", err)
+	}
+
+	return nil
+}
+
+func exportFormatFromProto(format pb.ExportFormat) domain.ExportFormat {
+	switch format {
+	case pb.ExportFormat_EXPORT_FORMAT_UNSPECIFIED, pb.ExportFormat_EXPORT_FORMAT_NDJSON:
+		return domain.ExportFormatNDJSON
+	case pb.ExportFormat_EXPORT_FORMAT_CSV:
+		return domain.ExportFormatCSV
+	default:
+		return ""
+	}
+}
+
+func sortOrderFromProto(order pb.SortOrder) domain.SortOrder {
+	switch order {
+	case pb.SortOrder_SORT_ORDER_NEWEST_FIRST:
+		return domain.SortNewestFirst
+	case pb.SortOrder_SORT_ORDER_OLDEST_FIRST:
+		return domain.SortOldestFirst
+	default:
+		return ""
+	}
+}
+
+func viewSourceFromProto(source pb.ViewSource) domain.ViewSource {
+	switch source {
+	case pb.ViewSource_VIEW_SOURCE_DIRECT:
+		return domain.ViewSourceDirect
+	case pb.ViewSource_VIEW_SOURCE_SEARCH:
+		return domain.ViewSourceSearch
+	case pb.ViewSource_VIEW_SOURCE_RECOMMENDATION:
+		return domain.ViewSourceRecommendation
+	case pb.ViewSource_VIEW_SOURCE_NOTIFICATION:
+		return domain.ViewSourceNotification
+	case pb.ViewSource_VIEW_SOURCE_API:
+		return domain.ViewSourceAPI
+	default:
+		return ""
+	}
+}
+
+func statsIntervalFromProto(interval pb.StatsInterval) domain.StatsInterval {
+	switch interval {
+	case pb.StatsInterval_STATS_INTERVAL_HOUR:
+		return domain.StatsIntervalHour
+	case pb.StatsInterval_STATS_INTERVAL_DAY:
+		return domain.StatsIntervalDay
+	case pb.StatsInterval_STATS_INTERVAL_WEEK:
+		return domain.StatsIntervalWeek
+	default:
+		return ""
+	}
+}
+
+func createViewFromProto(request *pb.CreateViewRequest) domain.CreateView {
+	return domain.CreateView{
+		ResumeID:       request.GetResumeId(),
+		CompanyID:      request.GetCompanyId(),
+		IdempotencyKey: request.GetIdempotencyKey(),
+		Viewer: domain.ViewerContext{
+			Source:       viewSourceFromProto(request.GetSource()),
+			ViewerUserID: request.GetViewerUserId(),
+			UserAgent:    request.GetUserAgent(),
+			Referrer:     request.GetReferrer(),
+			Metadata:     request.GetMetadata(),
+		},
+	}
+}
+
+func appendResults(resp *pb.BatchCreateViewsResponse, results []models.CreateViewResult, offset int) {
+	for i, result := range results {
+		item := &pb.CreateViewResult{Index: int32(offset + i)}
+
+		if result.Err != nil {
+			item.Code = int32(customerrors.ParseGRPCErrStatusCode(result.Err))
+			item.Error = result.Err.Error()
+			resp.Failed++
+		} else {
+			item.ViewId = result.View.ID.String()
+			item.Collapsed = result.View.Collapsed
+			resp.Created++
+		}
+
+		resp.Results = append(resp.Results, item)
+	}
+}
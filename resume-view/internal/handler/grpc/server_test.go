@@ -0,0 +1,380 @@
+//go:build !integration
+
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type fakeViewService struct {
+	ViewService
+	mu         sync.Mutex
+	batches    [][]domain.CreateView
+	hub        *feed.Hub
+	subscribed chan struct{}
+	backlog    []models.View
+	exports    chan domain.ExportViews
+	lists      chan domain.ListViews
+}
+
+func (s *fakeViewService) ListResumeView(_ context.Context, req domain.ListViews) (models.ViewList, error) {
+	s.lists <- req
+
+	return models.ViewList{Views: s.backlog, Total: len(s.backlog)}, nil
+}
+
+func (s *fakeViewService) WatchResumeViews(_ context.Context, resumeID string) (*feed.Subscription, error) {
+	if resumeID == "" {
+		return nil, customerrors.ErrInvalidResumeID
+	}
+
+	sub, err := s.hub.Subscribe(resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, view := range s.backlog {
+		s.hub.Publish(view)
+	}
+
+	close(s.subscribed)
+
+	return sub, nil
+}
+
+func (s *fakeViewService) BatchCreateViews(_ context.Context,
+	reqs []domain.CreateView) ([]models.CreateViewResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.batches = append(s.batches, append([]domain.CreateView(nil), reqs...))
+
+	results := make([]models.CreateViewResult, 0, len(reqs))
+	for _, req := range reqs {
+		if req.CompanyID == "" {
+			results = append(results, models.CreateViewResult{Err: customerrors.ErrInvalidCompanyID})
+
+			continue
+		}
+
+		results = append(results, models.CreateViewResult{View: models.CreatedView{ID: uuid.New()}})
+	}
+
+	return results, nil
+}
+
+func (s *fakeViewService) ExportViews(_ context.Context, req domain.ExportViews,
+	send func(chunk []byte) error) (int64, error) {
+	s.exports <- req
+
+	if req.Subject.ResumeID == "" && req.Subject.CompanyID == "" {
+		return 0, customerrors.ErrInvalidSubject
+	}
+
+	for _, chunk := range []string{"first\n", "second\n"} {
+		if err := send([]byte(chunk)); err != nil {
+			return 0, err
+		}
+	}
+
+	return 2, nil
+}
+
+func newTestClient(t *testing.T, service ViewService, opts ...grpc.ServerOption) pb.ViewServiceClient {
+	t.Helper()
+
+	conn := newTestConn(t, service, opts...)
+
+	return pb.NewViewServiceClient(conn)
+}
+
+func newTestConn(t *testing.T, service ViewService, opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	Register(zap.NewNop().Sugar(), service, server, noop.NewTracerProvider().Tracer("test"))
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func TestServer_StreamViews(t *testing.T) {
+	t.Parallel()
+
+	service := &fakeViewService{}
+	client := newTestClient(t, service)
+
+	stream, err := client.StreamViews(context.Background())
+	require.NoError(t, err)
+
+	total := streamBatchSize + 3
+	for i := 0; i < total; i++ {
+		companyID := uuid.NewString()
+		if i == streamBatchSize+1 {
+			companyID = ""
+		}
+
+		err = stream.Send(&pb.CreateViewRequest{ResumeId: "6630e5f1a6b1f2c3d4e5f6a7", CompanyId: companyID})
+		require.NoError(t, err)
+	}
+
+	resp, err := stream.CloseAndRecv()
+	require.NoError(t, err)
+
+	require.Len(t, service.batches, 2)
+	assert.Len(t, service.batches[0], streamBatchSize)
+	assert.Len(t, service.batches[1], 3)
+
+	require.Len(t, resp.GetResults(), total)
+	assert.Equal(t, int32(total-1), resp.GetCreated())
+	assert.Equal(t, int32(1), resp.GetFailed())
+
+	for i, result := range resp.GetResults() {
+		assert.Equal(t, int32(i), result.GetIndex())
+	}
+
+	failed := resp.GetResults()[streamBatchSize+1]
+	assert.Equal(t, int32(codes.InvalidArgument), failed.GetCode())
+	assert.Empty(t, failed.GetViewId())
+}
+
+func TestServer_BatchCreateViews(t *testing.T) {
+	t.Parallel()
+
+	service := &fakeViewService{}
+	client := newTestClient(t, service)
+
+	resp, err := client.BatchCreateViews(context.Background(), &pb.BatchCreateViewsRequest{
+		Views: []*pb.CreateViewRequest{
+			{
+				ResumeId:     "6630e5f1a6b1f2c3d4e5f6a7",
+				CompanyId:    uuid.NewString(),
+				Source:       pb.ViewSource_VIEW_SOURCE_RECOMMENDATION,
+				ViewerUserId: "user",
+				UserAgent:    "Mozilla/5.0",
+				Referrer:     "https://example.com",
+				Metadata:     map[string]string{"campaign": "spring"},
+			},
+			{ResumeId: "6630e5f1a6b1f2c3d4e5f6a7"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), resp.GetCreated())
+	assert.Equal(t, int32(1), resp.GetFailed())
+	assert.NotEmpty(t, resp.GetResults()[0].GetViewId())
+	assert.Equal(t, int32(codes.InvalidArgument), resp.GetResults()[1].GetCode())
+
+	require.Len(t, service.batches, 1)
+	assert.Equal(t, domain.ViewerContext{
+		Source:       domain.ViewSourceRecommendation,
+		ViewerUserID: "user",
+		UserAgent:    "Mozilla/5.0",
+		Referrer:     "https://example.com",
+		Metadata:     map[string]string{"campaign": "spring"},
+	}, service.batches[0][0].Viewer)
+	assert.Zero(t, service.batches[0][1].Viewer)
+}
+
+func TestServer_GetResumeViews(t *testing.T) {
+	t.Parallel()
+
+	view := models.View{
+		ID:           uuid.New(),
+		ResumeID:     "6630e5f1a6b1f2c3d4e5f6a7",
+		CompanyID:    uuid.New(),
+		Source:       domain.ViewSourceSearch,
+		ViewerUserID: "user",
+		Metadata:     map[string]string{"campaign": "spring"},
+	}
+	service := &fakeViewService{backlog: []models.View{view}, lists: make(chan domain.ListViews, 1)}
+	client := newTestClient(t, service)
+
+	resp, err := client.GetResumeViews(context.Background(), &pb.GetResumeViewsRequest{
+		ResumeId:          view.ResumeID,
+		Source:            pb.ViewSource_VIEW_SOURCE_SEARCH,
+		ViewerUserId:      "user",
+		Metadata:          map[string]string{"campaign": "spring"},
+		IncludeSuspicious: true,
+	})
+	require.NoError(t, err)
+
+	req := <-service.lists
+	assert.Equal(t, domain.ViewerContext{
+		Source:       domain.ViewSourceSearch,
+		ViewerUserID: "user",
+		Metadata:     map[string]string{"campaign": "spring"},
+	}, req.Viewer)
+	assert.True(t, req.IncludeSuspicious)
+
+	require.Len(t, resp.GetViews(), 1)
+	assert.Equal(t, pb.ViewSource_VIEW_SOURCE_SEARCH, resp.GetViews()[0].GetSource())
+	assert.Equal(t, "user", resp.GetViews()[0].GetViewerUserId())
+	assert.Equal(t, map[string]string{"campaign": "spring"}, resp.GetViews()[0].GetMetadata())
+}
+
+func TestServer_WatchResumeViews(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+
+	t.Run("Views are pushed to the watcher", func(t *testing.T) {
+		t.Parallel()
+
+		service := &fakeViewService{hub: feed.NewHub(4, 4), subscribed: make(chan struct{})}
+		client := newTestClient(t, service)
+
+		stream, err := client.WatchResumeViews(context.Background(), &pb.WatchResumeViewsRequest{ResumeId: resumeID})
+		require.NoError(t, err)
+
+		<-service.subscribed
+
+		view := models.View{ID: uuid.New(), ResumeID: resumeID, CompanyID: uuid.New()}
+		service.hub.Publish(view)
+
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+
+		assert.Equal(t, view.ID.String(), resp.GetViewId())
+		assert.Equal(t, view.CompanyID.String(), resp.GetCompanyId())
+	})
+
+	t.Run("Slow watcher is evicted", func(t *testing.T) {
+		t.Parallel()
+
+		service := &fakeViewService{
+			hub:        feed.NewHub(1, 4),
+			subscribed: make(chan struct{}),
+			backlog:    []models.View{{ResumeID: resumeID}, {ResumeID: resumeID}},
+		}
+		client := newTestClient(t, service)
+
+		stream, err := client.WatchResumeViews(context.Background(), &pb.WatchResumeViewsRequest{ResumeId: resumeID})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.NoError(t, err, "buffered view is delivered before eviction")
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("Invalid resume id", func(t *testing.T) {
+		t.Parallel()
+
+		client := newTestClient(t, &fakeViewService{})
+
+		stream, err := client.WatchResumeViews(context.Background(), &pb.WatchResumeViewsRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestServer_ExportViews(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		request *pb.ExportViewsRequest
+		want    domain.ExportViews
+		chunks  []string
+		code    codes.Code
+	}{
+		{
+			name:    "NDJSON by default",
+			request: &pb.ExportViewsRequest{ResumeId: "6630e5f1a6b1f2c3d4e5f6a7"},
+			want: domain.ExportViews{
+				Subject: domain.ViewSubject{ResumeID: "6630e5f1a6b1f2c3d4e5f6a7"},
+				Format:  domain.ExportFormatNDJSON,
+			},
+			chunks: []string{"first\n", "second\n"},
+		},
+		{
+			name:    "CSV of a company",
+			request: &pb.ExportViewsRequest{CompanyId: "company", Format: pb.ExportFormat_EXPORT_FORMAT_CSV},
+			want:    domain.ExportViews{Subject: domain.ViewSubject{CompanyID: "company"}, Format: domain.ExportFormatCSV},
+			chunks:  []string{"first\n", "second\n"},
+		},
+		{
+			name:    "Invalid subject",
+			request: &pb.ExportViewsRequest{},
+			want:    domain.ExportViews{Format: domain.ExportFormatNDJSON},
+			code:    codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &fakeViewService{exports: make(chan domain.ExportViews, 1)}
+			client := newTestClient(t, service)
+
+			stream, err := client.ExportViews(context.Background(), tt.request)
+			require.NoError(t, err)
+
+			var chunks []string
+
+			for {
+				chunk, err := stream.Recv()
+				if err != nil {
+					if tt.code == codes.OK {
+						assert.ErrorIs(t, err, io.EOF)
+					} else {
+						assert.Equal(t, tt.code, status.Code(err))
+					}
+
+					break
+				}
+
+				chunks = append(chunks, string(chunk.GetData()))
+			}
+
+			assert.Equal(t, tt.chunks, chunks)
+			assert.Equal(t, tt.want, <-service.exports)
+		})
+	}
+}
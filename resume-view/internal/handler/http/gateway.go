@@ -0,0 +1,436 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// maxRequestBodySize caps the JSON bodies the gateway decodes, well above the largest valid view.
+const maxRequestBodySize = 64 << 10
+
+type ViewService interface {
+	CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error)
+	ListResumeView(ctx context.Context, req domain.ListViews) (models.ViewList, error)
+	ListCompanyViews(ctx context.Context, req domain.ListCompanyViews) (models.CompanyViewList, error)
+	GetResumeViewStats(ctx context.Context, req domain.ViewStats) (models.ViewStats, error)
+}
+
+// Gateway serves ViewService as REST/JSON, described by api/api.yml. Callers are authenticated and
+// authorized like on the gRPC API, and errors get the HTTP status of their gRPC code.
+type Gateway struct {
+	log           *zap.SugaredLogger
+	tracer        trace.Tracer
+	service       ViewService
+	authenticator *auth.Authenticator
+}
+
+func NewGateway(log *zap.SugaredLogger, tracer trace.Tracer, service ViewService,
+	authenticator *auth.Authenticator) *Gateway {
+	return &Gateway{log: log, tracer: tracer, service: service, authenticator: authenticator}
+}
+
+func (g *Gateway) register(mux *http.ServeMux) {
+	mux.HandleFunc("POST /views", g.authenticated(g.createView))
+	mux.HandleFunc("GET /resumes/{id}/views", g.authenticated(g.getResumeViews))
+	mux.HandleFunc("GET /resumes/{id}/views/stats", g.authenticated(g.getResumeViewStats))
+	mux.HandleFunc("GET /companies/{id}/views", g.authenticated(g.getCompanyViews))
+}
+
+type authenticatedHandler func(w http.ResponseWriter, r *http.Request, claims *auth.Claims)
+
+func (g *Gateway) authenticated(next authenticatedHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, err := g.authenticator.ParseAuthorization(r.Header.Get("Authorization"))
+		if err != nil {
+			g.writeError(w, err)
+
+			return
+		}
+
+		next(w, r.WithContext(auth.WithClaims(r.Context(), claims)), claims)
+	}
+}
+
+type createViewRequest struct {
+	ResumeID       string            `json:"resume_id"`
+	CompanyID      string            `json:"company_id"`
+	IdempotencyKey string            `json:"idempotency_key"`
+	Source         string            `json:"source"`
+	ViewerUserID   string            `json:"viewer_user_id"`
+	UserAgent      string            `json:"user_agent"`
+	Referrer       string            `json:"referrer"`
+	Metadata       map[string]string `json:"metadata"`
+}
+
+type createViewResponse struct {
+	ViewID    string `json:"view_id"`
+	Collapsed bool   `json:"collapsed"`
+	Replayed  bool   `json:"replayed"`
+}
+
+// createView answers 201 for a counted view and 200 when an existing view is returned. Bodies larger than
+// maxRequestBodySize are rejected with 413.
+func (g *Gateway) createView(w http.ResponseWriter, r *http.Request, claims *auth.Claims) {
+	ctx, span := g.tracer.Start(r.Context(), "viewGateway.CreateView")
+	defer span.End()
+
+	var (
+		request     createViewRequest
+		tooLargeErr *http.MaxBytesError
+	)
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	if errors.As(err, &tooLargeErr) {
+		g.writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{
+			Message: "request body exceeds " + strconv.FormatInt(tooLargeErr.Limit, 10) + " bytes",
+		})
+
+		return
+	}
+
+	if err == nil {
+		err = json.Unmarshal(body, &request)
+	}
+
+	if err != nil {
+		g.writeError(w, &customerrors.ValidationError{Violations: []customerrors.FieldViolation{
+			{Field: "body", Description: "must be a JSON object", Err: customerrors.ErrInvalidBody},
+		}})
+
+		return
+	}
+
+	if !claims.ActsFor(request.CompanyID) {
+		g.writeError(w, customerrors.ErrPermissionDenied)
+
+		return
+	}
+
+	view, err := g.service.CreateView(ctx, domain.CreateView{
+		ResumeID:       request.ResumeID,
+		CompanyID:      request.CompanyID,
+		IdempotencyKey: request.IdempotencyKey,
+		Viewer: domain.ViewerContext{
+			Source:       domain.ViewSource(request.Source),
+			ViewerUserID: request.ViewerUserID,
+			UserAgent:    request.UserAgent,
+			Referrer:     request.Referrer,
+			Metadata:     request.Metadata,
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		g.writeError(w, err)
+
+		return
+	}
+
+	code := http.StatusOK
+	if view.Counted() {
+		code = http.StatusCreated
+	}
+
+	g.writeJSON(w, code, createViewResponse{
+		ViewID:    view.ID.String(),
+		Collapsed: view.Collapsed,
+		Replayed:  view.Replayed,
+	})
+}
+
+func (g *Gateway) getResumeViews(w http.ResponseWriter, r *http.Request, claims *auth.Claims) {
+	ctx, span := g.tracer.Start(r.Context(), "viewGateway.GetResumeViews")
+	defer span.End()
+
+	resumeID := r.PathValue("id")
+	if !claims.OwnsResume(resumeID) {
+		g.writeError(w, customerrors.ErrPermissionDenied)
+
+		return
+	}
+
+	q := queryParser{values: r.URL.Query()}
+	req := domain.ListViews{
+		ResumeID:  resumeID,
+		Cursor:    q.values.Get("cursor"),
+		CompanyID: q.values.Get("company_id"),
+		Viewer: domain.ViewerContext{
+			Source:       domain.ViewSource(q.values.Get("source")),
+			ViewerUserID: q.values.Get("viewer_user_id"),
+			UserAgent:    q.values.Get("user_agent"),
+			Referrer:     q.values.Get("referrer"),
+			Metadata:     q.metadata("metadata"),
+		},
+		From:              q.time("from"),
+		To:                q.time("to"),
+		Sort:              q.sortOrder("sort"),
+		PageSize:          q.int("page_size"),
+		IncludeSuspicious: q.bool("include_suspicious"),
+	}
+
+	if err := q.err(); err != nil {
+		g.writeError(w, err)
+
+		return
+	}
+
+	list, err := g.service.ListResumeView(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		g.writeError(w, err)
+
+		return
+	}
+
+	g.writeJSON(w, http.StatusOK, list)
+}
+
+func (g *Gateway) getResumeViewStats(w http.ResponseWriter, r *http.Request, claims *auth.Claims) {
+	ctx, span := g.tracer.Start(r.Context(), "viewGateway.GetResumeViewStats")
+	defer span.End()
+
+	resumeID := r.PathValue("id")
+	if !claims.OwnsResume(resumeID) {
+		g.writeError(w, customerrors.ErrPermissionDenied)
+
+		return
+	}
+
+	q := queryParser{values: r.URL.Query()}
+	req := domain.ViewStats{
+		ResumeID:          resumeID,
+		From:              q.time("from"),
+		To:                q.time("to"),
+		Interval:          q.statsInterval("interval"),
+		TopCompanies:      q.int("top_companies"),
+		IncludeSuspicious: q.bool("include_suspicious"),
+	}
+
+	if err := q.err(); err != nil {
+		g.writeError(w, err)
+
+		return
+	}
+
+	stats, err := g.service.GetResumeViewStats(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		g.writeError(w, err)
+
+		return
+	}
+
+	g.writeJSON(w, http.StatusOK, stats)
+}
+
+func (g *Gateway) getCompanyViews(w http.ResponseWriter, r *http.Request, claims *auth.Claims) {
+	ctx, span := g.tracer.Start(r.Context(), "viewGateway.GetCompanyViews")
+	defer span.End()
+
+	companyID := r.PathValue("id")
+	if !claims.ActsFor(companyID) {
+		g.writeError(w, customerrors.ErrPermissionDenied)
+
+		return
+	}
+
+	q := queryParser{values: r.URL.Query()}
+	req := domain.ListCompanyViews{
+		CompanyID: companyID,
+		Cursor:    q.values.Get("cursor"),
+		PageSize:  q.int("page_size"),
+	}
+
+	if err := q.err(); err != nil {
+		g.writeError(w, err)
+
+		return
+	}
+
+	list, err := g.service.ListCompanyViews(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		g.writeError(w, err)
+
+		return
+	}
+
+	g.writeJSON(w, http.StatusOK, list)
+}
+
+// queryParser collects the violations of every malformed query parameter, like the service validators.
+type queryParser struct {
+	values     url.Values
+	violations []customerrors.FieldViolation
+}
+
+func (q *queryParser) time(name string) time.Time {
+	value := q.values.Get(name)
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		q.violate(name, "must be an RFC 3339 time", customerrors.ErrInvalidTimeRange)
+	}
+
+	return t
+}
+
+func (q *queryParser) int(name string) int {
+	value := q.values.Get(name)
+	if value == "" {
+		return 0
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		q.violate(name, "must be an integer", customerrors.ErrInvalidQuery)
+	}
+
+	return n
+}
+
+func (q *queryParser) bool(name string) bool {
+	value := q.values.Get(name)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		q.violate(name, "must be a boolean", customerrors.ErrInvalidQuery)
+	}
+
+	return b
+}
+
+// metadata reads deepObject style parameters such as metadata[campaign]=spring into a map.
+func (q *queryParser) metadata(name string) map[string]string {
+	var metadata map[string]string
+
+	for param, values := range q.values {
+		key, ok := strings.CutPrefix(param, name+"[")
+		if !ok || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+
+		metadata[strings.TrimSuffix(key, "]")] = values[0]
+	}
+
+	return metadata
+}
+
+func (q *queryParser) sortOrder(name string) domain.SortOrder {
+	switch value := q.values.Get(name); value {
+	case "":
+		return ""
+	case "newest":
+		return domain.SortNewestFirst
+	case "oldest":
+		return domain.SortOldestFirst
+	default:
+		q.violate(name, "must be newest or oldest", customerrors.ErrInvalidSortOrder)
+
+		return ""
+	}
+}
+
+func (q *queryParser) statsInterval(name string) domain.StatsInterval {
+	switch interval := domain.StatsInterval(q.values.Get(name)); interval {
+	case "", domain.StatsIntervalHour, domain.StatsIntervalDay, domain.StatsIntervalWeek:
+		return interval
+	default:
+		q.violate(name, "must be hour, day or week", customerrors.ErrInvalidStatsRange)
+
+		return ""
+	}
+}
+
+func (q *queryParser) violate(field, description string, err error) {
+	q.violations = append(q.violations, customerrors.FieldViolation{Field: field, Description: description, Err: err})
+}
+
+func (q *queryParser) err() error {
+	if len(q.violations) == 0 {
+		return nil
+	}
+
+	return &customerrors.ValidationError{Violations: q.violations}
+}
+
+type fieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+type errorResponse struct {
+	Message    string           `json:"message"`
+	Violations []fieldViolation `json:"violations,omitempty"`
+}
+
+// writeError answers with the status of err, its field violations and, when rate limited, a Retry-After
+// header. Internal errors are logged and not exposed.
+func (g *Gateway) writeError(w http.ResponseWriter, err error) {
+	code := customerrors.HTTPStatusCode(err)
+	resp := errorResponse{Message: err.Error()}
+
+	var (
+		validationErr *customerrors.ValidationError
+		rateLimitErr  *customerrors.RateLimitError
+	)
+
+	if errors.As(err, &validationErr) {
+		for _, v := range validationErr.Violations {
+			resp.Violations = append(resp.Violations, fieldViolation{Field: v.Field, Description: v.Description})
+		}
+	}
+
+	if errors.As(err, &rateLimitErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateLimitErr.RetryAfter.Seconds()))))
+	}
+
+	if code == http.StatusInternalServerError {
+		g.log.Errorf("gateway request failed: %v", err)
+		resp.Message = http.StatusText(code)
+	}
+
+	g.writeJSON(w, code, resp)
+}
+
+func (g *Gateway) writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		g.log.Errorf("failed to write gateway response: %v", err)
+	}
+}
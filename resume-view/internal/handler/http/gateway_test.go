@@ -0,0 +1,364 @@
+//go:build !integration
+
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/auth"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type fakeViewService struct {
+	ViewService
+	createView    func(req domain.CreateView) (models.CreatedView, error)
+	listViews     func(req domain.ListViews) (models.ViewList, error)
+	getViewStats  func(req domain.ViewStats) (models.ViewStats, error)
+	companyViews  func(req domain.ListCompanyViews) (models.CompanyViewList, error)
+	claimsInCalls []*auth.Claims
+}
+
+func (s *fakeViewService) CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error) {
+	claims, _ := auth.ClaimsFromContext(ctx)
+	s.claimsInCalls = append(s.claimsInCalls, claims)
+
+	return s.createView(req)
+}
+
+func (s *fakeViewService) ListResumeView(_ context.Context, req domain.ListViews) (models.ViewList, error) {
+	return s.listViews(req)
+}
+
+func (s *fakeViewService) GetResumeViewStats(_ context.Context, req domain.ViewStats) (models.ViewStats, error) {
+	return s.getViewStats(req)
+}
+
+func (s *fakeViewService) ListCompanyViews(_ context.Context,
+	req domain.ListCompanyViews) (models.CompanyViewList, error) {
+	return s.companyViews(req)
+}
+
+const testSignKey = "test-sign-key"
+
+var (
+	testCompanyID = uuid.NewString()
+	testResumeID  = "42"
+)
+
+func newTestGatewayServer(service ViewService) http.Handler {
+	gateway := NewGateway(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), service,
+		auth.NewAuthenticator(testSignKey))
+
+	return NewGatewayServer(":0", gateway, CORS{
+		AllowedOrigins: []string{"http://localhost:5174"},
+		MaxAge:         time.Minute,
+	}).routes()
+}
+
+func newTestToken(t *testing.T, claims auth.Claims) string {
+	t.Helper()
+
+	token, err := auth.NewAuthenticator(testSignKey).GenerateToken(claims, time.Hour)
+	require.NoError(t, err)
+
+	return "Bearer " + token
+}
+
+func TestGateway_CreateView(t *testing.T) {
+	t.Parallel()
+
+	company := newTestToken(t, auth.Claims{UserID: "user", CompanyID: testCompanyID})
+	otherCompany := newTestToken(t, auth.Claims{UserID: "user", CompanyID: uuid.NewString()})
+	body := fmt.Sprintf(`{"resume_id":%q,"company_id":%q,"idempotency_key":"key"}`, testResumeID, testCompanyID)
+	viewID := uuid.New()
+
+	tests := []struct {
+		name       string
+		token      string
+		body       string
+		view       models.CreatedView
+		err        error
+		code       int
+		response   string
+		retryAfter string
+	}{
+		{
+			name:     "Counted",
+			token:    company,
+			body:     body,
+			view:     models.CreatedView{ID: viewID},
+			code:     http.StatusCreated,
+			response: fmt.Sprintf(`{"view_id":%q,"collapsed":false,"replayed":false}`, viewID),
+		},
+		{
+			name:     "Replayed",
+			token:    company,
+			body:     body,
+			view:     models.CreatedView{ID: viewID, Replayed: true},
+			code:     http.StatusOK,
+			response: fmt.Sprintf(`{"view_id":%q,"collapsed":false,"replayed":true}`, viewID),
+		},
+		{
+			name:     "Missing token",
+			body:     body,
+			code:     http.StatusUnauthorized,
+			response: `{"message":"unauthenticated: empty authorization header"}`,
+		},
+		{
+			name:     "Other company",
+			token:    otherCompany,
+			body:     body,
+			code:     http.StatusForbidden,
+			response: `{"message":"permission denied"}`,
+		},
+		{
+			name:  "Malformed body",
+			token: company,
+			body:  `{"resume_id":`,
+			code:  http.StatusBadRequest,
+			response: `{"message":"invalid request: body: must be a JSON object",
+				"violations":[{"field":"body","description":"must be a JSON object"}]}`,
+		},
+		{
+			name:     "Body too large",
+			token:    company,
+			body:     fmt.Sprintf(`{"resume_id":%q}`, strings.Repeat("1", maxRequestBodySize)),
+			code:     http.StatusRequestEntityTooLarge,
+			response: `{"message":"request body exceeds 65536 bytes"}`,
+		},
+		{
+			name:  "Invalid request",
+			token: company,
+			body:  body,
+			err: &customerrors.ValidationError{Violations: []customerrors.FieldViolation{
+				{Field: "resume_id", Description: "must be a number", Err: customerrors.ErrInvalidResumeID},
+			}},
+			code: http.StatusBadRequest,
+			response: `{"message":"invalid request: resume_id: must be a number",
+				"violations":[{"field":"resume_id","description":"must be a number"}]}`,
+		},
+		{
+			name:       "Rate limited",
+			token:      company,
+			body:       body,
+			err:        &customerrors.RateLimitError{RetryAfter: 1500 * time.Millisecond},
+			code:       http.StatusTooManyRequests,
+			response:   `{"message":"rate limit exceeded, retry after 1.5s"}`,
+			retryAfter: "2",
+		},
+		{
+			name:     "Internal error",
+			token:    company,
+			body:     body,
+			err:      fmt.Errorf("failed to create view: %w", assert.AnError),
+			code:     http.StatusInternalServerError,
+			response: `{"message":"Internal Server Error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &fakeViewService{createView: func(req domain.CreateView) (models.CreatedView, error) {
+				assert.Equal(t, domain.CreateView{
+					ResumeID:       testResumeID,
+					CompanyID:      testCompanyID,
+					IdempotencyKey: "key",
+				}, req)
+
+				return tt.view, tt.err
+			}}
+
+			req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(tt.body))
+			if tt.token != "" {
+				req.Header.Set("Authorization", tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			newTestGatewayServer(service).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.code, rec.Code)
+			assert.JSONEq(t, tt.response, rec.Body.String())
+			assert.Equal(t, tt.retryAfter, rec.Header().Get("Retry-After"))
+
+			for _, claims := range service.claimsInCalls {
+				assert.Equal(t, testCompanyID, claims.CompanyID)
+			}
+		})
+	}
+}
+
+func TestGateway_GetResumeViews(t *testing.T) {
+	t.Parallel()
+
+	owner := newTestToken(t, auth.Claims{UserID: "user", ResumeIDs: []string{testResumeID}})
+	stranger := newTestToken(t, auth.Claims{UserID: "user", ResumeIDs: []string{"7"}})
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	list := models.ViewList{
+		Cursor: "next",
+		Views:  []models.View{{ID: uuid.New(), ResumeID: testResumeID, CompanyID: uuid.New(), ViewedAt: from}},
+		Total:  1,
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		query string
+		code  int
+	}{
+		{
+			name:  "Owner",
+			token: owner,
+			query: "?cursor=abc&page_size=5&sort=oldest&from=2024-05-01T00:00:00Z&source=search&" +
+				"metadata%5Bcampaign%5D=spring&include_suspicious=true&company_id=" + testCompanyID,
+			code: http.StatusOK,
+		},
+		{
+			name:  "Not the owner",
+			token: stranger,
+			code:  http.StatusForbidden,
+		},
+		{
+			name:  "Malformed query",
+			token: owner,
+			query: "?page_size=ten&sort=random&from=yesterday&include_suspicious=maybe",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := &fakeViewService{listViews: func(req domain.ListViews) (models.ViewList, error) {
+				assert.Equal(t, domain.ListViews{
+					ResumeID:  testResumeID,
+					Cursor:    "abc",
+					CompanyID: testCompanyID,
+					Viewer: domain.ViewerContext{
+						Source:   domain.ViewSourceSearch,
+						Metadata: map[string]string{"campaign": "spring"},
+					},
+					From:              from,
+					Sort:              domain.SortOldestFirst,
+					PageSize:          5,
+					IncludeSuspicious: true,
+				}, req)
+
+				return list, nil
+			}}
+
+			req := httptest.NewRequest(http.MethodGet, "/resumes/"+testResumeID+"/views"+tt.query, nil)
+			req.Header.Set("Authorization", tt.token)
+
+			rec := httptest.NewRecorder()
+			newTestGatewayServer(service).ServeHTTP(rec, req)
+
+			require.Equal(t, tt.code, rec.Code)
+
+			switch tt.code {
+			case http.StatusOK:
+				var resp models.ViewList
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, list, resp)
+			case http.StatusBadRequest:
+				var resp errorResponse
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, []fieldViolation{
+					{Field: "from", Description: "must be an RFC 3339 time"},
+					{Field: "sort", Description: "must be newest or oldest"},
+					{Field: "page_size", Description: "must be an integer"},
+					{Field: "include_suspicious", Description: "must be a boolean"},
+				}, resp.Violations)
+			}
+		})
+	}
+}
+
+func TestGateway_GetResumeViewStats(t *testing.T) {
+	t.Parallel()
+
+	owner := newTestToken(t, auth.Claims{UserID: "user", ResumeIDs: []string{testResumeID}})
+	stats := models.ViewStats{Total: 3, UniqueCompanies: 2}
+
+	service := &fakeViewService{getViewStats: func(req domain.ViewStats) (models.ViewStats, error) {
+		assert.Equal(t, domain.ViewStats{
+			ResumeID:          testResumeID,
+			Interval:          domain.StatsIntervalWeek,
+			TopCompanies:      3,
+			IncludeSuspicious: true,
+		}, req)
+
+		return stats, nil
+	}}
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/resumes/"+testResumeID+"/views/stats?interval=week&top_companies=3&include_suspicious=1", nil)
+	req.Header.Set("Authorization", owner)
+
+	rec := httptest.NewRecorder()
+	newTestGatewayServer(service).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var resp models.ViewStats
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, stats, resp)
+
+	req = httptest.NewRequest(http.MethodGet, "/resumes/"+testResumeID+"/views/stats?interval=month", nil)
+	req.Header.Set("Authorization", owner)
+
+	rec = httptest.NewRecorder()
+	newTestGatewayServer(service).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGateway_CORS(t *testing.T) {
+	t.Parallel()
+
+	req := httptest.NewRequest(http.MethodOptions, "/views", nil)
+	req.Header.Set("Origin", "http://localhost:5174")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "authorization")
+
+	rec := httptest.NewRecorder()
+	newTestGatewayServer(&fakeViewService{}).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "http://localhost:5174", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "60", rec.Header().Get("Access-Control-Max-Age"))
+
+	req.Header.Set("Origin", "http://evil.example")
+
+	rec = httptest.NewRecorder()
+	newTestGatewayServer(&fakeViewService{}).ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestGateway_InternalEndpoints(t *testing.T) {
+	t.Parallel()
+
+	for _, path := range []string{"/metrics", "/healthz", "/readyz"} {
+		rec := httptest.NewRecorder()
+		newTestGatewayServer(&fakeViewService{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, "%s is served on the internal port only", path)
+	}
+}
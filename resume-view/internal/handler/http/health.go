@@ -0,0 +1,77 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/healthcheck"
+	"github.com/goccy/go-json"
+)
+
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+	statusNotProbed   = "not probed"
+)
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// healthz is the liveness probe. It succeeds while the process serves HTTP and reports the last
+// dependency probes for information only, so an outage of a dependency does not restart the instance.
+func (s *Server) healthz(w http.ResponseWriter, _ *http.Request) {
+	resp := s.newHealthResponse(s.health.Statuses())
+	resp.Status = statusOK
+
+	s.writeHealth(w, http.StatusOK, resp)
+}
+
+// readyz is the readiness probe. It fails with 503 if any dependency was down, or not probed yet, on the
+// last probe of the health monitor. It never pings the dependencies itself, so callers of the public port
+// cannot make the instance open connections to them.
+func (s *Server) readyz(w http.ResponseWriter, _ *http.Request) {
+	resp := s.newHealthResponse(s.health.Statuses())
+
+	code := http.StatusOK
+	if resp.Status != statusOK {
+		code = http.StatusServiceUnavailable
+	}
+
+	s.writeHealth(w, code, resp)
+}
+
+// newHealthResponse reports every check with a fixed status, so the errors of the dependencies, which may
+// name hosts and users, stay in the log rather than reaching callers of the public port. The monitor warns
+// when a dependency goes down, so the errors behind each probe request are only logged at debug level.
+func (s *Server) newHealthResponse(statuses map[string]error) healthResponse {
+	resp := healthResponse{Status: statusOK, Checks: make(map[string]string, len(statuses))}
+
+	for name, err := range statuses {
+		switch {
+		case err == nil:
+			resp.Checks[name] = statusOK
+
+			continue
+		case errors.Is(err, healthcheck.ErrNotProbed):
+			resp.Checks[name] = statusNotProbed
+		default:
+			resp.Checks[name] = statusUnavailable
+			s.log.Debugf("health check %s failed: %v", name, err)
+		}
+
+		resp.Status = statusUnavailable
+	}
+
+	return resp
+}
+
+func (s *Server) writeHealth(w http.ResponseWriter, code int, resp healthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Errorf("failed to write health response: %v", err)
+	}
+}
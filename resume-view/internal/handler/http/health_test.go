@@ -0,0 +1,93 @@
+//go:build !integration
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/healthcheck"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeHealthChecker struct {
+	statuses map[string]error
+}
+
+func (c *fakeHealthChecker) Statuses() map[string]error {
+	return c.statuses
+}
+
+func TestServer_Health(t *testing.T) {
+	t.Parallel()
+
+	healthy := map[string]error{"postgres": nil, "kafka": nil}
+	broken := map[string]error{"postgres": assert.AnError, "kafka": nil}
+
+	tests := []struct {
+		name     string
+		path     string
+		checker  *fakeHealthChecker
+		code     int
+		response healthResponse
+	}{
+		{
+			name:     "Ready",
+			path:     "/readyz",
+			checker:  &fakeHealthChecker{statuses: healthy},
+			code:     http.StatusOK,
+			response: healthResponse{Status: "ok", Checks: map[string]string{"postgres": "ok", "kafka": "ok"}},
+		},
+		{
+			name:    "Not ready",
+			path:    "/readyz",
+			checker: &fakeHealthChecker{statuses: broken},
+			code:    http.StatusServiceUnavailable,
+			response: healthResponse{
+				Status: "unavailable",
+				Checks: map[string]string{"postgres": "unavailable", "kafka": "ok"},
+			},
+		},
+		{
+			name:    "Not probed yet",
+			path:    "/readyz",
+			checker: &fakeHealthChecker{statuses: map[string]error{"postgres": healthcheck.ErrNotProbed}},
+			code:    http.StatusServiceUnavailable,
+			response: healthResponse{
+				Status: "unavailable",
+				Checks: map[string]string{"postgres": "not probed"},
+			},
+		},
+		{
+			name:    "Alive with a broken dependency",
+			path:    "/healthz",
+			checker: &fakeHealthChecker{statuses: broken},
+			code:    http.StatusOK,
+			response: healthResponse{
+				Status: "ok",
+				Checks: map[string]string{"postgres": "unavailable", "kafka": "ok"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := NewServer(zap.NewNop().Sugar(), ":0", tt.checker)
+
+			rec := httptest.NewRecorder()
+			server.routes().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, tt.code, rec.Code)
+
+			var resp healthResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, tt.response, resp)
+		})
+	}
+}
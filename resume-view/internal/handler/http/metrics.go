@@ -1,37 +1,125 @@
 package http
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/cors"
 	"go.uber.org/zap"
 )
 
+// HealthChecker reports the statuses of the dependencies as of their last probe.
+type HealthChecker interface {
+	Statuses() map[string]error
+}
+
+// Server serves the metrics and health endpoints. It is meant for an internal port, apart from the REST
+// gateway browsers reach through GatewayServer.
 type Server struct {
 	log           *zap.SugaredLogger
 	metricsServer *http.Server
 	port          string
+	health        HealthChecker
 }
 
-func NewServer(log *zap.SugaredLogger, port string) *Server {
-	return &Server{log: log, port: port}
-}
+func NewServer(log *zap.SugaredLogger, port string, health HealthChecker) *Server {
+	s := &Server{log: log, port: port, health: health}
 
-func (s *Server) Run() error {
 	s.metricsServer = &http.Server{
 		Addr:         s.port,
+		Handler:      s.routes(),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
-	http.Handle("/metrics", promhttp.Handler())
+	return s
+}
 
+func (s *Server) Run() error {
 	if err := s.metricsServer.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 		return fmt.Errorf("http server: %w", err)
 	}
 
 	return nil
 }
+
+// Shutdown stops accepting connections and waits for the active requests until ctx is done.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if err := s.metricsServer.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down http server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Server) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", s.healthz)
+	mux.HandleFunc("/readyz", s.readyz)
+
+	return mux
+}
+
+// CORS lists the browser origins allowed to call the gateway.
+type CORS struct {
+	AllowedOrigins []string
+	MaxAge         time.Duration
+}
+
+// GatewayServer serves the REST gateway on a listener of its own, so the CORS policy browsers are given
+// covers the gateway only and the metrics stay off the public port.
+type GatewayServer struct {
+	gateway *Gateway
+	cors    CORS
+	server  *http.Server
+}
+
+func NewGatewayServer(port string, gateway *Gateway, cors CORS) *GatewayServer {
+	s := &GatewayServer{gateway: gateway, cors: cors}
+
+	s.server = &http.Server{
+		Addr:         port,
+		Handler:      s.routes(),
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	return s
+}
+
+func (s *GatewayServer) Run() error {
+	if err := s.server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("gateway server: %w", err)
+	}
+
+	return nil
+}
+
+// Shutdown stops accepting connections and waits for the active requests until ctx is done.
+func (s *GatewayServer) Shutdown(ctx context.Context) error {
+	if err := s.server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shut down gateway server: %w", err)
+	}
+
+	return nil
+}
+
+func (s *GatewayServer) routes() http.Handler {
+	mux := http.NewServeMux()
+
+	s.gateway.register(mux)
+
+	return cors.New(cors.Options{
+		AllowedOrigins: s.cors.AllowedOrigins,
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"Retry-After"},
+		MaxAge:         int(s.cors.MaxAge.Seconds()),
+	}).Handler(mux)
+}
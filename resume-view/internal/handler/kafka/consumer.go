@@ -2,56 +2,276 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"go.uber.org/zap"
 )
 
-type Consumer struct {
-	log     *zap.SugaredLogger
-	conn    *kafka.Conn
-	topic   string
-	groupID string
+const (
+	eventStatusRetried      = "retried"
+	eventStatusDeadLettered = "dead_lettered"
+)
+
+const (
+	headerDLQError             = "x-dlq-error"
+	headerDLQAttempts          = "x-dlq-attempts"
+	headerDLQOriginalTopic     = "x-dlq-original-topic"
+	headerDLQOriginalPartition = "x-dlq-original-partition"
+	headerDLQOriginalOffset    = "x-dlq-original-offset"
+	headerDLQFailedAt          = "x-dlq-failed-at"
+)
+
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
 }
 
-func NewConsumer(log *zap.SugaredLogger, conn *kafka.Conn, topic, groupID string) *Consumer {
-	return &Consumer{log: log, conn: conn, topic: topic, groupID: groupID}
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
 }
 
-func (c *Consumer) Consume(ctx context.Context, handler func(ctx context.Context, message *kafka.Message) error) error {
-	br := c.conn.Broker()
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// backoff returns the delay before the given retry attempt, starting from 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxBackoff)
+}
+
+type ConsumerConfig struct {
+	Topic           string
+	GroupID         string
+	DeadLetterTopic string
+	Retry           RetryPolicy
+}
+
+type Consumer struct {
+	log      *zap.SugaredLogger
+	conn     *kafka.Conn
+	reader   MessageReader
+	dlq      MessageWriter
+	metrics  EventMetrics
+	retry    RetryPolicy
+	stop     chan struct{}
+	stopOnce sync.Once
+	running  sync.WaitGroup
+}
+
+func NewConsumer(log *zap.SugaredLogger, conn *kafka.Conn, metrics EventMetrics, cfg ConsumerConfig) *Consumer {
+	br := conn.Broker()
+	brokerAddr := net.JoinHostPort(br.Host, strconv.Itoa(br.Port))
+
 	r := kafka.NewReader(kafka.ReaderConfig{
-		Brokers: []string{net.JoinHostPort(br.Host, strconv.Itoa(br.Port))},
-		Topic:   c.topic,
-		GroupID: c.groupID,
+		Brokers: []string{brokerAddr},
+		Topic:   cfg.Topic,
+		GroupID: cfg.GroupID,
 	})
 
+	w := &kafka.Writer{
+		Addr:      kafka.TCP(brokerAddr),
+		Topic:     cfg.DeadLetterTopic,
+		Balancer:  &kafka.Hash{},
+		BatchSize: 1,
+	}
+
+	return &Consumer{
+		log:     log,
+		conn:    conn,
+		reader:  r,
+		dlq:     w,
+		metrics: metrics,
+		retry:   cfg.Retry,
+		stop:    make(chan struct{}),
+	}
+}
+
+// Permanent marks a handler error as not worth retrying, so the message goes straight to the dead-letter topic.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Consume runs the read loop until Stop is called or ctx is cancelled. Every message is handled with bounded
+// retries and dead-lettered if it still fails, then committed. Stop only ends fetching, so the message in
+// flight is still handled and committed, while cancelling ctx abandons it uncommitted.
+// It returns an error only when the loop cannot continue.
+func (c *Consumer) Consume(ctx context.Context, handler func(ctx context.Context, message *kafka.Message) error) error {
+	c.running.Add(1)
+	defer c.running.Done()
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-c.stop:
+			cancel()
+		case <-fetchCtx.Done():
+		}
+	}()
+
 	for {
-		m, err := r.ReadMessage(ctx)
+		select {
+		case <-c.stop:
+			return nil
+		default:
+		}
+
+		m, err := c.reader.FetchMessage(fetchCtx)
 		if err != nil {
-			c.log.Errorf("failed to read message: %v", err)
+			if fetchCtx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("failed to read message: %w", err)
+		}
+
+		attempts, err := c.handleWithRetries(ctx, handler, &m)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			c.log.Errorf("giving up on message on offset %d after %d attempts: %v", m.Offset, attempts, err)
+
+			if err := c.deadLetter(ctx, m, attempts, err); err != nil {
+				return fmt.Errorf("failed to dead-letter message on offset %d: %w", m.Offset, err)
+			}
+		}
 
-			break
+		if err := c.reader.CommitMessages(ctx, m); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("failed to commit message on offset %d: %w", m.Offset, err)
 		}
+	}
+}
 
-		if err := handler(ctx, &m); err != nil {
-			c.log.Errorf("failed to handle message: %v", err)
+func (c *Consumer) handleWithRetries(ctx context.Context,
+	handler func(ctx context.Context, message *kafka.Message) error, m *kafka.Message) (int, error) {
+	var (
+		attempt int
+		err     error
+	)
 
-			break
+	for attempt = 1; ; attempt++ {
+		err = handler(ctx, m)
+		if err == nil {
+			return attempt, nil
 		}
 
-		if err := r.CommitMessages(ctx, m); err != nil {
-			c.log.Errorf("failed to commit message: %v", err)
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt > c.retry.MaxRetries {
+			return attempt, err
 		}
+
+		c.metrics.IncEvent(eventStatusRetried)
+		c.log.Warnf("failed to handle message on offset %d, attempt %d: %v", m.Offset, attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return attempt, ctx.Err()
+		case <-time.After(c.retry.backoff(attempt)):
+		}
+	}
+}
+
+func (c *Consumer) deadLetter(ctx context.Context, m kafka.Message, attempts int, cause error) error {
+	headers := make([]kafka.Header, 0, len(m.Headers)+6)
+	headers = append(headers, m.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: headerDLQError, Value: []byte(cause.Error())},
+		kafka.Header{Key: headerDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: headerDLQOriginalTopic, Value: []byte(m.Topic)},
+		kafka.Header{Key: headerDLQOriginalPartition, Value: []byte(strconv.Itoa(m.Partition))},
+		kafka.Header{Key: headerDLQOriginalOffset, Value: []byte(strconv.FormatInt(m.Offset, 10))},
+		kafka.Header{Key: headerDLQFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+
+	err := c.dlq.WriteMessages(ctx, kafka.Message{
+		Key:     m.Key,
+		Value:   m.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		return fmt.Errorf("could not send message: %w", err)
 	}
 
+	c.metrics.IncEvent(eventStatusDeadLettered)
+
 	return nil
 }
 
+// Stats returns the reader stats accumulated since the previous call. Readers that do not keep stats,
+// such as test fakes, report none.
+func (c *Consumer) Stats() kafka.ReaderStats {
+	r, ok := c.reader.(interface{ Stats() kafka.ReaderStats })
+	if !ok {
+		return kafka.ReaderStats{}
+	}
+
+	return r.Stats()
+}
+
+// Stop stops fetching new messages and waits for the message in flight to be committed or for ctx to be done.
+func (c *Consumer) Stop(ctx context.Context) error {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+
+	drained := make(chan struct{})
+
+	go func() {
+		c.running.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to drain consumer: %w", ctx.Err())
+	}
+}
+
 func (c *Consumer) Close() error {
+	if err := c.reader.Close(); err != nil {
+		return fmt.Errorf("failed to close reader: %w", err)
+	}
+
+	if err := c.dlq.Close(); err != nil {
+		return fmt.Errorf("failed to close dead-letter writer: %w", err)
+	}
+
 	if err := c.conn.Close(); err != nil {
 		return fmt.Errorf("failed to close kafka connection: %w", err)
 	}
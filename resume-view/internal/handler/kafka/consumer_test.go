@@ -0,0 +1,343 @@
+//go:build !integration
+
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testRetryPolicy = RetryPolicy{
+	MaxRetries:     2,
+	InitialBackoff: time.Millisecond,
+	MaxBackoff:     5 * time.Millisecond,
+}
+
+// fakeReader is an in-process stand-in for kafka.Reader that serves a fixed set of messages
+// and blocks until the context is done once they are drained.
+type fakeReader struct {
+	mu        sync.Mutex
+	err       error
+	messages  []kafka.Message
+	committed []kafka.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+
+	if r.err != nil {
+		r.mu.Unlock()
+
+		return kafka.Message{}, r.err
+	}
+
+	if len(r.messages) > 0 {
+		m := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+
+		return m, nil
+	}
+
+	r.mu.Unlock()
+
+	<-ctx.Done()
+
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *fakeReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.committed = append(r.committed, msgs...)
+
+	return nil
+}
+
+func (r *fakeReader) Close() error {
+	return nil
+}
+
+func (r *fakeReader) Committed() []kafka.Message {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]kafka.Message(nil), r.committed...)
+}
+
+type fakeWriter struct {
+	mu      sync.Mutex
+	err     error
+	written []kafka.Message
+}
+
+func (w *fakeWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	w.written = append(w.written, msgs...)
+
+	return nil
+}
+
+func (w *fakeWriter) Close() error {
+	return nil
+}
+
+func (w *fakeWriter) Written() []kafka.Message {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return append([]kafka.Message(nil), w.written...)
+}
+
+func runConsumer(reader *fakeReader, writer *fakeWriter, metrics *fakeEventMetrics,
+	handler func(ctx context.Context, message *kafka.Message) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	consumer := &Consumer{
+		log:     zap.NewNop().Sugar(),
+		reader:  reader,
+		dlq:     writer,
+		metrics: metrics,
+		retry:   testRetryPolicy,
+	}
+
+	return consumer.Consume(ctx, handler)
+}
+
+func TestConsumer_Consume(t *testing.T) {
+	t.Parallel()
+
+	message := kafka.Message{
+		Topic:     "resume-views",
+		Partition: 2,
+		Offset:    42,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []kafka.Header{{Key: "traceparent", Value: []byte("trace")}},
+	}
+
+	t.Run("Retries until success", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{message}}
+		writer := &fakeWriter{}
+		metrics := &fakeEventMetrics{}
+
+		var calls int
+
+		err := runConsumer(reader, writer, metrics, func(_ context.Context, _ *kafka.Message) error {
+			calls++
+			if calls < 2 {
+				return assert.AnError
+			}
+
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Len(t, reader.Committed(), 1)
+		assert.Empty(t, writer.Written())
+		assert.Equal(t, map[string]int{eventStatusRetried: 1}, metrics.counts)
+	})
+
+	t.Run("Dead-letters after retries with metadata", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{message}}
+		writer := &fakeWriter{}
+		metrics := &fakeEventMetrics{}
+
+		var calls int
+
+		err := runConsumer(reader, writer, metrics, func(_ context.Context, _ *kafka.Message) error {
+			calls++
+
+			return assert.AnError
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, testRetryPolicy.MaxRetries+1, calls)
+		assert.Len(t, reader.Committed(), 1)
+
+		written := writer.Written()
+		require.Len(t, written, 1)
+		assert.Equal(t, message.Key, written[0].Key)
+		assert.Equal(t, message.Value, written[0].Value)
+
+		headers := make(map[string]string)
+		for _, h := range written[0].Headers {
+			headers[h.Key] = string(h.Value)
+		}
+
+		assert.Equal(t, "trace", headers["traceparent"])
+		assert.Equal(t, assert.AnError.Error(), headers[headerDLQError])
+		assert.Equal(t, strconv.Itoa(testRetryPolicy.MaxRetries+1), headers[headerDLQAttempts])
+		assert.Equal(t, "resume-views", headers[headerDLQOriginalTopic])
+		assert.Equal(t, "2", headers[headerDLQOriginalPartition])
+		assert.Equal(t, "42", headers[headerDLQOriginalOffset])
+		assert.NotEmpty(t, headers[headerDLQFailedAt])
+	})
+
+	t.Run("Permanent errors skip retries", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{message}}
+		writer := &fakeWriter{}
+		metrics := &fakeEventMetrics{}
+
+		var calls int
+
+		err := runConsumer(reader, writer, metrics, func(_ context.Context, _ *kafka.Message) error {
+			calls++
+
+			return Permanent(assert.AnError)
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Len(t, reader.Committed(), 1)
+		assert.Len(t, writer.Written(), 1)
+	})
+
+	t.Run("Dead-letter failure is fatal and not committed", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{message}}
+		writer := &fakeWriter{err: assert.AnError}
+		metrics := &fakeEventMetrics{}
+
+		err := runConsumer(reader, writer, metrics, func(_ context.Context, _ *kafka.Message) error {
+			return Permanent(assert.AnError)
+		})
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, reader.Committed())
+	})
+
+	t.Run("Read failure is fatal", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{err: assert.AnError}
+
+		err := runConsumer(reader, &fakeWriter{}, &fakeEventMetrics{}, func(_ context.Context, _ *kafka.Message) error {
+			return nil
+		})
+
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestConsumer_Stop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Drains and commits the message in flight", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{{Offset: 1}, {Offset: 2}}}
+		consumer := &Consumer{
+			log:     zap.NewNop().Sugar(),
+			reader:  reader,
+			dlq:     &fakeWriter{},
+			metrics: &fakeEventMetrics{},
+			retry:   testRetryPolicy,
+			stop:    make(chan struct{}),
+		}
+
+		handling := make(chan struct{})
+		release := make(chan struct{})
+		consumed := make(chan error, 1)
+
+		go func() {
+			consumed <- consumer.Consume(context.Background(), func(_ context.Context, _ *kafka.Message) error {
+				close(handling)
+				<-release
+
+				return nil
+			})
+		}()
+
+		<-handling
+
+		stopped := make(chan error, 1)
+
+		go func() {
+			stopped <- consumer.Stop(context.Background())
+		}()
+
+		select {
+		case <-stopped:
+			t.Fatal("stop returned before the message in flight was handled")
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		close(release)
+
+		require.NoError(t, <-stopped)
+		require.NoError(t, <-consumed)
+		assert.Equal(t, []kafka.Message{{Offset: 1}}, reader.Committed())
+	})
+
+	t.Run("Gives up at the deadline", func(t *testing.T) {
+		t.Parallel()
+
+		reader := &fakeReader{messages: []kafka.Message{{Offset: 1}}}
+		consumer := &Consumer{
+			log:     zap.NewNop().Sugar(),
+			reader:  reader,
+			dlq:     &fakeWriter{},
+			metrics: &fakeEventMetrics{},
+			retry:   testRetryPolicy,
+			stop:    make(chan struct{}),
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		handling := make(chan struct{})
+
+		go func() {
+			_ = consumer.Consume(ctx, func(ctx context.Context, _ *kafka.Message) error {
+				close(handling)
+				<-ctx.Done()
+
+				return ctx.Err()
+			})
+		}()
+
+		<-handling
+
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer stopCancel()
+
+		require.ErrorIs(t, consumer.Stop(stopCtx), context.DeadlineExceeded)
+		assert.Empty(t, reader.Committed())
+	})
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, time.Second, policy.backoff(5))
+}
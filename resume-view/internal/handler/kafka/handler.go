@@ -0,0 +1,194 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+const (
+	eventStatusProcessed = "processed"
+	eventStatusInvalid   = "invalid"
+	eventStatusFailed    = "failed"
+	eventStatusThrottled = "throttled"
+)
+
+const (
+	// minThrottleWait keeps a limiter reporting no wait, such as a backend falling back, from spinning.
+	minThrottleWait = 100 * time.Millisecond
+	// maxThrottleWaits bounds how often a message waits out the rate limit before the consumer backs off.
+	maxThrottleWaits = 10
+)
+
+type ViewService interface {
+	CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error)
+}
+
+type EventMetrics interface {
+	IncEvent(status string)
+}
+
+type ViewHandler struct {
+	log              *zap.SugaredLogger
+	tracer           trace.Tracer
+	service          ViewService
+	metrics          EventMetrics
+	propagator       propagation.TextMapPropagator
+	minThrottleWait  time.Duration
+	maxThrottleWaits int
+}
+
+func NewViewHandler(log *zap.SugaredLogger, tracer trace.Tracer, service ViewService,
+	metrics EventMetrics) *ViewHandler {
+	return &ViewHandler{
+		log:              log,
+		tracer:           tracer,
+		service:          service,
+		metrics:          metrics,
+		propagator:       propagation.TraceContext{},
+		minThrottleWait:  minThrottleWait,
+		maxThrottleWaits: maxThrottleWaits,
+	}
+}
+
+// Handle decodes a "resume viewed" event and records it. Malformed events are reported as permanent
+// failures, since redelivering them would never succeed.
+func (h *ViewHandler) Handle(ctx context.Context, message *kafka.Message) error {
+	ctx = h.propagator.Extract(ctx, headerCarrier{message: message})
+
+	ctx, span := h.tracer.Start(ctx, "viewConsumer.Handle",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.destination.name", message.Topic),
+			attribute.Int("messaging.kafka.partition", message.Partition),
+			attribute.Int64("messaging.kafka.offset", message.Offset),
+		))
+	defer span.End()
+
+	var event models.ViewEvent
+
+	if err := json.Unmarshal(message.Value, &event); err != nil {
+		h.metrics.IncEvent(eventStatusInvalid)
+
+		return Permanent(fmt.Errorf("failed to decode event: %w", err))
+	}
+
+	if err := event.Validate(); err != nil {
+		h.metrics.IncEvent(eventStatusInvalid)
+
+		return Permanent(fmt.Errorf("failed to validate event: %w", err))
+	}
+
+	idempotencyKey := event.IdempotencyKey
+	if idempotencyKey == "" {
+		// Without a producer key, the message position still dedupes redeliveries of the same message.
+		idempotencyKey = fmt.Sprintf("kafka:%s:%d:%d", message.Topic, message.Partition, message.Offset)
+	}
+
+	view, err := h.createView(ctx, domain.CreateView{
+		ResumeID:       event.ResumeID,
+		CompanyID:      event.CompanyID,
+		IdempotencyKey: idempotencyKey,
+		Viewer: domain.ViewerContext{
+			Source:       domain.ViewSource(event.Source),
+			ViewerUserID: event.ViewerUserID,
+			UserAgent:    event.UserAgent,
+			Referrer:     event.Referrer,
+			Metadata:     event.Metadata,
+		},
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		var validationErr *customerrors.ValidationError
+		if errors.As(err, &validationErr) {
+			h.metrics.IncEvent(eventStatusInvalid)
+
+			return Permanent(fmt.Errorf("failed to create view: %w", err))
+		}
+
+		h.metrics.IncEvent(eventStatusFailed)
+
+		return fmt.Errorf("failed to create view: %w", err)
+	}
+
+	h.metrics.IncEvent(eventStatusProcessed)
+	h.log.Debugf("recorded view %s from offset %d, counted: %t", view.ID, message.Offset, view.Counted())
+
+	return nil
+}
+
+// createView records the view, waiting out the company's rate limit instead of failing, so throttling
+// slows the partition down rather than sending views to the dead-letter topic. Each wait lasts at least
+// minThrottleWait. After maxThrottleWaits waits the rate limit error is returned, leaving the consumer to
+// back off and retry.
+func (h *ViewHandler) createView(ctx context.Context, req domain.CreateView) (models.CreatedView, error) {
+	for waits := 0; ; waits++ {
+		view, err := h.service.CreateView(ctx, req)
+
+		var rateLimitErr *customerrors.RateLimitError
+		if !errors.As(err, &rateLimitErr) || waits == h.maxThrottleWaits {
+			return view, err
+		}
+
+		h.metrics.IncEvent(eventStatusThrottled)
+
+		timer := time.NewTimer(max(rateLimitErr.RetryAfter, h.minThrottleWait))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+
+			return models.CreatedView{}, fmt.Errorf("failed to wait for rate limit: %w", ctx.Err())
+		}
+	}
+}
+
+// headerCarrier adapts kafka message headers to propagation.TextMapCarrier.
+type headerCarrier struct {
+	message *kafka.Message
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, h := range c.message.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, h := range c.message.Headers {
+		if h.Key == key {
+			c.message.Headers[i].Value = []byte(value)
+
+			return
+		}
+	}
+
+	c.message.Headers = append(c.message.Headers, kafka.Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.message.Headers))
+	for _, h := range c.message.Headers {
+		keys = append(keys, h.Key)
+	}
+
+	return keys
+}
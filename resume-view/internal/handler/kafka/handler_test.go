@@ -0,0 +1,242 @@
+//go:build !integration
+
+package kafka
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/google/uuid"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type fakeViewService struct {
+	mu        sync.Mutex
+	err       error
+	throttled int
+	views     []domain.CreateView
+}
+
+func (s *fakeViewService) CreateView(_ context.Context, req domain.CreateView) (models.CreatedView, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.throttled > 0 {
+		s.throttled--
+
+		return models.CreatedView{}, &customerrors.RateLimitError{RetryAfter: time.Millisecond}
+	}
+
+	if s.err != nil {
+		return models.CreatedView{}, s.err
+	}
+
+	s.views = append(s.views, req)
+
+	return models.CreatedView{ID: uuid.New()}, nil
+}
+
+type fakeEventMetrics struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *fakeEventMetrics) IncEvent(status string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.counts == nil {
+		m.counts = make(map[string]int)
+	}
+
+	m.counts[status]++
+}
+
+func TestViewHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	validEvent := []byte(`{"version":1,"resume_id":"` + resumeID + `","company_id":"` + companyID + `"}`)
+	keyedEvent := []byte(`{"version":1,"resume_id":"` + resumeID + `","company_id":"` + companyID +
+		`","idempotency_key":"abc"}`)
+	contextEvent := []byte(`{"version":1,"resume_id":"` + resumeID + `","company_id":"` + companyID +
+		`","source":"search","viewer_user_id":"user","user_agent":"Mozilla/5.0","referrer":"https://example.com",` +
+		`"metadata":{"campaign":"spring"}}`)
+
+	tests := []struct {
+		name       string
+		messages   []kafka.Message
+		serviceErr error
+		throttled  int
+		views      []domain.CreateView
+		committed  int
+		dlq        int
+		statuses   map[string]int
+	}{
+		{
+			name: "Valid event",
+			messages: []kafka.Message{
+				{Topic: "views", Offset: 1, Value: validEvent},
+			},
+			views:     []domain.CreateView{{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "kafka:views:0:1"}},
+			committed: 1,
+			statuses:  map[string]int{eventStatusProcessed: 1},
+		},
+		{
+			name: "Throttled view waits for the rate limit",
+			messages: []kafka.Message{
+				{Topic: "views", Offset: 1, Value: validEvent},
+			},
+			throttled: 2,
+			views:     []domain.CreateView{{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "kafka:views:0:1"}},
+			committed: 1,
+			statuses:  map[string]int{eventStatusThrottled: 2, eventStatusProcessed: 1},
+		},
+		{
+			name: "Throttled past the wait cap is retried by the consumer",
+			messages: []kafka.Message{
+				{Topic: "views", Offset: 1, Value: validEvent},
+			},
+			throttled: 3,
+			views:     []domain.CreateView{{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "kafka:views:0:1"}},
+			committed: 1,
+			statuses: map[string]int{
+				eventStatusThrottled: 2,
+				eventStatusFailed:    1,
+				eventStatusRetried:   1,
+				eventStatusProcessed: 1,
+			},
+		},
+		{
+			name: "Producer idempotency key",
+			messages: []kafka.Message{
+				{Topic: "views", Offset: 1, Value: keyedEvent},
+			},
+			views:     []domain.CreateView{{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "abc"}},
+			committed: 1,
+			statuses:  map[string]int{eventStatusProcessed: 1},
+		},
+		{
+			name: "Viewer context",
+			messages: []kafka.Message{
+				{Topic: "views", Offset: 1, Value: contextEvent},
+			},
+			views: []domain.CreateView{{
+				ResumeID:       resumeID,
+				CompanyID:      companyID,
+				IdempotencyKey: "kafka:views:0:1",
+				Viewer: domain.ViewerContext{
+					Source:       domain.ViewSourceSearch,
+					ViewerUserID: "user",
+					UserAgent:    "Mozilla/5.0",
+					Referrer:     "https://example.com",
+					Metadata:     map[string]string{"campaign": "spring"},
+				},
+			}},
+			committed: 1,
+			statuses:  map[string]int{eventStatusProcessed: 1},
+		},
+		{
+			name: "Undecodable event is dead-lettered",
+			messages: []kafka.Message{
+				{Offset: 1, Value: []byte(`not json`)},
+				{Offset: 2, Value: validEvent},
+			},
+			views:     []domain.CreateView{{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "kafka::0:2"}},
+			committed: 2,
+			dlq:       1,
+			statuses:  map[string]int{eventStatusInvalid: 1, eventStatusDeadLettered: 1, eventStatusProcessed: 1},
+		},
+		{
+			name: "Unsupported version is dead-lettered",
+			messages: []kafka.Message{
+				{Offset: 1, Value: []byte(`{"version":2,"resume_id":"` + resumeID + `","company_id":"` + companyID + `"}`)},
+			},
+			committed: 1,
+			dlq:       1,
+			statuses:  map[string]int{eventStatusInvalid: 1, eventStatusDeadLettered: 1},
+		},
+		{
+			name: "Invalid company id is dead-lettered",
+			messages: []kafka.Message{
+				{Offset: 1, Value: []byte(`{"version":1,"resume_id":"` + resumeID + `","company_id":"abc"}`)},
+			},
+			committed: 1,
+			dlq:       1,
+			statuses:  map[string]int{eventStatusInvalid: 1, eventStatusDeadLettered: 1},
+		},
+		{
+			name: "View rejected by the service is dead-lettered without retries",
+			messages: []kafka.Message{
+				{Offset: 1, Value: []byte(`{"version":1,"resume_id":"short","company_id":"` + companyID + `"}`)},
+			},
+			serviceErr: &customerrors.ValidationError{Violations: []customerrors.FieldViolation{
+				{Field: "resume_id", Description: "must be 24 hexadecimal characters", Err: customerrors.ErrInvalidResumeID},
+			}},
+			committed: 1,
+			dlq:       1,
+			statuses:  map[string]int{eventStatusInvalid: 1, eventStatusDeadLettered: 1},
+		},
+		{
+			name: "Service error is retried and dead-lettered",
+			messages: []kafka.Message{
+				{Offset: 1, Value: validEvent},
+			},
+			serviceErr: assert.AnError,
+			committed:  1,
+			dlq:        1,
+			statuses: map[string]int{
+				eventStatusFailed:       testRetryPolicy.MaxRetries + 1,
+				eventStatusRetried:      testRetryPolicy.MaxRetries,
+				eventStatusDeadLettered: 1,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader := &fakeReader{messages: tt.messages}
+			writer := &fakeWriter{}
+			service := &fakeViewService{err: tt.serviceErr, throttled: tt.throttled}
+			metrics := &fakeEventMetrics{}
+
+			handler := NewViewHandler(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), service, metrics)
+			handler.minThrottleWait = time.Millisecond
+			handler.maxThrottleWaits = 2
+
+			err := runConsumer(reader, writer, metrics, handler.Handle)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.views, service.views)
+			assert.Len(t, reader.Committed(), tt.committed)
+			assert.Len(t, writer.Written(), tt.dlq)
+			assert.Equal(t, tt.statuses, metrics.counts)
+		})
+	}
+}
+
+func TestHeaderCarrier(t *testing.T) {
+	t.Parallel()
+
+	message := &kafka.Message{}
+	carrier := headerCarrier{message: message}
+
+	carrier.Set("traceparent", "first")
+	carrier.Set("traceparent", "second")
+
+	assert.Equal(t, "second", carrier.Get("traceparent"))
+	assert.Equal(t, []string{"traceparent"}, carrier.Keys())
+	assert.Empty(t, carrier.Get("missing"))
+}
@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	headerOutboxID = "x-outbox-id"
+
+	publishBatchTimeout = 10 * time.Millisecond
+)
+
+// Publisher writes outbox events to a topic keyed by resume id, so the events of a resume land on one
+// partition in the order they are written.
+type Publisher struct {
+	writer MessageWriter
+}
+
+func NewPublisher(conn *kafka.Conn, topic string) *Publisher {
+	br := conn.Broker()
+
+	return &Publisher{writer: &kafka.Writer{
+		Addr:         kafka.TCP(net.JoinHostPort(br.Host, strconv.Itoa(br.Port))),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: publishBatchTimeout,
+	}}
+}
+
+// Publish writes events and returns once all of them are acknowledged. On error some of them may have been
+// written, so consumers must tolerate duplicates identified by the view id.
+func (p *Publisher) Publish(ctx context.Context, events []models.OutboxEvent) error {
+	messages := make([]kafka.Message, 0, len(events))
+
+	for _, event := range events {
+		messages = append(messages, kafka.Message{
+			Key:   []byte(event.ResumeID),
+			Value: event.Payload,
+			Headers: []kafka.Header{
+				{Key: headerOutboxID, Value: []byte(strconv.FormatInt(event.ID, 10))},
+			},
+		})
+	}
+
+	if err := p.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("failed to write events: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	if err := p.writer.Close(); err != nil {
+		return fmt.Errorf("failed to close publisher: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+//go:build !integration
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Events are keyed by resume", func(t *testing.T) {
+		t.Parallel()
+
+		writer := &fakeWriter{}
+		publisher := &Publisher{writer: writer}
+
+		err := publisher.Publish(context.Background(), []models.OutboxEvent{
+			{ID: 7, ResumeID: "first", Payload: []byte(`{"a":1}`)},
+			{ID: 8, ResumeID: "second", Payload: []byte(`{"b":2}`)},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []kafka.Message{
+			{
+				Key:     []byte("first"),
+				Value:   []byte(`{"a":1}`),
+				Headers: []kafka.Header{{Key: headerOutboxID, Value: []byte("7")}},
+			},
+			{
+				Key:     []byte("second"),
+				Value:   []byte(`{"b":2}`),
+				Headers: []kafka.Header{{Key: headerOutboxID, Value: []byte("8")}},
+			},
+		}, writer.Written())
+	})
+
+	t.Run("Write failure", func(t *testing.T) {
+		t.Parallel()
+
+		publisher := &Publisher{writer: &fakeWriter{err: assert.AnError}}
+
+		err := publisher.Publish(context.Background(), []models.OutboxEvent{{ID: 1, ResumeID: "first"}})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
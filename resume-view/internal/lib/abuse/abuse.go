@@ -0,0 +1,131 @@
+package abuse
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Rules a view can break, as reported by Detector.Check.
+const (
+	RuleBlockedCompany = "blocked_company"
+	RuleViewRate       = "view_rate"
+	RuleResumeSpread   = "resume_spread"
+)
+
+// Rules configures a Detector. A rule with a zero limit is disabled.
+type Rules struct {
+	// MaxViews is how many views a company may record within ViewWindow.
+	MaxViews   int
+	ViewWindow time.Duration
+	// MaxResumes is how many distinct resumes a company may view within ResumeWindow.
+	MaxResumes       int
+	ResumeWindow     time.Duration
+	BlockedCompanies []string
+}
+
+// Windows counts the distinct members added to a key within a sliding window.
+type Windows interface {
+	// Add records member under key and returns how many distinct members the key holds within window,
+	// member included.
+	Add(ctx context.Context, key, member string, window time.Duration) (int, error)
+	// Count returns, for every i, how many distinct members the key would hold within window once
+	// members[:i+1] were added. Nothing is recorded.
+	Count(ctx context.Context, key string, members []string, window time.Duration) ([]int, error)
+}
+
+// View is a view of a resume checked by Detector. Key identifies the view, so retries of the same request
+// are counted once. Without a key every view counts.
+type View struct {
+	ResumeID string
+	Key      string
+}
+
+// Detector flags views that look automated, such as scrapers walking through resumes.
+type Detector struct {
+	windows Windows
+	rules   Rules
+	blocked map[string]struct{}
+}
+
+func NewDetector(windows Windows, rules Rules) *Detector {
+	blocked := make(map[string]struct{}, len(rules.BlockedCompanies))
+	for _, companyID := range rules.BlockedCompanies {
+		blocked[companyID] = struct{}{}
+	}
+
+	return &Detector{windows: windows, rules: rules, blocked: blocked}
+}
+
+// Check returns the rules each of the company's views breaks, counting the views before it as recorded.
+// The sliding windows are left untouched: only the views that end up counted are passed to Record, so
+// retries and collapsed views do not push a company over its limits. Concurrent checks of a company may
+// both take the last view the windows allow.
+func (d *Detector) Check(ctx context.Context, companyID string, views []View) ([][]string, error) {
+	broken := make([][]string, len(views))
+
+	if _, ok := d.blocked[companyID]; ok {
+		for i := range broken {
+			broken[i] = append(broken[i], RuleBlockedCompany)
+		}
+	}
+
+	if d.rules.MaxViews > 0 {
+		keys := make([]string, len(views))
+		for i, view := range views {
+			keys[i] = cmp.Or(view.Key, uuid.NewString())
+		}
+
+		counts, err := d.windows.Count(ctx, "views:"+companyID, keys, d.rules.ViewWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count company views: %w", err)
+		}
+
+		for i, count := range counts {
+			if count > d.rules.MaxViews {
+				broken[i] = append(broken[i], RuleViewRate)
+			}
+		}
+	}
+
+	if d.rules.MaxResumes > 0 {
+		resumeIDs := make([]string, len(views))
+		for i, view := range views {
+			resumeIDs[i] = view.ResumeID
+		}
+
+		counts, err := d.windows.Count(ctx, "resumes:"+companyID, resumeIDs, d.rules.ResumeWindow)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count company resumes: %w", err)
+		}
+
+		for i, count := range counts {
+			if count > d.rules.MaxResumes {
+				broken[i] = append(broken[i], RuleResumeSpread)
+			}
+		}
+	}
+
+	return broken, nil
+}
+
+// Record adds a counted view of the company to the sliding windows.
+func (d *Detector) Record(ctx context.Context, companyID string, view View) error {
+	if d.rules.MaxViews > 0 {
+		if _, err := d.windows.Add(ctx, "views:"+companyID, cmp.Or(view.Key, uuid.NewString()),
+			d.rules.ViewWindow); err != nil {
+			return fmt.Errorf("failed to record company view: %w", err)
+		}
+	}
+
+	if d.rules.MaxResumes > 0 {
+		if _, err := d.windows.Add(ctx, "resumes:"+companyID, view.ResumeID, d.rules.ResumeWindow); err != nil {
+			return fmt.Errorf("failed to record company resume: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package abuse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingWindows struct{}
+
+func (failingWindows) Add(context.Context, string, string, time.Duration) (int, error) {
+	return 0, assert.AnError
+}
+
+func (failingWindows) Count(context.Context, string, []string, time.Duration) ([]int, error) {
+	return nil, assert.AnError
+}
+
+func TestDetector_Check(t *testing.T) {
+	t.Parallel()
+
+	companyID, blockedID := uuid.NewString(), uuid.NewString()
+
+	tests := []struct {
+		name   string
+		rules  Rules
+		views  []string
+		key    func(i int) string
+		broken [][]string
+	}{
+		{
+			name:   "View rate",
+			rules:  Rules{MaxViews: 2, ViewWindow: time.Minute},
+			views:  []string{"r1", "r1", "r1"},
+			broken: [][]string{nil, nil, {RuleViewRate}},
+		},
+		{
+			name:  "Retries of a view count once",
+			rules: Rules{MaxViews: 1, ViewWindow: time.Minute},
+			views: []string{"r1", "r1", "r1"},
+			key: func(int) string {
+				return "key"
+			},
+			broken: [][]string{nil, nil, nil},
+		},
+		{
+			name:   "Resume spread",
+			rules:  Rules{MaxResumes: 2, ResumeWindow: time.Minute},
+			views:  []string{"r1", "r2", "r1", "r3"},
+			broken: [][]string{nil, nil, nil, {RuleResumeSpread}},
+		},
+		{
+			name: "Every broken rule is reported",
+			rules: Rules{
+				MaxViews:         1,
+				ViewWindow:       time.Minute,
+				MaxResumes:       1,
+				ResumeWindow:     time.Minute,
+				BlockedCompanies: []string{blockedID, companyID},
+			},
+			views:  []string{"r1", "r2"},
+			broken: [][]string{{RuleBlockedCompany}, {RuleBlockedCompany, RuleViewRate, RuleResumeSpread}},
+		},
+		{
+			name:   "Disabled rules",
+			views:  []string{"r1", "r2", "r3"},
+			broken: [][]string{nil, nil, nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			detector := NewDetector(NewMemoryWindows(), tt.rules)
+
+			for i, resumeID := range tt.views {
+				var key string
+				if tt.key != nil {
+					key = tt.key(i)
+				}
+
+				view := View{ResumeID: resumeID, Key: key}
+
+				broken, err := detector.Check(context.Background(), companyID, []View{view})
+				require.NoError(t, err)
+				assert.Equal(t, tt.broken[i], broken[0], "view %d", i)
+
+				require.NoError(t, detector.Record(context.Background(), companyID, view))
+			}
+		})
+	}
+}
+
+func TestDetector_CheckBatch(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+	detector := NewDetector(NewMemoryWindows(), Rules{MaxResumes: 2, ResumeWindow: time.Minute})
+	views := []View{{ResumeID: "r1"}, {ResumeID: "r2"}, {ResumeID: "r1"}, {ResumeID: "r3"}}
+
+	broken, err := detector.Check(context.Background(), companyID, views)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{nil, nil, nil, {RuleResumeSpread}}, broken, "earlier views of the batch count")
+
+	broken, err = detector.Check(context.Background(), companyID, views[3:])
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{nil}, broken, "checked views are not recorded")
+}
+
+func TestDetector_Failure(t *testing.T) {
+	t.Parallel()
+
+	detector := NewDetector(failingWindows{}, Rules{MaxViews: 1, ViewWindow: time.Minute})
+
+	_, err := detector.Check(context.Background(), uuid.NewString(), []View{{ResumeID: "r1"}})
+	require.ErrorIs(t, err, assert.AnError)
+
+	err = detector.Record(context.Background(), uuid.NewString(), View{ResumeID: "r1"})
+	assert.ErrorIs(t, err, assert.AnError)
+}
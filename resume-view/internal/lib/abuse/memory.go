@@ -0,0 +1,109 @@
+package abuse
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often keys without members inside their window are dropped from memory.
+const sweepInterval = time.Minute
+
+type entry struct {
+	member string
+	at     time.Time
+}
+
+// window is a sliding log of a key. entries are in insertion order and may hold stale entries of members
+// added again later; seen holds the latest time of every member still inside the window.
+type window struct {
+	size    time.Duration
+	entries []entry
+	seen    map[string]time.Time
+}
+
+// expire drops the entries that left the window, keeping members that were added again since.
+func (w *window) expire(now time.Time) {
+	i := 0
+	for ; i < len(w.entries) && !w.entries[i].at.After(now.Add(-w.size)); i++ {
+		if e := w.entries[i]; w.seen[e.member].Equal(e.at) {
+			delete(w.seen, e.member)
+		}
+	}
+
+	w.entries = w.entries[i:]
+}
+
+// MemoryWindows keeps the sliding windows in process memory. Each replica counts on its own, so a company
+// spreading its views over replicas is flagged later than with RedisWindows.
+type MemoryWindows struct {
+	now       func() time.Time
+	mu        sync.Mutex
+	windows   map[string]*window
+	lastSweep time.Time
+}
+
+func NewMemoryWindows() *MemoryWindows {
+	return &MemoryWindows{now: time.Now, windows: make(map[string]*window)}
+}
+
+func (m *MemoryWindows) Add(_ context.Context, key, member string, size time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	w, ok := m.windows[key]
+	if !ok {
+		w = &window{seen: make(map[string]time.Time)}
+		m.windows[key] = w
+	}
+
+	w.size = size
+	w.expire(now)
+	w.entries = append(w.entries, entry{member: member, at: now})
+	w.seen[member] = now
+
+	return len(w.seen), nil
+}
+
+func (m *MemoryWindows) Count(_ context.Context, key string, members []string, size time.Duration) ([]int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var seen map[string]time.Time
+	if w, ok := m.windows[key]; ok {
+		w.size = size
+		w.expire(m.now())
+		seen = w.seen
+	}
+
+	counts := make([]int, len(members))
+	added := make(map[string]struct{}, len(members))
+
+	for i, member := range members {
+		if _, ok := seen[member]; !ok {
+			added[member] = struct{}{}
+		}
+
+		counts[i] = len(seen) + len(added)
+	}
+
+	return counts, nil
+}
+
+// sweep drops the windows whose members have all expired.
+func (m *MemoryWindows) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+
+	m.lastSweep = now
+
+	for key, w := range m.windows {
+		if w.expire(now); len(w.seen) == 0 {
+			delete(m.windows, key)
+		}
+	}
+}
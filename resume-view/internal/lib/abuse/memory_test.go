@@ -0,0 +1,76 @@
+//go:build !integration
+
+package abuse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryWindows_Add(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	windows := NewMemoryWindows()
+	windows.now = func() time.Time {
+		return now
+	}
+
+	add := func(key, member string) int {
+		t.Helper()
+
+		count, err := windows.Add(context.Background(), key, member, time.Minute)
+		require.NoError(t, err)
+
+		return count
+	}
+
+	assert.Equal(t, 1, add("company", "a"))
+	assert.Equal(t, 2, add("company", "b"))
+	assert.Equal(t, 2, add("company", "a"), "members are counted once")
+	assert.Equal(t, 1, add("other", "a"), "keys have separate windows")
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 3, add("company", "c"))
+
+	now = now.Add(30 * time.Second)
+	assert.Equal(t, 2, add("company", "d"), "a and b left the window")
+
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, 3, add("company", "a"), "a came back")
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, 1, add("company", "e"))
+	assert.Len(t, windows.windows, 1, "expired windows are swept")
+}
+
+func TestMemoryWindows_Count(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	windows := NewMemoryWindows()
+	windows.now = func() time.Time {
+		return now
+	}
+
+	counts, err := windows.Count(context.Background(), "company", []string{"a", "b"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, counts)
+
+	_, err = windows.Add(context.Background(), "company", "a", time.Minute)
+	require.NoError(t, err)
+
+	counts, err = windows.Count(context.Background(), "company", []string{"a", "b", "b", "c"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 2, 3}, counts, "members are counted once")
+
+	now = now.Add(time.Minute)
+
+	counts, err = windows.Count(context.Background(), "company", []string{"a"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, counts, "a left the window and counts as new")
+}
@@ -0,0 +1,87 @@
+package abuse
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "abuse:"
+
+// addScript keeps a window as a sorted set of members scored by the time they were last added, using the
+// Redis clock so replicas with skewed clocks agree on the window. Sets expire once their newest member has.
+var addScript = redis.NewScript(`
+local window = tonumber(ARGV[2])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - window)
+redis.call('ZADD', KEYS[1], now, ARGV[1])
+redis.call('PEXPIRE', KEYS[1], window)
+
+return redis.call('ZCARD', KEYS[1])
+`)
+
+// countScript counts a window as addScript would after adding ARGV[2..], without writing to it.
+var countScript = redis.NewScript(`
+local window = tonumber(ARGV[1])
+local time = redis.call('TIME')
+local now = tonumber(time[1]) * 1000 + math.floor(tonumber(time[2]) / 1000)
+local count = redis.call('ZCOUNT', KEYS[1], '(' .. (now - window), '+inf')
+local added = {}
+local counts = {}
+for i = 2, #ARGV do
+	local member = ARGV[i]
+	if not added[member] then
+		local score = redis.call('ZSCORE', KEYS[1], member)
+		if not score or tonumber(score) <= now - window then
+			count = count + 1
+		end
+		added[member] = true
+	end
+	counts[i - 1] = count
+end
+return counts
+`)
+
+// RedisWindows keeps the sliding windows in Redis, so all replicas count a company's views together.
+type RedisWindows struct {
+	client *redis.Client
+}
+
+func NewRedisWindows(client *redis.Client) *RedisWindows {
+	return &RedisWindows{client: client}
+}
+
+func (r *RedisWindows) Add(ctx context.Context, key, member string, window time.Duration) (int, error) {
+	count, err := addScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, member, window.Milliseconds()).Int()
+	if err != nil {
+		return 0, fmt.Errorf("failed to add to sliding window: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *RedisWindows) Count(ctx context.Context, key string, members []string,
+	window time.Duration) ([]int, error) {
+	args := make([]any, 0, len(members)+1)
+	args = append(args, window.Milliseconds())
+
+	for _, member := range members {
+		args = append(args, member)
+	}
+
+	counts, err := countScript.Run(ctx, r.client, []string{redisKeyPrefix + key}, args...).Int64Slice()
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sliding window: %w", err)
+	}
+
+	result := make([]int, len(counts))
+	for i, count := range counts {
+		result[i] = int(count)
+	}
+
+	return result, nil
+}
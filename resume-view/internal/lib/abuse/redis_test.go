@@ -0,0 +1,93 @@
+//go:build integration
+
+package abuse
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupRedis(ctx context.Context, t *testing.T) *redis.Client {
+	t.Helper()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	endpoint, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: endpoint})
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return client
+}
+
+func TestRedisWindows_Add(t *testing.T) {
+	ctx := context.Background()
+	client := setupRedis(ctx, t)
+
+	windows := NewRedisWindows(client)
+	// A second instance stands in for another replica sharing the same windows.
+	replica := NewRedisWindows(client)
+
+	add := func(w *RedisWindows, member string, window time.Duration) int {
+		t.Helper()
+
+		count, err := w.Add(ctx, "company", member, window)
+		require.NoError(t, err)
+
+		return count
+	}
+
+	assert.Equal(t, 1, add(windows, "a", time.Minute))
+	assert.Equal(t, 2, add(replica, "b", time.Minute))
+	assert.Equal(t, 2, add(windows, "a", time.Minute), "members are counted once")
+
+	ttl, err := client.PTTL(ctx, redisKeyPrefix+"company").Result()
+	require.NoError(t, err)
+	assert.Positive(t, ttl)
+	assert.LessOrEqual(t, ttl, time.Minute)
+
+	time.Sleep(200 * time.Millisecond)
+
+	assert.Equal(t, 1, add(windows, "c", 100*time.Millisecond), "a and b left the window")
+}
+
+func TestRedisWindows_Count(t *testing.T) {
+	ctx := context.Background()
+	client := setupRedis(ctx, t)
+
+	windows := NewRedisWindows(client)
+
+	_, err := windows.Add(ctx, "company", "a", time.Minute)
+	require.NoError(t, err)
+
+	counts, err := windows.Count(ctx, "company", []string{"a", "b", "b", "c"}, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 2, 3}, counts)
+
+	count, err := windows.Add(ctx, "company", "b", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count, "counted members are not recorded")
+}
@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-json"
+)
+
+// Writer writes gzip compressed NDJSON. Records go to a temporary file that is renamed to the final
+// path by Close, so a file at path is always a complete archive.
+type Writer struct {
+	path    string
+	file    *os.File
+	gzip    *gzip.Writer
+	encoder *json.Encoder
+}
+
+func Create(path string) (*Writer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create archive dir: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+
+	return &Writer{path: path, file: file, gzip: gz, encoder: json.NewEncoder(gz)}, nil
+}
+
+// Write appends v as one JSON line.
+func (w *Writer) Write(v any) error {
+	if err := w.encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write archive record: %w", err)
+	}
+
+	return nil
+}
+
+func (w *Writer) Close() error {
+	if err := w.gzip.Close(); err != nil {
+		w.Abort()
+
+		return fmt.Errorf("failed to flush archive: %w", err)
+	}
+
+	if err := w.file.Sync(); err != nil {
+		w.Abort()
+
+		return fmt.Errorf("failed to sync archive: %w", err)
+	}
+
+	if err := w.file.Close(); err != nil {
+		_ = os.Remove(w.file.Name())
+
+		return fmt.Errorf("failed to close archive: %w", err)
+	}
+
+	if err := os.Rename(w.file.Name(), w.path); err != nil {
+		_ = os.Remove(w.file.Name())
+
+		return fmt.Errorf("failed to move archive into place: %w", err)
+	}
+
+	return nil
+}
+
+// Abort discards everything written so far.
+func (w *Writer) Abort() {
+	_ = w.file.Close()
+	_ = os.Remove(w.file.Name())
+}
@@ -0,0 +1,136 @@
+//go:build !integration
+
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type record struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "nested", "records.ndjson.gz")
+
+	w, err := Create(path)
+	require.NoError(t, err)
+
+	records := []record{{ID: 1, Name: "first"}, {ID: 2, Name: "second"}}
+	for _, r := range records {
+		require.NoError(t, w.Write(r))
+	}
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist, "archive is not visible before Close")
+
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, records, readRecords(t, path))
+}
+
+func readRecords(t *testing.T, path string) []record {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	var got []record
+
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var r record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+
+		got = append(got, r)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return got
+}
+
+func TestWriter_Abort(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	w, err := Create(filepath.Join(dir, "records.ndjson.gz"))
+	require.NoError(t, err)
+	require.NoError(t, w.Write(record{ID: 1}))
+
+	w.Abort()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "records.ndjson.gz")
+
+	w, err := Create(path)
+	require.NoError(t, err)
+
+	for _, r := range []record{{ID: 1, Name: "keep"}, {ID: 2, Name: "drop"}, {ID: 3, Name: "keep"}} {
+		require.NoError(t, w.Write(r))
+	}
+
+	require.NoError(t, w.Close())
+
+	dropName := func(name string) func(raw []byte) (bool, error) {
+		return func(raw []byte) (bool, error) {
+			var r record
+
+			if err := json.Unmarshal(raw, &r); err != nil {
+				return false, err
+			}
+
+			return r.Name == name, nil
+		}
+	}
+
+	dropped, err := Filter(path, dropName("drop"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), dropped)
+	assert.Equal(t, []record{{ID: 1, Name: "keep"}, {ID: 3, Name: "keep"}}, readRecords(t, path))
+
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	dropped, err = Filter(path, dropName("missing"))
+	require.NoError(t, err)
+	assert.Zero(t, dropped)
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, before.ModTime(), after.ModTime(), "an archive without dropped records is left alone")
+
+	_, err = Filter(path, func([]byte) (bool, error) {
+		return false, assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Len(t, readRecords(t, path), 2)
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
@@ -0,0 +1,76 @@
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/goccy/go-json"
+)
+
+// Filter rewrites the archive at path without the records drop reports, and returns how many were
+// dropped. The archive is replaced the same way Writer creates one, so it is never left half written,
+// and it is not touched at all when no record is dropped.
+func Filter(path string, drop func(record []byte) (bool, error)) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	w, err := Create(path)
+	if err != nil {
+		return 0, err
+	}
+
+	dropped, err := filterRecords(bufio.NewReader(gz), w, drop)
+	if err != nil || dropped == 0 {
+		w.Abort()
+
+		return 0, err
+	}
+
+	if err = w.Close(); err != nil {
+		return 0, err
+	}
+
+	return dropped, nil
+}
+
+func filterRecords(r *bufio.Reader, w *Writer, drop func(record []byte) (bool, error)) (int64, error) {
+	var dropped int64
+
+	for {
+		line, err := r.ReadBytes('\n')
+
+		if record := bytes.TrimSpace(line); len(record) > 0 {
+			skip, dropErr := drop(record)
+			if dropErr != nil {
+				return 0, dropErr
+			}
+
+			if skip {
+				dropped++
+			} else if writeErr := w.Write(json.RawMessage(record)); writeErr != nil {
+				return 0, writeErr
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return dropped, nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to read archive: %w", err)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Claims are what resume-view authorizes on: the company the caller acts for, the resumes the caller owns
+// and whether the caller is an administrator. Setting them is up to the issuer of the token. The sign-in
+// tokens of employee-service carry only user_id, so they authenticate but are denied every call.
+type Claims struct {
+	jwt.RegisteredClaims
+	UserID    string   `json:"user_id"`
+	CompanyID string   `json:"company_id,omitempty"`
+	ResumeIDs []string `json:"resume_ids,omitempty"`
+	Admin     bool     `json:"admin,omitempty"`
+}
+
+// ActsFor reports whether the caller may record and list views on behalf of companyID.
+func (c *Claims) ActsFor(companyID string) bool {
+	return c.CompanyID != "" && c.CompanyID == companyID
+}
+
+// OwnsResume reports whether the caller may read the views of resumeID.
+func (c *Claims) OwnsResume(resumeID string) bool {
+	return slices.Contains(c.ResumeIDs, resumeID)
+}
+
+// Authenticator verifies HS256 tokens signed with the key shared with employee-service.
+type Authenticator struct {
+	SignKey string
+}
+
+func NewAuthenticator(signKey string) *Authenticator {
+	return &Authenticator{SignKey: signKey}
+}
+
+func (a *Authenticator) ParseToken(token string) (*Claims, error) {
+	parsedToken, err := jwt.ParseWithClaims(token, &Claims{}, func(_ *jwt.Token) (interface{}, error) {
+		return []byte(a.SignKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse token with claims: %w", err)
+	}
+
+	claims, ok := parsedToken.Claims.(*Claims)
+	if !ok || !parsedToken.Valid {
+		return nil, fmt.Errorf("failed to parse token claims")
+	}
+
+	return claims, nil
+}
+
+// ParseAuthorization verifies the bearer token of an Authorization header value. Every failure wraps
+// customerrors.ErrUnauthenticated.
+func (a *Authenticator) ParseAuthorization(header string) (*Claims, error) {
+	if header == "" {
+		return nil, fmt.Errorf("%w: empty authorization header", customerrors.ErrUnauthenticated)
+	}
+
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok || token == "" {
+		return nil, fmt.Errorf("%w: invalid authorization header", customerrors.ErrUnauthenticated)
+	}
+
+	claims, err := a.ParseToken(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", customerrors.ErrUnauthenticated, err)
+	}
+
+	return claims, nil
+}
+
+// GenerateToken signs claims valid for ttl. resume-view itself only verifies tokens; this is used by
+// tests and tooling.
+func (a *Authenticator) GenerateToken(claims Claims, ttl time.Duration) (string, error) {
+	now := time.Now()
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(ttl))
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims).SignedString([]byte(a.SignKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token with claims: %w", err)
+	}
+
+	return token, nil
+}
+
+type claimsKey struct{}
+
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+
+	return claims, ok
+}
@@ -0,0 +1,108 @@
+//go:build !integration
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticator_ParseToken(t *testing.T) {
+	t.Parallel()
+
+	authenticator := NewAuthenticator("sign-key")
+	claims := Claims{UserID: "user", CompanyID: "company", ResumeIDs: []string{"resume"}}
+
+	valid, err := authenticator.GenerateToken(claims, time.Hour)
+	require.NoError(t, err)
+
+	expired, err := authenticator.GenerateToken(claims, -time.Minute)
+	require.NoError(t, err)
+
+	foreign, err := NewAuthenticator("other-key").GenerateToken(claims, time.Hour)
+	require.NoError(t, err)
+
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, &claims).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	require.NoError(t, err)
+
+	// Tokens minted by employee-service carry only the user id.
+	employeeToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": "employee",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("sign-key"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    *Claims
+		wantErr bool
+	}{
+		{name: "Valid token", token: valid, want: &claims},
+		{name: "Employee-service token", token: employeeToken, want: &Claims{UserID: "employee"}},
+		{name: "Expired token", token: expired, wantErr: true},
+		{name: "Wrong key", token: foreign, wantErr: true},
+		{name: "Unsigned token", token: unsigned, wantErr: true},
+		{name: "Garbage", token: "not-a-token", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := authenticator.ParseToken(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.UserID, got.UserID)
+			assert.Equal(t, tt.want.CompanyID, got.CompanyID)
+			assert.Equal(t, tt.want.ResumeIDs, got.ResumeIDs)
+		})
+	}
+}
+
+func TestAuthenticator_ParseAuthorization(t *testing.T) {
+	t.Parallel()
+
+	authenticator := NewAuthenticator("sign-key")
+
+	token, err := authenticator.GenerateToken(Claims{UserID: "user"}, time.Hour)
+	require.NoError(t, err)
+
+	claims, err := authenticator.ParseAuthorization("Bearer " + token)
+	require.NoError(t, err)
+	assert.Equal(t, "user", claims.UserID)
+
+	for _, header := range []string{"", token, "Bearer ", "Bearer garbage"} {
+		_, err = authenticator.ParseAuthorization(header)
+		assert.ErrorIs(t, err, customerrors.ErrUnauthenticated, header)
+	}
+}
+
+func TestClaims(t *testing.T) {
+	t.Parallel()
+
+	claims := &Claims{CompanyID: "company", ResumeIDs: []string{"resume"}}
+
+	assert.True(t, claims.ActsFor("company"))
+	assert.False(t, claims.ActsFor("other"))
+	assert.False(t, (&Claims{}).ActsFor(""))
+	assert.True(t, claims.OwnsResume("resume"))
+	assert.False(t, claims.OwnsResume("other"))
+
+	ctx := WithClaims(context.Background(), claims)
+	got, ok := ClaimsFromContext(ctx)
+
+	assert.True(t, ok)
+	assert.Same(t, claims, got)
+}
@@ -3,16 +3,97 @@ package customerrors
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
+// statusClientClosedRequest is the non-standard status for requests the client gave up on.
+const statusClientClosedRequest = 499
+
 var (
 	ErrNotFound      = errors.New("not found")
 	ErrInvalidCursor = errors.New("invalid cursor")
+
+	ErrInvalidResumeID       = errors.New("invalid resume id")
+	ErrInvalidCompanyID      = errors.New("invalid company id")
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+	ErrInvalidStatsRange     = errors.New("invalid stats range")
+	ErrInvalidTimeRange      = errors.New("invalid time range")
+	ErrInvalidSortOrder      = errors.New("invalid sort order")
+	ErrBatchTooLarge         = errors.New("batch too large")
+	ErrInvalidSubject        = errors.New("invalid subject")
+	ErrInvalidExportFormat   = errors.New("invalid export format")
+	ErrInvalidBody           = errors.New("invalid request body")
+	ErrInvalidQuery          = errors.New("invalid query parameter")
+	ErrInvalidViewerContext  = errors.New("invalid viewer context")
+
+	ErrUnauthenticated  = errors.New("unauthenticated")
+	ErrPermissionDenied = errors.New("permission denied")
+
+	ErrRateLimited = errors.New("rate limit exceeded")
+
+	ErrTooManySubscribers = errors.New("too many subscribers")
+	ErrSubscriberEvicted  = errors.New("subscriber evicted for falling behind")
+
+	ErrInvalidEvent            = errors.New("invalid event")
+	ErrUnsupportedEventVersion = errors.New("unsupported event version")
 )
 
+// FieldViolation describes why a single request field was rejected. Err is the sentinel it matches.
+type FieldViolation struct {
+	Field       string
+	Description string
+	Err         error
+}
+
+// ValidationError lists every invalid field of a request. errors.Is matches it against the sentinel
+// of each violation.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		fields = append(fields, fmt.Sprintf("%s: %s", v.Field, v.Description))
+	}
+
+	return "invalid request: " + strings.Join(fields, "; ")
+}
+
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		errs = append(errs, v.Err)
+	}
+
+	return errs
+}
+
+// RateLimitError rejects a request that exceeded its rate limit. errors.Is matches it against ErrRateLimited.
+type RateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%v, retry after %v", ErrRateLimited, e.RetryAfter)
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
 func ParseGRPCErrStatusCode(err error) codes.Code {
+	var validationErr *ValidationError
+
 	switch {
 	case errors.Is(err, context.Canceled):
 		return codes.Canceled
@@ -20,9 +101,92 @@ func ParseGRPCErrStatusCode(err error) codes.Code {
 		return codes.DeadlineExceeded
 	case errors.Is(err, ErrNotFound):
 		return codes.NotFound
-	case errors.Is(err, ErrInvalidCursor):
+	case errors.As(err, &validationErr):
+		return codes.InvalidArgument
+	case errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidResumeID), errors.Is(err, ErrInvalidCompanyID),
+		errors.Is(err, ErrInvalidIdempotencyKey), errors.Is(err, ErrInvalidStatsRange),
+		errors.Is(err, ErrInvalidTimeRange), errors.Is(err, ErrInvalidSortOrder), errors.Is(err, ErrBatchTooLarge),
+		errors.Is(err, ErrInvalidSubject), errors.Is(err, ErrInvalidExportFormat), errors.Is(err, ErrInvalidBody),
+		errors.Is(err, ErrInvalidQuery), errors.Is(err, ErrInvalidViewerContext):
 		return codes.InvalidArgument
+	case errors.Is(err, ErrTooManySubscribers), errors.Is(err, ErrSubscriberEvicted), errors.Is(err, ErrRateLimited):
+		return codes.ResourceExhausted
+	case errors.Is(err, ErrUnauthenticated):
+		return codes.Unauthenticated
+	case errors.Is(err, ErrPermissionDenied):
+		return codes.PermissionDenied
 	}
 
 	return codes.Internal
 }
+
+// HTTPStatusCode maps err onto the HTTP status matching its ParseGRPCErrStatusCode, so the REST gateway
+// and the gRPC API classify errors the same way.
+func HTTPStatusCode(err error) int {
+	switch ParseGRPCErrStatusCode(err) {
+	case codes.Canceled:
+		return statusClientClosedRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// GRPCError converts err into a gRPC status error prefixed with op. Validation errors carry their
+// field violations as errdetails.BadRequest and rate limit errors their delay as errdetails.RetryInfo.
+// Errors that already are status errors, such as those returned by a stream's Recv, are passed
+// through unchanged.
+func GRPCError(op string, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	st := status.Newf(ParseGRPCErrStatusCode(err), "%s: %v", op, err)
+
+	detail := errorDetail(err)
+	if detail == nil {
+		return st.Err()
+	}
+
+	detailed, err := st.WithDetails(detail)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func errorDetail(err error) protoadapt.MessageV1 {
+	var (
+		validationErr *ValidationError
+		rateLimitErr  *RateLimitError
+	)
+
+	switch {
+	case errors.As(err, &validationErr):
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range validationErr.Violations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+			})
+		}
+
+		return badRequest
+	case errors.As(err, &rateLimitErr):
+		return &errdetails.RetryInfo{RetryDelay: durationpb.New(rateLimitErr.RetryAfter)}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+//go:build !integration
+
+package customerrors
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseGRPCErrStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "Canceled", err: context.Canceled, code: codes.Canceled},
+		{name: "Not found", err: fmt.Errorf("wrapped: %w", ErrNotFound), code: codes.NotFound},
+		{name: "Invalid resume id", err: ErrInvalidResumeID, code: codes.InvalidArgument},
+		{name: "Invalid company id", err: ErrInvalidCompanyID, code: codes.InvalidArgument},
+		{
+			name: "Validation error",
+			err:  &ValidationError{Violations: []FieldViolation{{Field: "company_id", Err: ErrInvalidCompanyID}}},
+			code: codes.InvalidArgument,
+		},
+		{name: "Evicted subscriber", err: ErrSubscriberEvicted, code: codes.ResourceExhausted},
+		{name: "Rate limited", err: &RateLimitError{RetryAfter: time.Second}, code: codes.ResourceExhausted},
+		{name: "Missing token", err: fmt.Errorf("wrapped: %w", ErrUnauthenticated), code: codes.Unauthenticated},
+		{name: "Foreign company", err: ErrPermissionDenied, code: codes.PermissionDenied},
+		{name: "Unknown", err: assert.AnError, code: codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.code, ParseGRPCErrStatusCode(tt.err))
+		})
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "Canceled", err: context.Canceled, code: statusClientClosedRequest},
+		{name: "Not found", err: ErrNotFound, code: http.StatusNotFound},
+		{name: "Invalid subject", err: fmt.Errorf("wrapped: %w", ErrInvalidSubject), code: http.StatusBadRequest},
+		{name: "Rate limited", err: &RateLimitError{RetryAfter: time.Second}, code: http.StatusTooManyRequests},
+		{name: "Missing token", err: ErrUnauthenticated, code: http.StatusUnauthorized},
+		{name: "Foreign company", err: ErrPermissionDenied, code: http.StatusForbidden},
+		{name: "Unknown", err: assert.AnError, code: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.code, HTTPStatusCode(tt.err))
+		})
+	}
+}
+
+func TestGRPCError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Validation error carries field violations", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("failed to create view: %w", &ValidationError{Violations: []FieldViolation{
+			{Field: "resume_id", Description: "must be 24 hexadecimal characters", Err: ErrInvalidResumeID},
+			{Field: "company_id", Description: "must be a UUID", Err: ErrInvalidCompanyID},
+		}})
+
+		st := status.Convert(GRPCError("viewHandler.CreateView", err))
+
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, badRequest.GetFieldViolations(), 2)
+		assert.Equal(t, "resume_id", badRequest.GetFieldViolations()[0].GetField())
+		assert.Equal(t, "must be a UUID", badRequest.GetFieldViolations()[1].GetDescription())
+	})
+
+	t.Run("Other errors have no details", func(t *testing.T) {
+		t.Parallel()
+
+		st := status.Convert(GRPCError("viewHandler.GetResumeViews", ErrNotFound))
+
+		assert.Equal(t, codes.NotFound, st.Code())
+		assert.Equal(t, "viewHandler.GetResumeViews: not found", st.Message())
+		assert.Empty(t, st.Details())
+	})
+
+	t.Run("Rate limit error carries retry info", func(t *testing.T) {
+		t.Parallel()
+
+		err := fmt.Errorf("failed to create view: %w", &RateLimitError{RetryAfter: 1500 * time.Millisecond})
+
+		st := status.Convert(GRPCError("viewHandler.CreateView", err))
+
+		assert.Equal(t, codes.ResourceExhausted, st.Code())
+		require.Len(t, st.Details(), 1)
+
+		retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+		require.True(t, ok)
+		assert.Equal(t, 1500*time.Millisecond, retryInfo.GetRetryDelay().AsDuration())
+	})
+
+	t.Run("Status errors pass through", func(t *testing.T) {
+		t.Parallel()
+
+		err := status.Error(codes.PermissionDenied, "authInterceptor: permission denied")
+
+		assert.Equal(t, err, GRPCError("viewHandler.StreamViews", err))
+	})
+}
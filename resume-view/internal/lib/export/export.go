@@ -0,0 +1,115 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+)
+
+// DefaultChunkSize is the size above which Encoder hands its buffered output to the sender.
+const DefaultChunkSize = 64 * 1024
+
+var csvHeader = []string{
+	"id", "resume_id", "company_id", "viewed_at", "source", "viewer_user_id", "user_agent", "referrer", "metadata",
+	"suspicious",
+}
+
+// Encoder encodes views as NDJSON or CSV and passes the output to send in chunks of about chunkSize bytes.
+// Chunks always end at a record boundary.
+type Encoder struct {
+	format    domain.ExportFormat
+	chunkSize int
+	send      func(chunk []byte) error
+	buf       bytes.Buffer
+	json      *json.Encoder
+	csv       *csv.Writer
+}
+
+func NewEncoder(format domain.ExportFormat, chunkSize int, send func(chunk []byte) error) (*Encoder, error) {
+	e := &Encoder{format: format, chunkSize: chunkSize, send: send}
+
+	switch format {
+	case domain.ExportFormatNDJSON:
+		e.json = json.NewEncoder(&e.buf)
+	case domain.ExportFormatCSV:
+		e.csv = csv.NewWriter(&e.buf)
+
+		if err := e.csv.Write(csvHeader); err != nil {
+			return nil, fmt.Errorf("failed to write csv header: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+
+	return e, nil
+}
+
+func (e *Encoder) Encode(view models.View) error {
+	if e.json != nil {
+		if err := e.json.Encode(view); err != nil {
+			return fmt.Errorf("failed to encode view: %w", err)
+		}
+	} else {
+		// The metadata column holds a JSON object, or nothing when the view has no metadata.
+		var metadata []byte
+
+		if len(view.Metadata) > 0 {
+			var err error
+
+			if metadata, err = json.Marshal(view.Metadata); err != nil {
+				return fmt.Errorf("failed to encode view metadata: %w", err)
+			}
+		}
+
+		err := e.csv.Write([]string{
+			view.ID.String(), view.ResumeID, view.CompanyID.String(), view.ViewedAt.UTC().Format(time.RFC3339Nano),
+			string(view.Source), view.ViewerUserID, view.UserAgent, view.Referrer, string(metadata),
+			strconv.FormatBool(view.Suspicious),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to encode view: %w", err)
+		}
+
+		e.csv.Flush()
+	}
+
+	if e.buf.Len() < e.chunkSize {
+		return nil
+	}
+
+	return e.flush()
+}
+
+// Close sends what is left in the buffer. An export without views still sends the CSV header.
+func (e *Encoder) Close() error {
+	if e.csv != nil {
+		e.csv.Flush()
+
+		if err := e.csv.Error(); err != nil {
+			return fmt.Errorf("failed to flush csv: %w", err)
+		}
+	}
+
+	if e.buf.Len() == 0 {
+		return nil
+	}
+
+	return e.flush()
+}
+
+func (e *Encoder) flush() error {
+	chunk := bytes.Clone(e.buf.Bytes())
+	e.buf.Reset()
+
+	if err := e.send(chunk); err != nil {
+		return fmt.Errorf("failed to send chunk: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+//go:build !integration
+
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testViews(n int) []models.View {
+	views := make([]models.View, 0, n)
+	for i := range n {
+		views = append(views, models.View{
+			ID:        uuid.New(),
+			ResumeID:  "6630e5f1a6b1f2c3d4e5f6a7",
+			CompanyID: uuid.New(),
+			ViewedAt:  time.Date(2024, 5, 6, 0, i, 0, 0, time.UTC),
+		})
+	}
+
+	return views
+}
+
+func encode(t *testing.T, format domain.ExportFormat, chunkSize int, views []models.View) []string {
+	t.Helper()
+
+	var chunks []string
+
+	encoder, err := NewEncoder(format, chunkSize, func(chunk []byte) error {
+		chunks = append(chunks, string(chunk))
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	for _, view := range views {
+		require.NoError(t, encoder.Encode(view))
+	}
+
+	require.NoError(t, encoder.Close())
+
+	return chunks
+}
+
+func TestEncoder_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	views := testViews(5)
+	chunks := encode(t, domain.ExportFormatNDJSON, 200, views)
+
+	require.Greater(t, len(chunks), 1)
+
+	var decoded []models.View
+
+	for _, chunk := range chunks {
+		require.True(t, strings.HasSuffix(chunk, "\n"), "chunks end at a record boundary")
+
+		for _, line := range strings.Split(strings.TrimSuffix(chunk, "\n"), "\n") {
+			var view models.View
+			require.NoError(t, json.Unmarshal([]byte(line), &view))
+
+			decoded = append(decoded, view)
+		}
+	}
+
+	assert.Equal(t, views, decoded)
+}
+
+const csvHeaderLine = "id,resume_id,company_id,viewed_at,source,viewer_user_id,user_agent,referrer,metadata," +
+	"suspicious\n"
+
+func TestEncoder_CSV(t *testing.T) {
+	t.Parallel()
+
+	views := testViews(2)
+	views[1].Source = domain.ViewSourceSearch
+	views[1].ViewerUserID = "user-1"
+	views[1].UserAgent = "Mozilla/5.0 (KHTML, like Gecko)"
+	views[1].Referrer = "https://example.com/search?q=go"
+	views[1].Metadata = map[string]string{"campaign": "spring"}
+	views[1].Suspicious = true
+
+	chunks := encode(t, domain.ExportFormatCSV, DefaultChunkSize, views)
+
+	require.Len(t, chunks, 1)
+	assert.Equal(t, csvHeaderLine+
+		views[0].ID.String()+",6630e5f1a6b1f2c3d4e5f6a7,"+views[0].CompanyID.String()+",2024-05-06T00:00:00Z,,,,,,false\n"+
+		views[1].ID.String()+",6630e5f1a6b1f2c3d4e5f6a7,"+views[1].CompanyID.String()+",2024-05-06T00:01:00Z,"+
+		`search,user-1,"Mozilla/5.0 (KHTML, like Gecko)",https://example.com/search?q=go,`+
+		`"{""campaign"":""spring""}",true`+"\n",
+		chunks[0])
+}
+
+func TestEncoder_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, encode(t, domain.ExportFormatNDJSON, DefaultChunkSize, nil))
+	assert.Equal(t, []string{csvHeaderLine},
+		encode(t, domain.ExportFormatCSV, DefaultChunkSize, nil))
+}
+
+func TestEncoder_SendFailure(t *testing.T) {
+	t.Parallel()
+
+	encoder, err := NewEncoder(domain.ExportFormatNDJSON, 1, func(_ []byte) error {
+		return assert.AnError
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, encoder.Encode(testViews(1)[0]), assert.AnError)
+}
+
+func TestNewEncoder_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewEncoder("xml", DefaultChunkSize, nil)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+)
+
+const (
+	DefaultBufferSize     = 64
+	DefaultMaxSubscribers = 1000
+)
+
+// Hub fans out recorded views to the subscribers of their resume. Publish never blocks: a subscriber
+// whose buffer is full is evicted and its channel is closed, so one slow client cannot hold up writes.
+type Hub struct {
+	mu             sync.Mutex
+	subscribers    map[string]map[*Subscription]struct{}
+	count          int
+	bufferSize     int
+	maxSubscribers int
+}
+
+func NewHub(bufferSize, maxSubscribers int) *Hub {
+	return &Hub{
+		subscribers:    make(map[string]map[*Subscription]struct{}),
+		bufferSize:     bufferSize,
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+type Subscription struct {
+	hub      *Hub
+	resumeID string
+	views    chan models.View
+	evicted  atomic.Bool
+}
+
+// Subscribe registers a subscriber for the views of resumeID. The caller must Close the subscription.
+func (h *Hub) Subscribe(resumeID string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count >= h.maxSubscribers {
+		return nil, customerrors.ErrTooManySubscribers
+	}
+
+	sub := &Subscription{hub: h, resumeID: resumeID, views: make(chan models.View, h.bufferSize)}
+
+	if h.subscribers[resumeID] == nil {
+		h.subscribers[resumeID] = make(map[*Subscription]struct{})
+	}
+
+	h.subscribers[resumeID][sub] = struct{}{}
+	h.count++
+
+	return sub, nil
+}
+
+func (h *Hub) Publish(view models.View) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[view.ResumeID] {
+		select {
+		case sub.views <- view:
+		default:
+			sub.evicted.Store(true)
+			h.remove(sub)
+		}
+	}
+}
+
+// remove must be called with mu held. A subscription is closed only once, when it leaves the map.
+func (h *Hub) remove(sub *Subscription) {
+	subs, ok := h.subscribers[sub.resumeID]
+	if !ok {
+		return
+	}
+
+	if _, ok = subs[sub]; !ok {
+		return
+	}
+
+	delete(subs, sub)
+
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.resumeID)
+	}
+
+	h.count--
+	close(sub.views)
+}
+
+// Views is closed when the subscription is closed or evicted.
+func (s *Subscription) Views() <-chan models.View {
+	return s.views
+}
+
+// Evicted reports whether the subscription was dropped for falling behind.
+func (s *Subscription) Evicted() bool {
+	return s.evicted.Load()
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
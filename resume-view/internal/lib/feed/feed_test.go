@@ -0,0 +1,119 @@
+//go:build !integration
+
+package feed
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_Publish(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(2, 10)
+
+	first, err := hub.Subscribe("resume")
+	require.NoError(t, err)
+
+	second, err := hub.Subscribe("resume")
+	require.NoError(t, err)
+
+	other, err := hub.Subscribe("other")
+	require.NoError(t, err)
+
+	view := models.View{ID: uuid.New(), ResumeID: "resume"}
+	hub.Publish(view)
+
+	assert.Equal(t, view, <-first.Views())
+	assert.Equal(t, view, <-second.Views())
+	assert.Empty(t, other.Views())
+}
+
+func TestHub_EvictsSlowSubscriber(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(1, 10)
+
+	slow, err := hub.Subscribe("resume")
+	require.NoError(t, err)
+
+	fast, err := hub.Subscribe("resume")
+	require.NoError(t, err)
+
+	hub.Publish(models.View{ResumeID: "resume"})
+	<-fast.Views()
+
+	hub.Publish(models.View{ResumeID: "resume"})
+
+	assert.True(t, slow.Evicted())
+	assert.False(t, fast.Evicted())
+
+	_, ok := <-slow.Views()
+	assert.True(t, ok, "buffered view is still delivered")
+
+	_, ok = <-slow.Views()
+	assert.False(t, ok, "channel is closed after eviction")
+
+	slow.Close()
+	fast.Close()
+
+	_, ok = <-fast.Views()
+	assert.True(t, ok)
+
+	_, ok = <-fast.Views()
+	assert.False(t, ok)
+}
+
+func TestHub_MaxSubscribers(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(1, 1)
+
+	sub, err := hub.Subscribe("resume")
+	require.NoError(t, err)
+
+	_, err = hub.Subscribe("other")
+	require.ErrorIs(t, err, customerrors.ErrTooManySubscribers)
+
+	sub.Close()
+
+	_, err = hub.Subscribe("other")
+	require.NoError(t, err)
+}
+
+func TestHub_ConcurrentPublishAndClose(t *testing.T) {
+	t.Parallel()
+
+	hub := NewHub(4, 100)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		sub, err := hub.Subscribe("resume")
+		require.NoError(t, err)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for j := 0; j < 5; j++ {
+				<-sub.Views()
+			}
+
+			sub.Close()
+		}()
+	}
+
+	for i := 0; i < 100; i++ {
+		hub.Publish(models.View{ResumeID: "resume"})
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,171 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ErrNotProbed is the status of a dependency before its first probe completes.
+var ErrNotProbed = errors.New("not probed yet")
+
+// Check probes a single dependency. Name is also the gRPC health service name of the dependency.
+type Check struct {
+	Name string
+	Ping func(ctx context.Context) error
+}
+
+// Monitor periodically probes every dependency and mirrors the results into a grpc.health.v1 server:
+// each dependency is reported under its own name, and the overall status ("") and services are
+// SERVING only while all of them are reachable.
+type Monitor struct {
+	log      *zap.SugaredLogger
+	server   *health.Server
+	checks   []Check
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+
+	mu       sync.RWMutex
+	statuses map[string]error
+}
+
+// NewMonitor reports every dependency as NOT_SERVING until the first probe completes. services are the
+// gRPC services whose status follows the overall one.
+func NewMonitor(log *zap.SugaredLogger, server *health.Server, interval, timeout time.Duration,
+	services []string, checks ...Check) *Monitor {
+	m := &Monitor{
+		log:      log,
+		server:   server,
+		checks:   checks,
+		services: services,
+		interval: interval,
+		timeout:  timeout,
+		statuses: make(map[string]error, len(checks)),
+	}
+
+	for _, check := range checks {
+		server.SetServingStatus(check.Name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	m.setOverall(healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return m
+}
+
+// Run probes the dependencies until ctx is done.
+func (m *Monitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		m.Check(ctx)
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Check probes every dependency now, updates the reported statuses and returns the probe errors by
+// dependency name. A nil error means the dependency is reachable.
+func (m *Monitor) Check(ctx context.Context) map[string]error {
+	results := make(map[string]error, len(m.checks))
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+
+	for _, check := range m.checks {
+		wg.Add(1)
+
+		go func(check Check) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, m.timeout)
+			defer cancel()
+
+			err := check.Ping(ctx)
+
+			mu.Lock()
+			results[check.Name] = err
+			mu.Unlock()
+		}(check)
+	}
+
+	wg.Wait()
+
+	m.update(results)
+
+	return results
+}
+
+// Statuses returns the results of the last probe. Dependencies that were not probed yet are reported
+// with ErrNotProbed.
+func (m *Monitor) Statuses() map[string]error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	statuses := make(map[string]error, len(m.checks))
+	for _, check := range m.checks {
+		err, ok := m.statuses[check.Name]
+		if !ok {
+			err = ErrNotProbed
+		}
+
+		statuses[check.Name] = err
+	}
+
+	return statuses
+}
+
+func (m *Monitor) update(results map[string]error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	overall := healthpb.HealthCheckResponse_SERVING
+
+	for name, err := range results {
+		status := healthpb.HealthCheckResponse_SERVING
+		if err != nil {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		previous, known := m.statuses[name]
+		if !known || (previous == nil) != (err == nil) {
+			m.logTransition(name, err)
+		}
+
+		m.statuses[name] = err
+		m.server.SetServingStatus(name, status)
+	}
+
+	m.setOverall(overall)
+}
+
+func (m *Monitor) logTransition(name string, err error) {
+	if err != nil {
+		m.log.Warnf("dependency %s is unavailable: %v", name, err)
+
+		return
+	}
+
+	m.log.Infof("dependency %s is available", name)
+}
+
+func (m *Monitor) setOverall(status healthpb.HealthCheckResponse_ServingStatus) {
+	m.server.SetServingStatus("", status)
+
+	for _, service := range m.services {
+		m.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,84 @@
+//go:build !integration
+
+package healthcheck
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func servingStatus(t *testing.T, server *health.Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+
+	resp, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	require.NoError(t, err)
+
+	return resp.GetStatus()
+}
+
+func TestMonitor_Check(t *testing.T) {
+	t.Parallel()
+
+	var kafkaDown atomic.Bool
+
+	kafkaDown.Store(true)
+
+	server := health.NewServer()
+	monitor := NewMonitor(zap.NewNop().Sugar(), server, time.Minute, time.Second, []string{"resume_view.ViewService"},
+		Check{Name: "postgres", Ping: func(context.Context) error { return nil }},
+		Check{Name: "kafka", Ping: func(context.Context) error {
+			if kafkaDown.Load() {
+				return assert.AnError
+			}
+
+			return nil
+		}},
+	)
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, "postgres"))
+	assert.Equal(t, map[string]error{"postgres": ErrNotProbed, "kafka": ErrNotProbed}, monitor.Statuses())
+
+	results := monitor.Check(context.Background())
+	assert.NoError(t, results["postgres"])
+	assert.ErrorIs(t, results["kafka"], assert.AnError)
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, "postgres"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, "kafka"))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, "resume_view.ViewService"))
+
+	kafkaDown.Store(false)
+	monitor.Check(context.Background())
+
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, "kafka"))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, ""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, servingStatus(t, server, "resume_view.ViewService"))
+	assert.Equal(t, map[string]error{"postgres": nil, "kafka": nil}, monitor.Statuses())
+}
+
+func TestMonitor_CheckTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := health.NewServer()
+	monitor := NewMonitor(zap.NewNop().Sugar(), server, time.Minute, 10*time.Millisecond, nil,
+		Check{Name: "postgres", Ping: func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		}},
+	)
+
+	results := monitor.Check(context.Background())
+
+	assert.ErrorIs(t, results["postgres"], context.DeadlineExceeded)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, servingStatus(t, server, "postgres"))
+}
@@ -0,0 +1,152 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Component is a part of the application with an optional start, run and stop step.
+type Component struct {
+	Name string
+	// Start prepares the component and returns once the components added after it can rely on it.
+	Start func(ctx context.Context) error
+	// Run serves in the background until the component is stopped or its context is cancelled.
+	Run func(ctx context.Context) error
+	// Stop releases the component before the deadline of ctx. Its Run context is cancelled afterwards.
+	Stop func(ctx context.Context) error
+}
+
+type component struct {
+	Component
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Manager starts components in the order they were added and stops them in reverse order.
+type Manager struct {
+	log             *zap.SugaredLogger
+	shutdownTimeout time.Duration
+	components      []Component
+	started         []*component
+	errCh           chan error
+	stopping        atomic.Bool
+	stopOnce        sync.Once
+	stopErr         error
+}
+
+func NewManager(log *zap.SugaredLogger, shutdownTimeout time.Duration) *Manager {
+	return &Manager{log: log, shutdownTimeout: shutdownTimeout}
+}
+
+// Add appends components. Components must only depend on components added before them.
+func (m *Manager) Add(components ...Component) {
+	m.components = append(m.components, components...)
+}
+
+// Start starts the components one after another and launches their Run step. If a component fails to
+// start, the ones already started are stopped and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	m.errCh = make(chan error, len(m.components))
+
+	for _, c := range m.components {
+		if c.Start != nil {
+			if err := c.Start(ctx); err != nil {
+				if stopErr := m.Stop(); stopErr != nil {
+					m.log.Errorf("failed to stop after failed start: %v", stopErr)
+				}
+
+				return fmt.Errorf("failed to start %s: %w", c.Name, err)
+			}
+		}
+
+		started := &component{Component: c, cancel: func() {}}
+		m.started = append(m.started, started)
+
+		if c.Run != nil {
+			m.run(ctx, started)
+		}
+
+		m.log.Debugf("started %s", c.Name)
+	}
+
+	return nil
+}
+
+func (m *Manager) run(ctx context.Context, c *component) {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c.cancel = cancel
+	c.done = make(chan struct{})
+
+	go func() {
+		defer close(c.done)
+
+		if err := c.Run(runCtx); err != nil && !m.stopping.Load() {
+			m.errCh <- fmt.Errorf("%s: %w", c.Name, err)
+		}
+	}()
+}
+
+// Errors reports components whose Run step failed before Stop was called.
+func (m *Manager) Errors() <-chan error {
+	return m.errCh
+}
+
+// Stop stops the started components in reverse order. All of them share the shutdown timeout: once it
+// expires, the remaining ones are still asked to stop but no longer waited for.
+func (m *Manager) Stop() error {
+	m.stopOnce.Do(func() {
+		m.stopping.Store(true)
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.shutdownTimeout)
+		defer cancel()
+
+		var errs []error
+
+		for i := len(m.started) - 1; i >= 0; i-- {
+			if err := m.stop(ctx, m.started[i]); err != nil {
+				m.log.Errorf("failed to stop %s: %v", m.started[i].Name, err)
+				errs = append(errs, fmt.Errorf("%s: %w", m.started[i].Name, err))
+
+				continue
+			}
+
+			m.log.Debugf("stopped %s", m.started[i].Name)
+		}
+
+		m.stopErr = errors.Join(errs...)
+	})
+
+	return m.stopErr
+}
+
+func (m *Manager) stop(ctx context.Context, c *component) error {
+	var err error
+	if c.Stop != nil {
+		err = c.Stop(ctx)
+	}
+
+	c.cancel()
+
+	if c.done == nil {
+		return err
+	}
+
+	select {
+	case <-c.done:
+		return err
+	default:
+	}
+
+	select {
+	case <-c.done:
+		return err
+	case <-ctx.Done():
+		return errors.Join(err, fmt.Errorf("run did not return: %w", ctx.Err()))
+	}
+}
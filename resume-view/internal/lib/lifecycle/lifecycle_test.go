@@ -0,0 +1,163 @@
+//go:build !integration
+
+package lifecycle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *recorder) add(event string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) Events() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.events...)
+}
+
+func (r *recorder) component(name string) Component {
+	return Component{
+		Name: name,
+		Start: func(_ context.Context) error {
+			r.add("start " + name)
+
+			return nil
+		},
+		Stop: func(_ context.Context) error {
+			r.add("stop " + name)
+
+			return nil
+		},
+	}
+}
+
+func newTestManager(timeout time.Duration) *Manager {
+	return NewManager(zap.NewNop().Sugar(), timeout)
+}
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Stops in reverse order", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		m := newTestManager(time.Second)
+		m.Add(rec.component("db"), rec.component("consumer"), rec.component("server"))
+
+		require.NoError(t, m.Start(context.Background()))
+		require.NoError(t, m.Stop())
+
+		assert.Equal(t, []string{
+			"start db", "start consumer", "start server",
+			"stop server", "stop consumer", "stop db",
+		}, rec.Events())
+	})
+
+	t.Run("Failed start stops started components", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		failing := rec.component("server")
+		failing.Start = func(_ context.Context) error {
+			return assert.AnError
+		}
+
+		m := newTestManager(time.Second)
+		m.Add(rec.component("db"), rec.component("consumer"), failing)
+
+		err := m.Start(context.Background())
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, []string{"start db", "start consumer", "stop consumer", "stop db"}, rec.Events())
+	})
+
+	t.Run("Run is cancelled after stop", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		m := newTestManager(time.Second)
+		m.Add(Component{
+			Name: "worker",
+			Run: func(ctx context.Context) error {
+				<-ctx.Done()
+				rec.add("run done")
+
+				return ctx.Err()
+			},
+			Stop: func(_ context.Context) error {
+				rec.add("stop worker")
+
+				return nil
+			},
+		})
+
+		require.NoError(t, m.Start(context.Background()))
+		require.NoError(t, m.Stop())
+
+		assert.Equal(t, []string{"stop worker", "run done"}, rec.Events())
+		assert.Empty(t, m.Errors())
+	})
+
+	t.Run("Run failure is reported", func(t *testing.T) {
+		t.Parallel()
+
+		m := newTestManager(time.Second)
+		m.Add(Component{
+			Name: "worker",
+			Run: func(_ context.Context) error {
+				return assert.AnError
+			},
+		})
+
+		require.NoError(t, m.Start(context.Background()))
+
+		select {
+		case err := <-m.Errors():
+			assert.ErrorIs(t, err, assert.AnError)
+		case <-time.After(time.Second):
+			t.Fatal("run failure was not reported")
+		}
+
+		require.NoError(t, m.Stop())
+	})
+
+	t.Run("Deadline is shared and remaining components are still stopped", func(t *testing.T) {
+		t.Parallel()
+
+		rec := &recorder{}
+		m := newTestManager(20 * time.Millisecond)
+		m.Add(rec.component("db"), Component{
+			Name: "consumer",
+			Stop: func(ctx context.Context) error {
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+		})
+
+		require.NoError(t, m.Start(context.Background()))
+
+		err := m.Stop()
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, []string{"start db", "stop db"}, rec.Events())
+	})
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/segmentio/kafka-go"
+)
+
+var (
+	poolAcquiredDesc = prometheus.NewDesc("resume_view_db_pool_acquired_connections",
+		"Number of connections currently acquired from the pool", nil, nil)
+	poolIdleDesc = prometheus.NewDesc("resume_view_db_pool_idle_connections",
+		"Number of idle connections in the pool", nil, nil)
+	poolTotalDesc = prometheus.NewDesc("resume_view_db_pool_total_connections",
+		"Total number of connections in the pool", nil, nil)
+	poolMaxDesc = prometheus.NewDesc("resume_view_db_pool_max_connections",
+		"Maximum size of the pool", nil, nil)
+	poolAcquiresDesc = prometheus.NewDesc("resume_view_db_pool_acquires_total",
+		"Total number of successful connection acquires", nil, nil)
+	poolEmptyAcquiresDesc = prometheus.NewDesc("resume_view_db_pool_empty_acquires_total",
+		"Total number of acquires that had to wait for a connection", nil, nil)
+	poolAcquireDurationDesc = prometheus.NewDesc("resume_view_db_pool_acquire_duration_seconds_total",
+		"Total time spent acquiring connections", nil, nil)
+
+	kafkaLagDesc = prometheus.NewDesc("resume_view_kafka_consumer_lag",
+		"Number of messages the consumer is behind the partition high watermark", nil, nil)
+	kafkaMessagesDesc = prometheus.NewDesc("resume_view_kafka_consumer_messages_total",
+		"Total number of messages fetched by the consumer", nil, nil)
+	kafkaBytesDesc = prometheus.NewDesc("resume_view_kafka_consumer_bytes_total",
+		"Total number of message bytes fetched by the consumer", nil, nil)
+	kafkaErrorsDesc = prometheus.NewDesc("resume_view_kafka_consumer_errors_total",
+		"Total number of consumer fetch errors", nil, nil)
+	kafkaRebalancesDesc = prometheus.NewDesc("resume_view_kafka_consumer_rebalances_total",
+		"Total number of consumer group rebalances", nil, nil)
+)
+
+// poolCollector reads the pgx pool stats at scrape time.
+type poolCollector struct {
+	pool *pgxpool.Pool
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredDesc
+	ch <- poolIdleDesc
+	ch <- poolTotalDesc
+	ch <- poolMaxDesc
+	ch <- poolAcquiresDesc
+	ch <- poolEmptyAcquiresDesc
+	ch <- poolAcquireDurationDesc
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolAcquiredDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleDesc, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalDesc, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(poolMaxDesc, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquiresDesc, prometheus.CounterValue,
+		float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(poolAcquireDurationDesc, prometheus.CounterValue,
+		stat.AcquireDuration().Seconds())
+}
+
+// kafkaReaderCollector turns kafka-go reader stats into metrics. The reader resets its counters on
+// every Stats call, so they are accumulated here to expose monotonic counters.
+type kafkaReaderCollector struct {
+	stats func() kafka.ReaderStats
+
+	mu         sync.Mutex
+	messages   int64
+	bytes      int64
+	errors     int64
+	rebalances int64
+}
+
+// Describe lists the descriptors without reading the stats, which would reset the reader counters.
+func (c *kafkaReaderCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- kafkaLagDesc
+	ch <- kafkaMessagesDesc
+	ch <- kafkaBytesDesc
+	ch <- kafkaErrorsDesc
+	ch <- kafkaRebalancesDesc
+}
+
+func (c *kafkaReaderCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.stats()
+
+	c.mu.Lock()
+	c.messages += stats.Messages
+	c.bytes += stats.Bytes
+	c.errors += stats.Errors
+	c.rebalances += stats.Rebalances
+	messages, bytes, errors, rebalances := c.messages, c.bytes, c.errors, c.rebalances
+	c.mu.Unlock()
+
+	ch <- prometheus.MustNewConstMetric(kafkaLagDesc, prometheus.GaugeValue, float64(stats.Lag))
+	ch <- prometheus.MustNewConstMetric(kafkaMessagesDesc, prometheus.CounterValue, float64(messages))
+	ch <- prometheus.MustNewConstMetric(kafkaBytesDesc, prometheus.CounterValue, float64(bytes))
+	ch <- prometheus.MustNewConstMetric(kafkaErrorsDesc, prometheus.CounterValue, float64(errors))
+	ch <- prometheus.MustNewConstMetric(kafkaRebalancesDesc, prometheus.CounterValue, float64(rebalances))
+}
+
+func RegisterPool(pool *pgxpool.Pool) error {
+	if err := prometheus.Register(&poolCollector{pool: pool}); err != nil {
+		return fmt.Errorf("error registering pool metrics: %w", err)
+	}
+
+	return nil
+}
+
+// RegisterKafkaReader exposes the lag and throughput of a consumer. stats must return the reader stats
+// accumulated since its previous call, as kafka.Reader.Stats does.
+func RegisterKafkaReader(stats func() kafka.ReaderStats) error {
+	if err := prometheus.Register(&kafkaReaderCollector{stats: stats}); err != nil {
+		return fmt.Errorf("error registering kafka consumer metrics: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor records the latency and status code of every unary RPC.
+func (metrics *PrometheusMetrics) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		metrics.RPCDuration.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+		metrics.RPCCounter.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records the status code of every streaming RPC. Streams such as
+// WatchResumeViews live as long as the client wants, so their duration is not observed.
+func (metrics *PrometheusMetrics) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, stream)
+
+		metrics.RPCCounter.WithLabelValues(info.FullMethod, status.Code(err).String()).Inc()
+
+		return err
+	}
+}
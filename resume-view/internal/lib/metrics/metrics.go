@@ -6,26 +6,84 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// PrometheusMetrics holds the service metrics. Labels only take values from small fixed sets, such as
+// outcomes, statuses, RPC methods and codes, so the number of series does not grow with the data.
+// Per-resume counts are served by GetResumeViewStats instead.
 type PrometheusMetrics struct {
-	TotalViewCounter *prometheus.CounterVec
+	ViewCounter           *prometheus.CounterVec
+	SuspiciousViewCounter *prometheus.CounterVec
+	EventCounter          *prometheus.CounterVec
+	RPCCounter            *prometheus.CounterVec
+	RPCDuration           *prometheus.HistogramVec
+	DeadNotifications     prometheus.Counter
 }
 
 func NewPrometheusMetrics() (*PrometheusMetrics, error) {
-	metrics := &PrometheusMetrics{
-		TotalViewCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
-			Name: "resume_views_total",
-			Help: "Total number of resume views",
-		}, []string{"resume_id"}),
+	metrics := newPrometheusMetrics()
+
+	collectors := []prometheus.Collector{
+		metrics.ViewCounter,
+		metrics.SuspiciousViewCounter,
+		metrics.EventCounter,
+		metrics.RPCCounter,
+		metrics.RPCDuration,
+		metrics.DeadNotifications,
 	}
 
-	err := prometheus.Register(metrics.TotalViewCounter)
-	if err != nil {
-		return nil, fmt.Errorf("error registering counter metrics: %v", err)
+	for _, collector := range collectors {
+		if err := prometheus.Register(collector); err != nil {
+			return nil, fmt.Errorf("error registering metrics: %w", err)
+		}
 	}
 
 	return metrics, nil
 }
 
-func (metrics *PrometheusMetrics) Inc(resumeID string) {
-	metrics.TotalViewCounter.WithLabelValues(resumeID).Inc()
+func newPrometheusMetrics() *PrometheusMetrics {
+	return &PrometheusMetrics{
+		ViewCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "resume_views_total",
+			Help: "Total number of recorded resume views by outcome",
+		}, []string{"outcome"}),
+		SuspiciousViewCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "resume_views_suspicious_total",
+			Help: "Total number of recorded resume views flagged as suspicious by broken abuse rule",
+		}, []string{"rule"}),
+		EventCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "resume_view_events_total",
+			Help: "Total number of consumed resume view events by processing status",
+		}, []string{"status"}),
+		RPCCounter: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "resume_view_rpc_requests_total",
+			Help: "Total number of handled gRPC requests by method and status code",
+		}, []string{"method", "code"}),
+		RPCDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "resume_view_rpc_duration_seconds",
+			Help:    "Latency of unary gRPC requests by method",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+		DeadNotifications: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "resume_view_notifications_dead_total",
+			Help: "Total number of notifications moved to the dead letter table after failing every delivery attempt",
+		}),
+	}
+}
+
+// IncView counts a CreateView call by its outcome: counted, collapsed or replayed.
+func (metrics *PrometheusMetrics) IncView(outcome string) {
+	metrics.ViewCounter.WithLabelValues(outcome).Inc()
+}
+
+// IncSuspiciousView counts a flagged view once for every abuse rule it broke.
+func (metrics *PrometheusMetrics) IncSuspiciousView(rule string) {
+	metrics.SuspiciousViewCounter.WithLabelValues(rule).Inc()
+}
+
+// AddDeadNotifications counts the notifications given up on after their last failed delivery.
+func (metrics *PrometheusMetrics) AddDeadNotifications(n int) {
+	metrics.DeadNotifications.Add(float64(n))
+}
+
+func (metrics *PrometheusMetrics) IncEvent(status string) {
+	metrics.EventCounter.WithLabelValues(status).Inc()
 }
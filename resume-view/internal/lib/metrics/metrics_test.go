@@ -0,0 +1,128 @@
+//go:build !integration
+
+package metrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/view.ViewService/CreateView"
+
+func TestPrometheusMetrics_UnaryServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	metrics := newPrometheusMetrics()
+	interceptor := metrics.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+
+	_, err := interceptor(context.Background(), nil, info, func(_ context.Context, _ any) (any, error) {
+		return "ok", nil
+	})
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), nil, info, func(_ context.Context, _ any) (any, error) {
+		return nil, status.Error(codes.InvalidArgument, "bad request")
+	})
+	require.Error(t, err)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.RPCCounter.WithLabelValues(testMethod, "OK")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.RPCCounter.WithLabelValues(testMethod, "InvalidArgument")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.RPCDuration))
+}
+
+func TestPrometheusMetrics_StreamServerInterceptor(t *testing.T) {
+	t.Parallel()
+
+	metrics := newPrometheusMetrics()
+	interceptor := metrics.StreamServerInterceptor()
+	info := &grpc.StreamServerInfo{FullMethod: "/view.ViewService/WatchResumeViews"}
+
+	err := interceptor(nil, nil, info, func(_ any, _ grpc.ServerStream) error {
+		return status.Error(codes.ResourceExhausted, "evicted")
+	})
+	require.Error(t, err)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		metrics.RPCCounter.WithLabelValues(info.FullMethod, "ResourceExhausted")), 0)
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.RPCDuration))
+}
+
+func TestPrometheusMetrics_IncView(t *testing.T) {
+	t.Parallel()
+
+	metrics := newPrometheusMetrics()
+	metrics.IncView("counted")
+	metrics.IncView("counted")
+	metrics.IncView("replayed")
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.ViewCounter))
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.ViewCounter.WithLabelValues("counted")), 0)
+}
+
+func TestPrometheusMetrics_IncSuspiciousView(t *testing.T) {
+	t.Parallel()
+
+	metrics := newPrometheusMetrics()
+	metrics.IncSuspiciousView("view_rate")
+	metrics.IncSuspiciousView("view_rate")
+	metrics.IncSuspiciousView("blocked_company")
+
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.SuspiciousViewCounter))
+	assert.InDelta(t, 2, testutil.ToFloat64(metrics.SuspiciousViewCounter.WithLabelValues("view_rate")), 0)
+}
+
+func TestKafkaReaderCollector(t *testing.T) {
+	t.Parallel()
+
+	stats := []kafka.ReaderStats{
+		{Lag: 10, Messages: 3, Bytes: 300},
+		{Lag: 4, Messages: 2, Bytes: 200, Errors: 1},
+	}
+	calls := 0
+	collector := &kafkaReaderCollector{stats: func() kafka.ReaderStats {
+		s := stats[calls]
+		calls++
+
+		return s
+	}}
+
+	registry := prometheus.NewPedanticRegistry()
+	require.NoError(t, registry.Register(collector))
+
+	_, err := registry.Gather()
+	require.NoError(t, err)
+
+	expected := `
+# HELP resume_view_kafka_consumer_lag Number of messages the consumer is behind the partition high watermark
+# TYPE resume_view_kafka_consumer_lag gauge
+resume_view_kafka_consumer_lag 4
+# HELP resume_view_kafka_consumer_messages_total Total number of messages fetched by the consumer
+# TYPE resume_view_kafka_consumer_messages_total counter
+resume_view_kafka_consumer_messages_total 5
+# HELP resume_view_kafka_consumer_bytes_total Total number of message bytes fetched by the consumer
+# TYPE resume_view_kafka_consumer_bytes_total counter
+resume_view_kafka_consumer_bytes_total 500
+# HELP resume_view_kafka_consumer_errors_total Total number of consumer fetch errors
+# TYPE resume_view_kafka_consumer_errors_total counter
+resume_view_kafka_consumer_errors_total 1
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"resume_view_kafka_consumer_lag",
+		"resume_view_kafka_consumer_messages_total",
+		"resume_view_kafka_consumer_bytes_total",
+		"resume_view_kafka_consumer_errors_total",
+	)
+	assert.NoError(t, err)
+}
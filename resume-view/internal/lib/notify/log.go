@@ -0,0 +1,24 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"go.uber.org/zap"
+)
+
+// LogNotifier writes notifications to the log instead of delivering them, for development and tests.
+type LogNotifier struct {
+	log *zap.SugaredLogger
+}
+
+func NewLogNotifier(log *zap.SugaredLogger) *LogNotifier {
+	return &LogNotifier{log: log}
+}
+
+func (l *LogNotifier) Notify(_ context.Context, notification models.Notification) error {
+	l.log.Infof("notification %d %s of resume %s: %s", notification.ID, notification.Kind, notification.ResumeID,
+		notification.Payload)
+
+	return nil
+}
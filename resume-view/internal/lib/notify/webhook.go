@@ -0,0 +1,140 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+)
+
+const (
+	HeaderID        = "X-Webhook-Id"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	signaturePrefix = "sha256="
+)
+
+type WebhookConfig struct {
+	URL            string
+	Secret         string
+	Timeout        time.Duration
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// WebhookNotifier POSTs notifications as JSON to a URL. Each request is signed with HMAC-SHA256 over
+// "<timestamp>.<body>", see Sign, so the receiver can check it came from this service and is recent.
+type WebhookNotifier struct {
+	client *http.Client
+	cfg    WebhookConfig
+}
+
+func NewWebhookNotifier(cfg WebhookConfig) *WebhookNotifier {
+	return &WebhookNotifier{client: &http.Client{Timeout: cfg.Timeout}, cfg: cfg}
+}
+
+type webhookBody struct {
+	ID        int64           `json:"id"`
+	Kind      string          `json:"kind"`
+	ResumeID  string          `json:"resume_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Notify delivers a notification, retrying transport errors, 429 and 5xx responses with exponential
+// backoff. Other responses outside 2xx are not retried. Receivers should use the X-Webhook-Id header to
+// drop duplicates, since a notification is delivered at least once.
+func (w *WebhookNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	body, err := json.Marshal(webhookBody{
+		ID:        notification.ID,
+		Kind:      notification.Kind,
+		ResumeID:  notification.ResumeID,
+		CreatedAt: notification.CreatedAt,
+		Data:      notification.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		retry, err := w.post(ctx, notification.ID, body)
+		if err == nil {
+			return nil
+		}
+
+		if !retry || attempt > w.cfg.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("failed to deliver notification: %w", errors.Join(err, ctx.Err()))
+		case <-time.After(w.backoff(attempt)):
+		}
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (w *WebhookNotifier) post(ctx context.Context, id int64, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderID, strconv.FormatInt(id, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(w.cfg.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to send webhook: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("webhook rejected notification with status %d", resp.StatusCode)
+	}
+}
+
+// backoff returns the delay before the given retry attempt, starting from 1.
+func (w *WebhookNotifier) backoff(attempt int) time.Duration {
+	delay := w.cfg.InitialBackoff
+	for i := 1; i < attempt && delay < w.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, w.cfg.MaxBackoff)
+}
+
+// Sign returns the X-Webhook-Signature value of a body sent at timestamp, in Unix seconds.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
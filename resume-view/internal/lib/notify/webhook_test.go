@@ -0,0 +1,104 @@
+//go:build !integration
+
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestNotifier(url string) *WebhookNotifier {
+	return NewWebhookNotifier(WebhookConfig{
+		URL:            url,
+		Secret:         "secret",
+		Timeout:        time.Second,
+		MaxRetries:     2,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	t.Parallel()
+
+	notification := models.Notification{
+		ID:       42,
+		Kind:     models.NotificationNewCompany,
+		ResumeID: "resume",
+		Payload:  []byte(`{"resume_id":"resume"}`),
+	}
+
+	tests := []struct {
+		name     string
+		statuses []int
+		wantErr  bool
+		requests int32
+	}{
+		{name: "Delivered", statuses: []int{http.StatusNoContent}, requests: 1},
+		{name: "Retries server errors", statuses: []int{http.StatusBadGateway, http.StatusOK}, requests: 2},
+		{name: "Retries too many requests", statuses: []int{http.StatusTooManyRequests, http.StatusOK}, requests: 2},
+		{name: "Does not retry client errors", statuses: []int{http.StatusBadRequest}, wantErr: true, requests: 1},
+		{
+			name:     "Gives up after max retries",
+			statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError},
+			wantErr:  true,
+			requests: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := requests.Add(1)
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+
+				assert.Equal(t, "42", r.Header.Get(HeaderID))
+				assert.Equal(t, Sign("secret", r.Header.Get(HeaderTimestamp), body), r.Header.Get(HeaderSignature))
+
+				var got webhookBody
+				assert.NoError(t, json.Unmarshal(body, &got))
+				assert.Equal(t, models.NotificationNewCompany, got.Kind)
+				assert.JSONEq(t, string(notification.Payload), string(got.Data))
+
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			t.Cleanup(server.Close)
+
+			err := newTestNotifier(server.URL).Notify(context.Background(), notification)
+
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.requests, requests.Load())
+		})
+	}
+}
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	signature := Sign("secret", "1700000000", []byte(`{}`))
+
+	assert.Equal(t, "sha256=b8569b78799ff9e3cbff0fc2d63a33a2b57f3282abd07c37ae5e8e7d79a5f163", signature)
+	assert.NotEqual(t, signature, Sign("other", "1700000000", []byte(`{}`)))
+	assert.NotEqual(t, signature, Sign("secret", "1700000001", []byte(`{}`)))
+}
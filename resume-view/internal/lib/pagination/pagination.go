@@ -6,18 +6,31 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
 	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
 	"github.com/google/uuid"
 )
 
-func DecodeCursor(encodedCursor string) (time.Time, uuid.UUID, error) {
+// DecodeCursor returns the position a page ended at. The cursor is only valid for the sort order it
+// was issued for, since the position means "after" in one order and "before" in the other. Cursors
+// without an order predate sorting and were always newest first.
+func DecodeCursor(encodedCursor string, order domain.SortOrder) (time.Time, uuid.UUID, error) {
 	byt, err := base64.StdEncoding.DecodeString(encodedCursor)
 	if err != nil {
 		return time.Time{}, [16]byte{}, customerrors.ErrInvalidCursor
 	}
 
 	arrStr := strings.Split(string(byt), ",")
-	if len(arrStr) != 2 {
+	if len(arrStr) != 2 && len(arrStr) != 3 {
+		return time.Time{}, [16]byte{}, customerrors.ErrInvalidCursor
+	}
+
+	cursorOrder := domain.SortNewestFirst
+	if len(arrStr) == 3 {
+		cursorOrder = domain.SortOrder(arrStr[2])
+	}
+
+	if cursorOrder != order {
 		return time.Time{}, [16]byte{}, customerrors.ErrInvalidCursor
 	}
 
@@ -34,8 +47,34 @@ func DecodeCursor(encodedCursor string) (time.Time, uuid.UUID, error) {
 	return res, viewID, nil
 }
 
-func EncodeCursor(t time.Time, uuid string) string {
-	key := fmt.Sprintf("%s,%s", t.Format(time.RFC3339Nano), uuid)
+func EncodeCursor(t time.Time, uuid string, order domain.SortOrder) string {
+	key := fmt.Sprintf("%s,%s,%s", t.Format(time.RFC3339Nano), uuid, order)
+
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
+// DecodeResumeCursor returns the position a page of resumes ordered by last view, newest first, ended at.
+func DecodeResumeCursor(encodedCursor string) (time.Time, string, error) {
+	byt, err := base64.StdEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return time.Time{}, "", customerrors.ErrInvalidCursor
+	}
+
+	arrStr := strings.Split(string(byt), ",")
+	if len(arrStr) != 2 || arrStr[1] == "" {
+		return time.Time{}, "", customerrors.ErrInvalidCursor
+	}
+
+	res, err := time.Parse(time.RFC3339Nano, arrStr[0])
+	if err != nil {
+		return time.Time{}, "", customerrors.ErrInvalidCursor
+	}
+
+	return res, arrStr[1], nil
+}
+
+func EncodeResumeCursor(t time.Time, resumeID string) string {
+	key := fmt.Sprintf("%s,%s", t.Format(time.RFC3339Nano), resumeID)
 
 	return base64.StdEncoding.EncodeToString([]byte(key))
 }
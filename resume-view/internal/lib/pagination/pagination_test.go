@@ -0,0 +1,106 @@
+//go:build !integration
+
+package pagination
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+
+	viewedAt := time.Date(2024, 5, 6, 12, 30, 0, 123456000, time.UTC)
+	viewID := uuid.New()
+
+	legacy := base64.StdEncoding.EncodeToString([]byte(viewedAt.Format(time.RFC3339Nano) + "," + viewID.String()))
+
+	tests := []struct {
+		name    string
+		cursor  string
+		order   domain.SortOrder
+		wantErr error
+	}{
+		{
+			name:   "Newest first",
+			cursor: EncodeCursor(viewedAt, viewID.String(), domain.SortNewestFirst),
+			order:  domain.SortNewestFirst,
+		},
+		{
+			name:   "Oldest first",
+			cursor: EncodeCursor(viewedAt, viewID.String(), domain.SortOldestFirst),
+			order:  domain.SortOldestFirst,
+		},
+		{
+			name:   "Legacy cursor is newest first",
+			cursor: legacy,
+			order:  domain.SortNewestFirst,
+		},
+		{
+			name:    "Legacy cursor with oldest first",
+			cursor:  legacy,
+			order:   domain.SortOldestFirst,
+			wantErr: customerrors.ErrInvalidCursor,
+		},
+		{
+			name:    "Cursor of the opposite order",
+			cursor:  EncodeCursor(viewedAt, viewID.String(), domain.SortNewestFirst),
+			order:   domain.SortOldestFirst,
+			wantErr: customerrors.ErrInvalidCursor,
+		},
+		{
+			name:    "Not base64",
+			cursor:  "???",
+			order:   domain.SortNewestFirst,
+			wantErr: customerrors.ErrInvalidCursor,
+		},
+		{
+			name:    "Malformed view id",
+			cursor:  base64.StdEncoding.EncodeToString([]byte(viewedAt.Format(time.RFC3339Nano) + ",abc,desc")),
+			order:   domain.SortNewestFirst,
+			wantErr: customerrors.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotViewedAt, gotViewID, err := DecodeCursor(tt.cursor, tt.order)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, viewedAt.Equal(gotViewedAt))
+			assert.Equal(t, viewID, gotViewID)
+		})
+	}
+}
+
+func TestResumeCursor(t *testing.T) {
+	t.Parallel()
+
+	viewedAt := time.Date(2024, 5, 6, 12, 30, 0, 123456000, time.UTC)
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+
+	gotViewedAt, gotResumeID, err := DecodeResumeCursor(EncodeResumeCursor(viewedAt, resumeID))
+	require.NoError(t, err)
+	assert.True(t, viewedAt.Equal(gotViewedAt))
+	assert.Equal(t, resumeID, gotResumeID)
+
+	_, _, err = DecodeResumeCursor(base64.StdEncoding.EncodeToString([]byte(viewedAt.Format(time.RFC3339Nano) + ",")))
+	assert.ErrorIs(t, err, customerrors.ErrInvalidCursor)
+
+	_, _, err = DecodeResumeCursor("???")
+	assert.ErrorIs(t, err, customerrors.ErrInvalidCursor)
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often full buckets are dropped from memory.
+const sweepInterval = time.Minute
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryLimiter keeps a token bucket per key in process memory. Each replica limits on its own, so the
+// effective limit grows with the number of replicas.
+type MemoryLimiter struct {
+	limit     Limit
+	now       func() time.Time
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+func NewMemoryLimiter(limit Limit) (*MemoryLimiter, error) {
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+
+	return &MemoryLimiter{limit: limit, now: time.Now, buckets: make(map[string]*bucket)}, nil
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, n int) (Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(l.limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	tokens, result := l.limit.take(b.tokens, now.Sub(b.updated), n)
+	b.tokens, b.updated = tokens, now
+
+	return result, nil
+}
+
+// sweep drops the buckets that have refilled completely, since a new bucket starts out full anyway.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+
+	l.lastSweep = now
+	refill := l.limit.refillTime()
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= refill {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+//go:build !integration
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	limiter, err := NewMemoryLimiter(Limit{Rate: 2, Burst: 4})
+	require.NoError(t, err)
+
+	limiter.now = func() time.Time {
+		return now
+	}
+
+	allow := func(key string, n int) Result {
+		t.Helper()
+
+		result, err := limiter.Allow(context.Background(), key, n)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	assert.Equal(t, Result{Allowed: 3}, allow("company", 3))
+	assert.Equal(t, Result{Allowed: 1, RetryAfter: 500 * time.Millisecond}, allow("company", 2))
+	assert.Equal(t, Result{Allowed: 0, RetryAfter: 500 * time.Millisecond}, allow("company", 1))
+	assert.Equal(t, Result{Allowed: 4}, allow("other", 4), "keys have separate buckets")
+
+	now = now.Add(250 * time.Millisecond)
+	assert.Equal(t, Result{Allowed: 0, RetryAfter: 250 * time.Millisecond}, allow("company", 1))
+
+	now = now.Add(time.Second)
+	assert.Equal(t, Result{Allowed: 2, RetryAfter: 250 * time.Millisecond}, allow("company", 3))
+
+	now = now.Add(time.Hour)
+	assert.Equal(t, Result{Allowed: 4, RetryAfter: 500 * time.Millisecond}, allow("company", 10))
+	assert.Len(t, limiter.buckets, 1, "refilled buckets are swept")
+}
+
+func TestNewMemoryLimiter_InvalidLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		limit Limit
+	}{
+		{name: "Zero rate", limit: Limit{Rate: 0, Burst: 1}},
+		{name: "Negative rate", limit: Limit{Rate: -1, Burst: 1}},
+		{name: "NaN rate", limit: Limit{Rate: math.NaN(), Burst: 1}},
+		{name: "Infinite rate", limit: Limit{Rate: math.Inf(1), Burst: 1}},
+		{name: "Zero burst", limit: Limit{Rate: 1, Burst: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewMemoryLimiter(tt.limit)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Limit configures a token bucket: it holds up to Burst tokens and refills Rate tokens per second.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// validate rejects limits that never refill or never hold a whole token, which would make every request
+// wait forever.
+func (l Limit) validate() error {
+	switch {
+	case !(l.Rate > 0) || math.IsInf(l.Rate, 1):
+		return errors.New("rate limit rate must be a positive number")
+	case l.Burst < 1:
+		return errors.New("rate limit burst must be at least 1")
+	}
+
+	return nil
+}
+
+// Result reports how many of the requested tokens were granted. When fewer were granted, RetryAfter is
+// how long until the next token is available.
+type Result struct {
+	Allowed    int
+	RetryAfter time.Duration
+}
+
+// take refills a bucket holding tokens, last updated elapsed ago, and takes up to n tokens from it.
+// It returns the tokens left in the bucket along with the result.
+func (l Limit) take(tokens float64, elapsed time.Duration, n int) (float64, Result) {
+	tokens = math.Min(float64(l.Burst), tokens+elapsed.Seconds()*l.Rate)
+
+	allowed := min(n, int(tokens))
+	tokens -= float64(allowed)
+
+	result := Result{Allowed: allowed}
+	if allowed < n {
+		result.RetryAfter = time.Duration((1 - tokens) / l.Rate * float64(time.Second))
+	}
+
+	return tokens, result
+}
+
+// refillTime is how long an empty bucket takes to fill up, after which its state can be forgotten.
+func (l Limit) refillTime() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
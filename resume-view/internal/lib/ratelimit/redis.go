@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const redisKeyPrefix = "ratelimit:views:"
+
+// takeScript refills and takes from a bucket atomically, using the Redis clock so replicas with skewed
+// clocks share one bucket consistently. Buckets expire once they would have refilled completely.
+var takeScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local requested = tonumber(ARGV[3])
+
+local time = redis.call('TIME')
+local now = tonumber(time[1]) + tonumber(time[2]) / 1000000
+
+local bucket = redis.call('HMGET', KEYS[1], 'tokens', 'updated')
+local tokens = tonumber(bucket[1]) or burst
+local updated = tonumber(bucket[2]) or now
+
+tokens = math.min(burst, tokens + math.max(0, now - updated) * rate)
+
+local allowed = math.min(requested, math.floor(tokens))
+tokens = tokens - allowed
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'updated', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000))
+
+local retryAfter = 0
+if allowed < requested then
+	retryAfter = math.ceil((1 - tokens) / rate * 1000)
+end
+
+return {allowed, retryAfter}
+`)
+
+// RedisLimiter keeps the token buckets in Redis, so all replicas share one limit per key.
+type RedisLimiter struct {
+	client *redis.Client
+	limit  Limit
+}
+
+func NewRedisLimiter(client *redis.Client, limit Limit) (*RedisLimiter, error) {
+	if err := limit.validate(); err != nil {
+		return nil, err
+	}
+
+	return &RedisLimiter{client: client, limit: limit}, nil
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, n int) (Result, error) {
+	values, err := takeScript.Run(ctx, l.client, []string{redisKeyPrefix + key},
+		l.limit.Rate, l.limit.Burst, n).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to take rate limit tokens: %w", err)
+	}
+
+	if len(values) != 2 {
+		return Result{}, fmt.Errorf("unexpected rate limit script reply %v", values)
+	}
+
+	return Result{Allowed: int(values[0]), RetryAfter: time.Duration(values[1]) * time.Millisecond}, nil
+}
@@ -0,0 +1,75 @@
+//go:build integration
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupRedis(ctx context.Context, t *testing.T) *redis.Client {
+	t.Helper()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections"),
+		},
+		Started: true,
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, container.Terminate(ctx))
+	})
+
+	endpoint, err := container.PortEndpoint(ctx, "6379/tcp", "")
+	require.NoError(t, err)
+
+	client := redis.NewClient(&redis.Options{Addr: endpoint})
+
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return client
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	client := setupRedis(ctx, t)
+
+	limit := Limit{Rate: 0.5, Burst: 3}
+	limiter, err := NewRedisLimiter(client, limit)
+	require.NoError(t, err)
+
+	// A second limiter stands in for another replica sharing the same buckets.
+	replica, err := NewRedisLimiter(client, limit)
+	require.NoError(t, err)
+
+	result, err := limiter.Allow(ctx, "company", 2)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Allowed: 2}, result)
+
+	result, err = replica.Allow(ctx, "company", 2)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Allowed)
+	assert.InDelta(t, 2*time.Second, result.RetryAfter, float64(100*time.Millisecond))
+
+	result, err = limiter.Allow(ctx, "other", 3)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Allowed: 3}, result)
+
+	ttl, err := client.PTTL(ctx, redisKeyPrefix+"company").Result()
+	require.NoError(t, err)
+	assert.Positive(t, ttl)
+	assert.LessOrEqual(t, ttl, limit.refillTime())
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ViewedResume summarises the views of one resume by a company.
+type ViewedResume struct {
+	ResumeID      string    `json:"resume_id" db:"resume_id"`
+	FirstViewedAt time.Time `json:"first_viewed_at" db:"first_viewed_at"`
+	LastViewedAt  time.Time `json:"last_viewed_at" db:"last_viewed_at"`
+	Count         int       `json:"count" db:"count"`
+}
+
+type CompanyViewList struct {
+	Cursor  string         `json:"cursor"`
+	Resumes []ViewedResume `json:"resumes"`
+}
+
+func (v *CompanyViewList) ToProto() *pb.GetCompanyViewsResponse {
+	resumes := make([]*pb.ViewedResume, 0, len(v.Resumes))
+	for _, val := range v.Resumes {
+		resumes = append(resumes, &pb.ViewedResume{
+			ResumeId:      val.ResumeID,
+			FirstViewedAt: timestamppb.New(val.FirstViewedAt),
+			LastViewedAt:  timestamppb.New(val.LastViewedAt),
+			Count:         int32(val.Count),
+		})
+	}
+
+	return &pb.GetCompanyViewsResponse{
+		Resumes: resumes,
+		Cursor:  v.Cursor,
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Erasure is the audit record of an erasure of the views of a resume or a company.
+type Erasure struct {
+	ID       uuid.UUID `db:"id"`
+	Erased   int64     `db:"views_erased"`
+	ErasedAt time.Time `db:"erased_at"`
+}
+
+func (e *Erasure) ToProto() *pb.EraseViewsResponse {
+	return &pb.EraseViewsResponse{
+		ErasureId: e.ID.String(),
+		Erased:    e.Erased,
+		ErasedAt:  timestamppb.New(e.ErasedAt),
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/google/uuid"
+)
+
+// ViewEventVersion is the latest "resume viewed" event schema version understood by the consumer.
+const ViewEventVersion = 1
+
+// ViewEvent is the payload producers publish to record a resume view asynchronously.
+type ViewEvent struct {
+	Version        int               `json:"version"`
+	ResumeID       string            `json:"resume_id"`
+	CompanyID      string            `json:"company_id"`
+	IdempotencyKey string            `json:"idempotency_key,omitempty"`
+	Source         string            `json:"source,omitempty"`
+	ViewerUserID   string            `json:"viewer_user_id,omitempty"`
+	UserAgent      string            `json:"user_agent,omitempty"`
+	Referrer       string            `json:"referrer,omitempty"`
+	Metadata       map[string]string `json:"metadata,omitempty"`
+}
+
+func (e *ViewEvent) Validate() error {
+	if e.Version < 1 || e.Version > ViewEventVersion {
+		return fmt.Errorf("%w: %d", customerrors.ErrUnsupportedEventVersion, e.Version)
+	}
+
+	if e.ResumeID == "" {
+		return fmt.Errorf("%w: empty resume_id", customerrors.ErrInvalidEvent)
+	}
+
+	if _, err := uuid.Parse(e.CompanyID); err != nil {
+		return fmt.Errorf("%w: invalid company_id", customerrors.ErrInvalidEvent)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Kinds of notifications sent to resume owners.
+const (
+	// NotificationNewCompany is sent when a company views a resume for the first time. Its payload holds
+	// resume_id, company_id and viewed_at.
+	NotificationNewCompany = "new_company"
+	// NotificationDigest summarizes the views of a resume since its last digest. Its payload holds resume_id,
+	// views, new_companies and the from and to times of the first and last view.
+	NotificationDigest = "digest"
+)
+
+// Notification is a pending notification of a resume owner. Payload is a JSON object whose fields depend
+// on Kind.
+type Notification struct {
+	ID        int64     `db:"id"`
+	Kind      string    `db:"kind"`
+	ResumeID  string    `db:"resume_id"`
+	Payload   []byte    `db:"payload"`
+	Attempts  int       `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
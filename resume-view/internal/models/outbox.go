@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ViewedEventVersion is the schema version of the "resume viewed" events published from the outbox.
+const ViewedEventVersion = 1
+
+// ViewedEvent is published once a view has been counted, for downstream consumers such as notifications
+// and analytics.
+type ViewedEvent struct {
+	Version      int               `json:"version"`
+	ViewID       uuid.UUID         `json:"view_id"`
+	ResumeID     string            `json:"resume_id"`
+	CompanyID    string            `json:"company_id"`
+	ViewedAt     time.Time         `json:"viewed_at"`
+	Source       string            `json:"source,omitempty"`
+	ViewerUserID string            `json:"viewer_user_id,omitempty"`
+	UserAgent    string            `json:"user_agent,omitempty"`
+	Referrer     string            `json:"referrer,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// OutboxEvent is a pending event stored in the outbox. Events of the same resume are published in ID order.
+type OutboxEvent struct {
+	ID        int64     `db:"id"`
+	ResumeID  string    `db:"resume_id"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// Partition is a monthly range partition of the views table covering [From, To).
+type Partition struct {
+	Name string
+	From time.Time
+	To   time.Time
+}
+
+// RetentionReport lists the partitions touched by one retention run. Expired counts the views deleted
+// from the default partition. Skipped is set when another replica was running retention.
+type RetentionReport struct {
+	Created  []string
+	Archived []string
+	Removed  []string
+	Expired  int64
+	Skipped  bool
+}
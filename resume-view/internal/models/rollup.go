@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// RollupMismatch is an aggregate that disagrees with the views table. Day is nil for per-resume totals and
+// CompanyID is set for the totals of a company.
+type RollupMismatch struct {
+	ResumeID  string     `json:"resume_id" db:"resume_id"`
+	Day       *time.Time `json:"day,omitempty" db:"day"`
+	CompanyID *string    `json:"company_id,omitempty" db:"company_id"`
+	Raw       int        `json:"raw" db:"raw"`
+	Rollup    int        `json:"rollup" db:"rollup"`
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ViewBucket struct {
+	Start time.Time `json:"start" db:"bucket"`
+	Count int       `json:"count" db:"count"`
+}
+
+type CompanyViews struct {
+	CompanyID    uuid.UUID `json:"company_id" db:"company_id"`
+	Count        int       `json:"count" db:"count"`
+	LastViewedAt time.Time `json:"last_viewed_at" db:"last_viewed_at"`
+}
+
+type ViewStats struct {
+	Buckets         []ViewBucket   `json:"buckets"`
+	Total           int            `json:"total"`
+	UniqueCompanies int            `json:"unique_companies"`
+	TopCompanies    []CompanyViews `json:"top_companies"`
+}
+
+func (v *ViewStats) ToProto() *pb.GetResumeViewStatsResponse {
+	buckets := make([]*pb.ViewBucket, 0, len(v.Buckets))
+	for _, val := range v.Buckets {
+		buckets = append(buckets, &pb.ViewBucket{
+			Start: timestamppb.New(val.Start),
+			Count: int32(val.Count),
+		})
+	}
+
+	companies := make([]*pb.CompanyViews, 0, len(v.TopCompanies))
+	for _, val := range v.TopCompanies {
+		companies = append(companies, &pb.CompanyViews{
+			CompanyId:    val.CompanyID.String(),
+			Count:        int32(val.Count),
+			LastViewedAt: timestamppb.New(val.LastViewedAt),
+		})
+	}
+
+	return &pb.GetResumeViewStatsResponse{
+		Buckets:         buckets,
+		Total:           int32(v.Total),
+		UniqueCompanies: int32(v.UniqueCompanies),
+		TopCompanies:    companies,
+	}
+}
@@ -4,26 +4,74 @@ import (
 	"time"
 
 	pb "github.com/Verce11o/resume-view/protos/gen/go"
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
 	"github.com/google/uuid"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type View struct {
-	ID        uuid.UUID `json:"id" db:"id"`
-	ResumeID  string    `json:"resume_id" db:"resume_id"`
-	CompanyID uuid.UUID `json:"company_id" db:"company_id"`
-	ViewedAt  time.Time `json:"viewed_at" db:"viewed_at"`
+	ID           uuid.UUID         `json:"id" db:"id"`
+	ResumeID     string            `json:"resume_id" db:"resume_id"`
+	CompanyID    uuid.UUID         `json:"company_id" db:"company_id"`
+	ViewedAt     time.Time         `json:"viewed_at" db:"viewed_at"`
+	Source       domain.ViewSource `json:"source,omitempty" db:"source"`
+	ViewerUserID string            `json:"viewer_user_id,omitempty" db:"viewer_user_id"`
+	UserAgent    string            `json:"user_agent,omitempty" db:"user_agent"`
+	Referrer     string            `json:"referrer,omitempty" db:"referrer"`
+	Metadata     map[string]string `json:"metadata,omitempty" db:"metadata"`
+	Suspicious   bool              `json:"suspicious,omitempty" db:"suspicious"`
 }
 
 func (v *View) ToProto() *pb.View {
 	return &pb.View{
-		ViewId:    v.ID.String(),
-		ResumeId:  v.ResumeID,
-		CompanyId: v.CompanyID.String(),
-		ViewedAt:  timestamppb.New(v.ViewedAt),
+		ViewId:       v.ID.String(),
+		ResumeId:     v.ResumeID,
+		CompanyId:    v.CompanyID.String(),
+		ViewedAt:     timestamppb.New(v.ViewedAt),
+		Source:       viewSourceToProto(v.Source),
+		ViewerUserId: v.ViewerUserID,
+		UserAgent:    v.UserAgent,
+		Referrer:     v.Referrer,
+		Metadata:     v.Metadata,
+		Suspicious:   v.Suspicious,
 	}
 }
 
+func viewSourceToProto(source domain.ViewSource) pb.ViewSource {
+	switch source {
+	case domain.ViewSourceDirect:
+		return pb.ViewSource_VIEW_SOURCE_DIRECT
+	case domain.ViewSourceSearch:
+		return pb.ViewSource_VIEW_SOURCE_SEARCH
+	case domain.ViewSourceRecommendation:
+		return pb.ViewSource_VIEW_SOURCE_RECOMMENDATION
+	case domain.ViewSourceNotification:
+		return pb.ViewSource_VIEW_SOURCE_NOTIFICATION
+	case domain.ViewSourceAPI:
+		return pb.ViewSource_VIEW_SOURCE_API
+	default:
+		return pb.ViewSource_VIEW_SOURCE_UNSPECIFIED
+	}
+}
+
+type CreatedView struct {
+	ID        uuid.UUID `json:"id"`
+	Replayed  bool      `json:"replayed"`
+	Collapsed bool      `json:"collapsed"`
+	ViewedAt  time.Time `json:"viewed_at"`
+}
+
+// Counted reports whether the call recorded a new view rather than returning an existing one.
+func (v *CreatedView) Counted() bool {
+	return !v.Replayed && !v.Collapsed
+}
+
+// CreateViewResult is the outcome of one item of a batch. Err is set when the item was rejected.
+type CreateViewResult struct {
+	View CreatedView
+	Err  error
+}
+
 type ViewList struct {
 	Cursor string `json:"cursor"`
 	Views  []View `json:"views"`
@@ -0,0 +1,218 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// subjectColumn returns the views column that selects subject and its value.
+func subjectColumn(subject domain.ViewSubject) (string, string) {
+	if subject.ResumeID != "" {
+		return "resume_id", subject.ResumeID
+	}
+
+	return "company_id", subject.CompanyID
+}
+
+// EraseViews deletes the views of the subject along with everything derived from them: the rollups, dedup
+// windows, idempotency keys, pending outbox events and notifications. Views in partitions that retention
+// detached but kept are deleted as well; the archives are left to the caller. The erasure is recorded in
+// view_erasures in the same transaction.
+func (r *ViewRepository) EraseViews(ctx context.Context, req domain.EraseViews) (models.Erasure, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.EraseViews")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.Erasure{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	column, value := subjectColumn(req.Subject)
+
+	// Dedup rows first and view_totals second, in the order writers lock them.
+	q := fmt.Sprintf(`DELETE FROM view_dedup WHERE %s = $1`, column) //nolint:gosec // column is a constant
+
+	if _, err = tx.Exec(ctx, q, value); err != nil {
+		return models.Erasure{}, fmt.Errorf("failed to erase dedup windows: %w", err)
+	}
+
+	q = fmt.Sprintf(`SELECT resume_id FROM view_totals
+		WHERE resume_id IN (SELECT resume_id FROM views WHERE %s = $1)
+		ORDER BY resume_id FOR UPDATE`, column) //nolint:gosec // column is a constant
+
+	if _, err = tx.Exec(ctx, q, value); err != nil {
+		return models.Erasure{}, fmt.Errorf("failed to lock view totals: %w", err)
+	}
+
+	var erased int64
+
+	q = fmt.Sprintf(`WITH erased AS (
+			DELETE FROM views WHERE %s = $1 RETURNING id, resume_id, viewed_at, suspicious
+		), keys AS (
+			DELETE FROM view_idempotency_keys WHERE view_id IN (SELECT id FROM erased)
+		), daily AS (
+			UPDATE view_daily_counts d SET count = d.count - e.count
+			FROM (SELECT resume_id, (viewed_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS count
+				FROM erased WHERE NOT suspicious GROUP BY 1, 2) e
+			WHERE d.resume_id = e.resume_id AND d.day = e.day
+		), totals AS (
+			UPDATE view_totals t SET total = t.total - e.count, updated_at = NOW()
+			FROM (SELECT resume_id, COUNT(*) AS count FROM erased WHERE NOT suspicious GROUP BY resume_id) e
+			WHERE t.resume_id = e.resume_id
+		)
+		SELECT COUNT(*) FROM erased`, column) //nolint:gosec // column is a constant
+
+	if err = tx.QueryRow(ctx, q, value).Scan(&erased); err != nil {
+		return models.Erasure{}, fmt.Errorf("failed to erase views: %w", err)
+	}
+
+	detached, err := r.eraseDetachedPartitions(ctx, tx, column, value)
+	if err != nil {
+		return models.Erasure{}, err
+	}
+
+	erased += detached
+
+	if err = r.erasePending(ctx, tx, req.Subject); err != nil {
+		return models.Erasure{}, err
+	}
+
+	var erasure models.Erasure
+
+	q = `INSERT INTO view_erasures (resume_id, company_id, requested_by, reason, views_erased)
+		VALUES (NULLIF($1, ''), NULLIF($2, '')::UUID, $3, $4, $5) RETURNING id, views_erased, erased_at`
+
+	err = tx.QueryRow(ctx, q, req.Subject.ResumeID, req.Subject.CompanyID, req.RequestedBy, req.Reason, erased).
+		Scan(&erasure.ID, &erasure.Erased, &erasure.ErasedAt)
+	if err != nil {
+		return models.Erasure{}, fmt.Errorf("failed to record erasure: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.Erasure{}, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return erasure, nil
+}
+
+// eraseDetachedPartitions deletes the views of the subject from the monthly partitions that retention
+// detached from views, which no longer count towards the rollups.
+func (r *ViewRepository) eraseDetachedPartitions(ctx context.Context, tx pgx.Tx, column, value string) (int64,
+	error) {
+	q := `SELECT c.relname FROM pg_class c
+		WHERE c.relkind = 'r' AND c.relnamespace = current_schema()::regnamespace
+		AND c.relname ~ '^views_[0-9]{4}_[0-9]{2}$'
+		AND NOT EXISTS (SELECT 1 FROM pg_inherits i WHERE i.inhrelid = c.oid)
+		ORDER BY c.relname`
+
+	rows, err := tx.Query(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list detached partitions: %w", err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to list detached partitions: %w", err)
+	}
+
+	var erased int64
+
+	for _, name := range names {
+		q = fmt.Sprintf(`DELETE FROM %s WHERE %s = $1`,
+			pgx.Identifier{name}.Sanitize(), column) //nolint:gosec // column is a constant
+
+		tag, err := tx.Exec(ctx, q, value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to erase detached partition %s: %w", name, err)
+		}
+
+		erased += tag.RowsAffected()
+	}
+
+	return erased, nil
+}
+
+// erasePending deletes the data of the subject that is kept besides the views. The rows of a resume are
+// deleted outright. A company is removed from the notification tables and the outbox by its id, while the
+// pending digests keep their counts, which do not identify it.
+func (r *ViewRepository) erasePending(ctx context.Context, tx pgx.Tx, subject domain.ViewSubject) error {
+	queries := []string{
+		`DELETE FROM view_totals WHERE resume_id = $1`,
+		`DELETE FROM view_daily_counts WHERE resume_id = $1`,
+		`DELETE FROM view_companies WHERE resume_id = $1`,
+		`DELETE FROM view_company_resumes WHERE resume_id = $1`,
+		`DELETE FROM view_digests WHERE resume_id = $1`,
+		`DELETE FROM view_notifications WHERE resume_id = $1`,
+		`DELETE FROM view_notifications_dead WHERE resume_id = $1`,
+		`DELETE FROM view_outbox WHERE resume_id = $1`,
+	}
+	value := subject.ResumeID
+
+	if subject.ResumeID == "" {
+		queries = []string{
+			`DELETE FROM view_idempotency_keys WHERE company_id = $1`,
+			`DELETE FROM view_companies WHERE company_id = $1`,
+			`DELETE FROM view_company_resumes WHERE company_id = $1`,
+			`DELETE FROM view_notifications WHERE payload->>'company_id' = $1`,
+			`DELETE FROM view_notifications_dead WHERE payload->>'company_id' = $1`,
+			`DELETE FROM view_outbox WHERE payload->>'company_id' = $1`,
+		}
+		value = subject.CompanyID
+	}
+
+	for _, q := range queries {
+		if _, err := tx.Exec(ctx, q, value); err != nil {
+			return fmt.Errorf("failed to erase derived data: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ExportViews calls fn with every view of the subject, oldest first, and returns how many were exported.
+func (r *ViewRepository) ExportViews(ctx context.Context, subject domain.ViewSubject,
+	fn func(view models.View) error) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ExportViews")
+	defer span.End()
+
+	column, value := subjectColumn(subject)
+
+	q := fmt.Sprintf(`SELECT %s FROM views WHERE %s = $1
+		ORDER BY viewed_at, id`, viewColumns, column) //nolint:gosec // column is a constant
+
+	rows, err := r.db.Query(ctx, q, value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export views: %w", err)
+	}
+	defer rows.Close()
+
+	var exported int64
+
+	for rows.Next() {
+		var view models.View
+
+		if view, err = pgx.RowToStructByName[models.View](rows); err != nil {
+			return exported, fmt.Errorf("failed to scan view: %w", err)
+		}
+
+		if err = fn(view); err != nil {
+			return exported, err
+		}
+
+		exported++
+	}
+
+	if err = rows.Err(); err != nil {
+		return exported, fmt.Errorf("failed to export views: %w", err)
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+)
+
+// The advisory lock serializing retention runs and archive redactions across replicas.
+const (
+	lockRetention    = `SELECT pg_advisory_lock(hashtext('view_retention'))`
+	tryLockRetention = `SELECT pg_try_advisory_lock(hashtext('view_retention'))`
+	unlockRetention  = `SELECT pg_advisory_unlock(hashtext('view_retention'))`
+)
+
+// WithRetentionLock runs fn while holding the advisory lock that lets a single retention run, or erasure of
+// the archives, go on at a time across replicas. The lock belongs to a connection of its own, so it is held
+// for as long as fn runs, outside any transaction. Unless wait is set, fn is skipped and false is returned
+// when another session holds the lock.
+func (r *ViewRepository) WithRetentionLock(ctx context.Context, wait bool,
+	fn func(ctx context.Context) error) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.WithRetentionLock")
+	defer span.End()
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	locked := true
+
+	if wait {
+		_, err = conn.Exec(ctx, lockRetention)
+	} else {
+		err = conn.QueryRow(ctx, tryLockRetention).Scan(&locked)
+	}
+
+	if err != nil {
+		return false, fmt.Errorf("failed to take retention lock: %w", err)
+	}
+
+	if !locked {
+		return false, nil
+	}
+
+	defer func() {
+		// A connection that still holds the lock must not go back to the pool.
+		if _, err := conn.Exec(context.WithoutCancel(ctx), unlockRetention); err != nil {
+			_ = conn.Conn().Close(context.WithoutCancel(ctx))
+		}
+	}()
+
+	return true, fn(ctx)
+}
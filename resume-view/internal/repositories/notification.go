@@ -0,0 +1,191 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// trackNotifications queues an alert for every company viewing a resume for the first time and adds the
+// views to the pending digests. Like addToOutbox it must run after incrementRollups, which serializes the
+// writers of a resume.
+func (r *ViewRepository) trackNotifications(ctx context.Context, tx pgx.Tx, events ...models.ViewedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	resumeIDs := make([]string, 0, len(events))
+	companyIDs := make([]string, 0, len(events))
+	viewedAt := make([]time.Time, 0, len(events))
+
+	for _, event := range events {
+		resumeIDs = append(resumeIDs, event.ResumeID)
+		companyIDs = append(companyIDs, event.CompanyID)
+		viewedAt = append(viewedAt, event.ViewedAt)
+	}
+
+	q := `WITH input AS (
+			SELECT * FROM unnest($1::CHAR(24)[], $2::UUID[], $3::TIMESTAMPTZ[]) AS v (resume_id, company_id, viewed_at)
+		), first_views AS (
+			INSERT INTO view_companies (resume_id, company_id, first_viewed_at)
+			SELECT DISTINCT ON (resume_id, company_id) resume_id, company_id, viewed_at FROM input
+			ORDER BY resume_id, company_id, viewed_at
+			ON CONFLICT DO NOTHING
+			RETURNING resume_id, company_id, first_viewed_at
+		), alerts AS (
+			INSERT INTO view_notifications (kind, resume_id, payload)
+			SELECT $4, resume_id, jsonb_build_object(
+				'resume_id', resume_id, 'company_id', company_id, 'viewed_at', first_viewed_at)
+			FROM first_views
+		)
+		INSERT INTO view_digests AS d (resume_id, views, new_companies, first_viewed_at, last_viewed_at)
+		SELECT i.resume_id, COUNT(*),
+			(SELECT COUNT(*) FROM first_views f WHERE f.resume_id = i.resume_id), MIN(i.viewed_at), MAX(i.viewed_at)
+		FROM input i
+		GROUP BY i.resume_id
+		ON CONFLICT (resume_id) DO UPDATE SET
+			views = d.views + EXCLUDED.views,
+			new_companies = d.new_companies + EXCLUDED.new_companies,
+			first_viewed_at = LEAST(d.first_viewed_at, EXCLUDED.first_viewed_at),
+			last_viewed_at = GREATEST(d.last_viewed_at, EXCLUDED.last_viewed_at)`
+
+	if _, err := tx.Exec(ctx, q, resumeIDs, companyIDs, viewedAt, models.NotificationNewCompany); err != nil {
+		return fmt.Errorf("failed to track notifications: %w", err)
+	}
+
+	return nil
+}
+
+// CloseDigests turns the pending digests whose first view is at least interval old into notifications,
+// so each resume gets at most one digest per interval however many replicas run this.
+func (r *ViewRepository) CloseDigests(ctx context.Context, interval time.Duration) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.CloseDigests")
+	defer span.End()
+
+	q := `WITH closed AS (
+			DELETE FROM view_digests WHERE first_viewed_at <= $1
+			RETURNING resume_id, views, new_companies, first_viewed_at, last_viewed_at
+		)
+		INSERT INTO view_notifications (kind, resume_id, payload)
+		SELECT $2, resume_id, jsonb_build_object('resume_id', resume_id, 'views', views,
+			'new_companies', new_companies, 'from', first_viewed_at, 'to', last_viewed_at)
+		FROM closed ORDER BY first_viewed_at`
+
+	tag, err := r.db.Exec(ctx, q, time.Now().Add(-interval), models.NotificationDigest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to close digests: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// RelayNotifications hands the oldest pending notifications, at most limit, to notify one by one. The batch
+// is leased for lease in a statement of its own, so no transaction stays open while notify runs and
+// replicas relay disjoint notifications. Delivered ones are deleted and failed ones released for the next
+// run until they have failed maxAttempts times. A relay that dies mid-batch leaves its notifications to be
+// picked up again once the lease ends, so lease must outlast the delivery of a batch. Errors of notify are
+// left to the caller to report; it returns how many notifications were delivered.
+func (r *ViewRepository) RelayNotifications(ctx context.Context, limit, maxAttempts int, lease time.Duration,
+	notify func(ctx context.Context, notification models.Notification) error) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.RelayNotifications")
+	defer span.End()
+
+	notifications, err := r.leaseNotifications(ctx, limit, maxAttempts, lease)
+	if err != nil {
+		return 0, err
+	}
+
+	var delivered, failed []int64
+
+	for _, notification := range notifications {
+		if notify(ctx, notification) != nil {
+			failed = append(failed, notification.ID)
+
+			continue
+		}
+
+		delivered = append(delivered, notification.ID)
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, `DELETE FROM view_notifications WHERE id = ANY($1)`, delivered); err != nil {
+		return 0, fmt.Errorf("failed to delete delivered notifications: %w", err)
+	}
+
+	q := `UPDATE view_notifications SET attempts = attempts + 1, locked_until = NULL WHERE id = ANY($1)`
+
+	if _, err = tx.Exec(ctx, q, failed); err != nil {
+		return 0, fmt.Errorf("failed to count notification attempts: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return len(delivered), nil
+}
+
+// DeadLetterNotifications moves the notifications that failed maxAttempts deliveries to
+// view_notifications_dead, where they are kept for inspection rather than piling up in the queue, and
+// returns how many were moved.
+func (r *ViewRepository) DeadLetterNotifications(ctx context.Context, maxAttempts int) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.DeadLetterNotifications")
+	defer span.End()
+
+	q := `WITH dead AS (
+			DELETE FROM view_notifications WHERE attempts >= $1
+			RETURNING id, kind, resume_id, payload, attempts, created_at
+		)
+		INSERT INTO view_notifications_dead (id, kind, resume_id, payload, attempts, created_at)
+		SELECT id, kind, resume_id, payload, attempts, created_at FROM dead`
+
+	tag, err := r.db.Exec(ctx, q, maxAttempts)
+	if err != nil {
+		return 0, fmt.Errorf("failed to dead-letter notifications: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// leaseNotifications leases the oldest pending notifications that are not leased already, oldest first.
+func (r *ViewRepository) leaseNotifications(ctx context.Context, limit, maxAttempts int,
+	lease time.Duration) ([]models.Notification, error) {
+	now := time.Now()
+
+	q := `WITH pending AS (
+			SELECT id FROM view_notifications
+			WHERE attempts < $2 AND (locked_until IS NULL OR locked_until <= $3)
+			ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		UPDATE view_notifications n SET locked_until = $4 FROM pending WHERE n.id = pending.id
+		RETURNING n.id, n.kind, n.resume_id, n.payload, n.attempts, n.created_at`
+
+	rows, err := r.db.Query(ctx, q, limit, maxAttempts, now, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease notifications: %w", err)
+	}
+
+	notifications, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.Notification])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan notifications: %w", err)
+	}
+
+	slices.SortFunc(notifications, func(a, b models.Notification) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return notifications, nil
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/jackc/pgx/v5"
+)
+
+// addToOutbox stores "resume viewed" events in the transaction that records the views, so an event exists
+// exactly when its view was committed. It must run after incrementRollups: the view_totals row lock
+// serializes the writers of a resume, so the outbox ids of a resume follow commit order.
+func (r *ViewRepository) addToOutbox(ctx context.Context, tx pgx.Tx, events ...models.ViewedEvent) error {
+	rows := make([][]any, 0, len(events))
+
+	for _, event := range events {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal viewed event: %w", err)
+		}
+
+		rows = append(rows, []any{event.ResumeID, payload})
+	}
+
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"view_outbox"}, []string{"resume_id", "payload"},
+		pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("failed to add events to outbox: %w", err)
+	}
+
+	return nil
+}
+
+// RelayOutbox hands the oldest pending events, at most limit, to publish and deletes them once it succeeds.
+// The batch is leased for lease in a short transaction of its own, so no transaction stays open while
+// publish runs. A transaction-level advisory lock lets a single replica lease at a time, and no batch is
+// leased while another lease is live, which keeps events in order. If another relay holds the lock or a
+// live lease, nothing is published and 0 is returned. A failed publish releases the batch for the next run
+// and returns its error; a relay that dies mid-publish leaves its batch to be published again once the
+// lease ends, so lease must outlast a publish.
+func (r *ViewRepository) RelayOutbox(ctx context.Context, limit int, lease time.Duration,
+	publish func(ctx context.Context, events []models.OutboxEvent) error) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.RelayOutbox")
+	defer span.End()
+
+	events, err := r.leaseOutbox(ctx, limit, lease)
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+
+	ids := make([]int64, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	if err = publish(ctx, events); err != nil {
+		// The lease ends on its own should the release fail as well.
+		q := `UPDATE view_outbox SET locked_until = NULL WHERE id = ANY($1)`
+		_, _ = r.db.Exec(context.WithoutCancel(ctx), q, ids)
+
+		return 0, fmt.Errorf("failed to publish outbox events: %w", err)
+	}
+
+	if _, err = r.db.Exec(ctx, `DELETE FROM view_outbox WHERE id = ANY($1)`, ids); err != nil {
+		return 0, fmt.Errorf("failed to delete delivered outbox events: %w", err)
+	}
+
+	return len(events), nil
+}
+
+// leaseOutbox leases the oldest pending events, oldest first, unless another relay holds the advisory lock
+// or a live lease.
+func (r *ViewRepository) leaseOutbox(ctx context.Context, limit int, lease time.Duration) ([]models.OutboxEvent,
+	error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var locked bool
+
+	if err = tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock(hashtext('view_outbox'))`).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("failed to lock outbox: %w", err)
+	}
+
+	if !locked {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	q := `WITH pending AS (
+			SELECT id FROM view_outbox
+			WHERE NOT EXISTS (SELECT 1 FROM view_outbox WHERE locked_until > $2)
+			ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED
+		)
+		UPDATE view_outbox o SET locked_until = $3 FROM pending WHERE o.id = pending.id
+		RETURNING o.id, o.resume_id, o.payload, o.created_at`
+
+	rows, err := tx.Query(ctx, q, limit, now, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lease outbox events: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.OutboxEvent])
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan outbox events: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	slices.SortFunc(events, func(a, b models.OutboxEvent) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events, nil
+}
@@ -0,0 +1,328 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	partitionPrefix     = "views_"
+	partitionNameLayout = "2006_01"
+	defaultPartition    = "views_default"
+)
+
+// PartitionName returns the name of the monthly partition holding t.
+func PartitionName(t time.Time) string {
+	return partitionPrefix + t.UTC().Format(partitionNameLayout)
+}
+
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// CreatePartitions creates the missing monthly partitions for every month from from to to inclusive and
+// returns the names of the created ones. Rows the default partition holds for a month being created are
+// moved into its new partition.
+func (r *ViewRepository) CreatePartitions(ctx context.Context, from, to time.Time) ([]string, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.CreatePartitions")
+	defer span.End()
+
+	partitions, err := r.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]struct{}, len(partitions))
+	for _, p := range partitions {
+		existing[p.Name] = struct{}{}
+	}
+
+	var created []string
+
+	for start := monthStart(from); !start.After(to); start = start.AddDate(0, 1, 0) {
+		name := PartitionName(start)
+		if _, ok := existing[name]; ok {
+			continue
+		}
+
+		if err = r.createPartition(ctx, name, start, start.AddDate(0, 1, 0)); err != nil {
+			return created, err
+		}
+
+		created = append(created, name)
+	}
+
+	return created, nil
+}
+
+// createPartition creates the partition for [from, to). Postgres refuses to create it while the default
+// partition holds rows of that range, so they are set aside in a temporary table and moved over once the
+// partition exists. Writers lock views before its partitions, so views is locked first, which keeps the
+// default partition from getting new rows of the range until the transaction commits.
+func (r *ViewRepository) createPartition(ctx context.Context, name string, from, to time.Time) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err = tx.Exec(ctx, `LOCK TABLE views IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+		return fmt.Errorf("failed to lock views: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, `CREATE TEMP TABLE views_moving (LIKE views) ON COMMIT DROP`); err != nil {
+		return fmt.Errorf("failed to create temporary table: %w", err)
+	}
+
+	q := fmt.Sprintf(`WITH moved AS (
+			DELETE FROM %s WHERE viewed_at >= $1 AND viewed_at < $2 RETURNING %s
+		)
+		INSERT INTO views_moving (%s) SELECT %s FROM moved`,
+		defaultPartition, viewColumns, viewColumns, viewColumns) //nolint:gosec // the names are constants
+
+	tag, err := tx.Exec(ctx, q, from, to)
+	if err != nil {
+		return fmt.Errorf("failed to move views out of the default partition: %w", err)
+	}
+
+	// DDL takes no parameters, the bounds are formatted from time values.
+	q = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s PARTITION OF views FOR VALUES FROM ('%s') TO ('%s')`,
+		pgx.Identifier{name}.Sanitize(), from.Format(time.RFC3339), to.Format(time.RFC3339))
+
+	if _, err = tx.Exec(ctx, q); err != nil {
+		return fmt.Errorf("failed to create partition %s: %w", name, err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		q = fmt.Sprintf(`INSERT INTO views (%s) SELECT %s FROM views_moving`,
+			viewColumns, viewColumns) //nolint:gosec // the names are constants
+
+		if _, err = tx.Exec(ctx, q); err != nil {
+			return fmt.Errorf("failed to move views into partition %s: %w", name, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListPartitions returns the monthly partitions attached to views, oldest first. The default partition
+// is not included.
+func (r *ViewRepository) ListPartitions(ctx context.Context) ([]models.Partition, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ListPartitions")
+	defer span.End()
+
+	q := `SELECT c.relname FROM pg_inherits i JOIN pg_class c ON c.oid = i.inhrelid
+		 WHERE i.inhparent = 'views'::regclass ORDER BY c.relname`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	names, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	partitions := make([]models.Partition, 0, len(names))
+
+	for _, name := range names {
+		from, err := time.Parse(partitionNameLayout, strings.TrimPrefix(name, partitionPrefix))
+		if err != nil {
+			continue
+		}
+
+		partitions = append(partitions, models.Partition{Name: name, From: from, To: from.AddDate(0, 1, 0)})
+	}
+
+	return partitions, nil
+}
+
+// ExportPartition streams every view of the partition to fn in viewed_at order.
+func (r *ViewRepository) ExportPartition(ctx context.Context, partition models.Partition,
+	fn func(view models.View) error) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ExportPartition")
+	defer span.End()
+
+	q := fmt.Sprintf(`SELECT %s FROM %s ORDER BY viewed_at, id`, viewColumns,
+		pgx.Identifier{partition.Name}.Sanitize())
+
+	return r.exportPartition(ctx, partition.Name, q, fn)
+}
+
+// ExportDefaultPartition streams the views of the default partition viewed before before to fn in
+// viewed_at order.
+func (r *ViewRepository) ExportDefaultPartition(ctx context.Context, before time.Time,
+	fn func(view models.View) error) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ExportDefaultPartition")
+	defer span.End()
+
+	q := fmt.Sprintf(`SELECT %s FROM %s WHERE viewed_at < $1 ORDER BY viewed_at, id`,
+		viewColumns, defaultPartition) //nolint:gosec // the names are constants
+
+	return r.exportPartition(ctx, defaultPartition, q, fn, before)
+}
+
+func (r *ViewRepository) exportPartition(ctx context.Context, name, q string, fn func(view models.View) error,
+	args ...any) (int64, error) {
+	rows, err := r.db.Query(ctx, q, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export partition %s: %w", name, err)
+	}
+	defer rows.Close()
+
+	var exported int64
+
+	for rows.Next() {
+		var view models.View
+
+		if view, err = pgx.RowToStructByName[models.View](rows); err != nil {
+			return exported, fmt.Errorf("failed to scan view: %w", err)
+		}
+
+		if err = fn(view); err != nil {
+			return exported, err
+		}
+
+		exported++
+	}
+
+	if err = rows.Err(); err != nil {
+		return exported, fmt.Errorf("failed to export partition %s: %w", name, err)
+	}
+
+	return exported, nil
+}
+
+// RemovePartition detaches the partition and subtracts its views from the rollups, so they keep
+// matching the views table. Unless detachOnly is set, the detached table is dropped as well.
+func (r *ViewRepository) RemovePartition(ctx context.Context, partition models.Partition, detachOnly bool) error {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.RemovePartition")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	table := pgx.Identifier{partition.Name}.Sanitize()
+
+	if _, err = tx.Exec(ctx, fmt.Sprintf(`ALTER TABLE views DETACH PARTITION %s`, table)); err != nil {
+		return fmt.Errorf("failed to detach partition %s: %w", partition.Name, err)
+	}
+
+	q := fmt.Sprintf(`UPDATE view_totals t SET total = t.total - p.count, updated_at = NOW()
+		 FROM (SELECT resume_id, COUNT(*) AS count FROM %s WHERE NOT suspicious GROUP BY resume_id) p
+		 WHERE t.resume_id = p.resume_id`, table)
+
+	if _, err = tx.Exec(ctx, q); err != nil {
+		return fmt.Errorf("failed to update view totals: %w", err)
+	}
+
+	q = `DELETE FROM view_daily_counts WHERE day >= $1 AND day < $2`
+
+	if _, err = tx.Exec(ctx, q, partition.From, partition.To); err != nil {
+		return fmt.Errorf("failed to delete daily counts: %w", err)
+	}
+
+	q = fmt.Sprintf(`UPDATE view_company_resumes c SET views = c.views - p.count
+		 FROM (SELECT company_id, resume_id, COUNT(*) AS count FROM %s WHERE NOT suspicious GROUP BY 1, 2) p
+		 WHERE c.company_id = p.company_id AND c.resume_id = p.resume_id`, table)
+
+	if _, err = tx.Exec(ctx, q); err != nil {
+		return fmt.Errorf("failed to update company view counts: %w", err)
+	}
+
+	if err = r.trimCompanyRollups(ctx, tx, partition.To); err != nil {
+		return err
+	}
+
+	if !detachOnly {
+		if _, err = tx.Exec(ctx, fmt.Sprintf(`DROP TABLE %s`, table)); err != nil {
+			return fmt.Errorf("failed to drop partition %s: %w", partition.Name, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ExpireDefaultPartition deletes the views of the default partition viewed before before and subtracts
+// them from the rollups. It returns how many views were deleted.
+func (r *ViewRepository) ExpireDefaultPartition(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ExpireDefaultPartition")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// view_totals rows are locked in the order writers lock them.
+	q := fmt.Sprintf(`SELECT resume_id FROM view_totals
+		WHERE resume_id IN (SELECT resume_id FROM %s WHERE viewed_at < $1)
+		ORDER BY resume_id FOR UPDATE`, defaultPartition) //nolint:gosec // the names are constants
+
+	if _, err = tx.Exec(ctx, q, before); err != nil {
+		return 0, fmt.Errorf("failed to lock view totals: %w", err)
+	}
+
+	var expired int64
+
+	q = fmt.Sprintf(`WITH expired AS (
+			DELETE FROM %s WHERE viewed_at < $1 RETURNING resume_id, company_id, viewed_at, suspicious
+		), daily AS (
+			UPDATE view_daily_counts d SET count = d.count - e.count
+			FROM (SELECT resume_id, (viewed_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS count
+				FROM expired WHERE NOT suspicious GROUP BY 1, 2) e
+			WHERE d.resume_id = e.resume_id AND d.day = e.day
+		), totals AS (
+			UPDATE view_totals t SET total = t.total - e.count, updated_at = NOW()
+			FROM (SELECT resume_id, COUNT(*) AS count FROM expired WHERE NOT suspicious GROUP BY resume_id) e
+			WHERE t.resume_id = e.resume_id
+		), companies AS (
+			UPDATE view_company_resumes c SET views = c.views - e.count
+			FROM (SELECT company_id, resume_id, COUNT(*) AS count FROM expired WHERE NOT suspicious GROUP BY 1, 2) e
+			WHERE c.company_id = e.company_id AND c.resume_id = e.resume_id
+		)
+		SELECT COUNT(*) FROM expired`, defaultPartition) //nolint:gosec // the names are constants
+
+	if err = tx.QueryRow(ctx, q, before).Scan(&expired); err != nil {
+		return 0, fmt.Errorf("failed to expire default partition: %w", err)
+	}
+
+	if err = r.trimCompanyRollups(ctx, tx, before); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return expired, nil
+}
@@ -1,11 +1,15 @@
 package repositories
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
 	customerrors "github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
 	"github.com/Verce11o/resume-view/resume-view/internal/lib/pagination"
 	"github.com/Verce11o/resume-view/resume-view/internal/models"
@@ -15,36 +19,416 @@ import (
 	"go.opentelemetry.io/otel/trace"
 )
 
-const paginationLimit = 20
+// viewColumns are the columns of models.View, in the order of its fields.
+const viewColumns = "id, resume_id, company_id, viewed_at, source, viewer_user_id, user_agent, referrer, metadata, " +
+	"suspicious"
 
 type ViewRepository struct {
-	db     *pgxpool.Pool
-	tracer trace.Tracer
+	db             *pgxpool.Pool
+	tracer         trace.Tracer
+	idempotencyTTL time.Duration
 }
 
-func NewViewRepository(db *pgxpool.Pool, tracer trace.Tracer) *ViewRepository {
-	return &ViewRepository{db: db, tracer: tracer}
+func NewViewRepository(db *pgxpool.Pool, tracer trace.Tracer, idempotencyTTL time.Duration) *ViewRepository {
+	return &ViewRepository{db: db, tracer: tracer, idempotencyTTL: idempotencyTTL}
 }
 
-func (r *ViewRepository) CreateView(ctx context.Context, resumeID, companyID string) (uuid.UUID, error) {
+// CreateView claims the idempotency key and the dedup window before inserting the view, updating
+// the rollups, adding a "resume viewed" event to the outbox and tracking owner notifications. Concurrent
+// requests for the same key or (resume_id, company_id) pair block on the row lock until this transaction
+// finishes and then observe its result. Suspicious views are only inserted.
+func (r *ViewRepository) CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error) {
 	ctx, span := r.tracer.Start(ctx, "viewRepository.CreateView")
 	defer span.End()
 
-	var id uuid.UUID
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return models.CreatedView{}, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	view, err := r.claimView(ctx, tx, req)
+	if err != nil {
+		return models.CreatedView{}, err
+	}
+
+	if !view.Counted() {
+		if err = tx.Commit(ctx); err != nil {
+			return models.CreatedView{}, fmt.Errorf("could not commit transaction: %w", err)
+		}
+
+		return view, nil
+	}
+
+	var viewedAt time.Time
+
+	q := `INSERT INTO views (id, resume_id, company_id, source, viewer_user_id, user_agent, referrer, metadata,
+		 suspicious) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING viewed_at`
+
+	err = tx.QueryRow(ctx, q, view.ID, req.ResumeID, req.CompanyID, req.Viewer.Source, req.Viewer.ViewerUserID,
+		req.Viewer.UserAgent, req.Viewer.Referrer, viewMetadata(req.Viewer), req.Suspicious).Scan(&viewedAt)
+	if err != nil {
+		return models.CreatedView{}, fmt.Errorf("failed create view: %w", err)
+	}
+
+	if !req.Suspicious {
+		if err = r.countView(ctx, tx, viewedEvent(view.ID, req, viewedAt)); err != nil {
+			return models.CreatedView{}, err
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return models.CreatedView{}, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	view.ViewedAt = viewedAt
+
+	return view, nil
+}
+
+// CreateViews records a batch in one transaction. Keys and dedup windows are claimed row by row, then
+// every view that is counted is written with a single COPY. Like in CreateView, suspicious views are only
+// inserted. Claims are taken in (resume_id, company_id) order and rollups in resume_id order rather than in
+// request order, so concurrent batches lock rows in the same order as each other and as EraseViews and
+// cannot deadlock. Results are aligned with reqs.
+func (r *ViewRepository) CreateViews(ctx context.Context, reqs []domain.CreateView) ([]models.CreatedView, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.CreateViews")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	var viewedAt time.Time
+
+	if err = tx.QueryRow(ctx, `SELECT NOW()`).Scan(&viewedAt); err != nil {
+		return nil, fmt.Errorf("failed to get transaction time: %w", err)
+	}
+
+	results := make([]models.CreatedView, len(reqs))
+	rows := make([][]any, 0, len(reqs))
+	events := make([]models.ViewedEvent, 0, len(reqs))
+	counted := make(map[string]int)
+
+	for _, i := range claimOrder(reqs) {
+		req := reqs[i]
+
+		results[i], err = r.claimView(ctx, tx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !results[i].Counted() {
+			continue
+		}
+
+		results[i].ViewedAt = viewedAt
+		rows = append(rows, []any{
+			results[i].ID, req.ResumeID, req.CompanyID, viewedAt, req.Viewer.Source, req.Viewer.ViewerUserID,
+			req.Viewer.UserAgent, req.Viewer.Referrer, viewMetadata(req.Viewer), req.Suspicious,
+		})
+
+		if !req.Suspicious {
+			events = append(events, viewedEvent(results[i].ID, req, viewedAt))
+			counted[req.ResumeID]++
+		}
+	}
+
+	columns := []string{
+		"id", "resume_id", "company_id", "viewed_at", "source", "viewer_user_id", "user_agent", "referrer", "metadata",
+		"suspicious",
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"views"}, columns, pgx.CopyFromRows(rows))
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy views: %w", err)
+	}
+
+	resumeIDs := make([]string, 0, len(counted))
+	for resumeID := range counted {
+		resumeIDs = append(resumeIDs, resumeID)
+	}
+
+	slices.Sort(resumeIDs)
+
+	for _, resumeID := range resumeIDs {
+		if err = r.incrementRollups(ctx, tx, resumeID, viewedAt, counted[resumeID]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = r.incrementCompanyRollups(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err = r.addToOutbox(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err = r.trackNotifications(ctx, tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return results, nil
+}
+
+// claimOrder returns the indexes of reqs sorted by resume and company. Requests for the same pair keep their
+// order, so the first of them still claims the dedup window.
+func claimOrder(reqs []domain.CreateView) []int {
+	order := make([]int, len(reqs))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Or(
+			cmp.Compare(reqs[a].ResumeID, reqs[b].ResumeID),
+			cmp.Compare(reqs[a].CompanyID, reqs[b].CompanyID),
+		)
+	})
+
+	return order
+}
+
+// countView updates the rollups of a counted view, adds its event to the outbox and tracks the owner
+// notifications.
+func (r *ViewRepository) countView(ctx context.Context, tx pgx.Tx, event models.ViewedEvent) error {
+	if err := r.incrementRollups(ctx, tx, event.ResumeID, event.ViewedAt, 1); err != nil {
+		return err
+	}
+
+	if err := r.incrementCompanyRollups(ctx, tx, event); err != nil {
+		return err
+	}
+
+	if err := r.addToOutbox(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return r.trackNotifications(ctx, tx, event)
+}
+
+// viewMetadata never returns nil, which would be written as NULL into the NOT NULL metadata column.
+func viewMetadata(viewer domain.ViewerContext) map[string]string {
+	if viewer.Metadata == nil {
+		return map[string]string{}
+	}
+
+	return viewer.Metadata
+}
+
+func viewedEvent(viewID uuid.UUID, req domain.CreateView, viewedAt time.Time) models.ViewedEvent {
+	return models.ViewedEvent{
+		Version:      models.ViewedEventVersion,
+		ViewID:       viewID,
+		ResumeID:     req.ResumeID,
+		CompanyID:    req.CompanyID,
+		ViewedAt:     viewedAt,
+		Source:       string(req.Viewer.Source),
+		ViewerUserID: req.Viewer.ViewerUserID,
+		UserAgent:    req.Viewer.UserAgent,
+		Referrer:     req.Viewer.Referrer,
+		Metadata:     req.Viewer.Metadata,
+	}
+}
+
+// claimView runs the idempotency and dedup claims of CreateView without inserting the view. Suspicious
+// views do not claim the dedup window, so a clean view right after one is still counted.
+func (r *ViewRepository) claimView(ctx context.Context, tx pgx.Tx, req domain.CreateView) (models.CreatedView, error) {
+	viewID := uuid.New()
+
+	if req.IdempotencyKey != "" {
+		replayedID, replayed, err := r.claimIdempotencyKey(ctx, tx, req, viewID)
+		if err != nil {
+			return models.CreatedView{}, err
+		}
+
+		if replayed {
+			return models.CreatedView{ID: replayedID, Replayed: true}, nil
+		}
+	}
+
+	if req.DedupWindow > 0 && !req.Suspicious {
+		countedID, collapsed, err := r.claimDedupWindow(ctx, tx, req, viewID)
+		if err != nil {
+			return models.CreatedView{}, err
+		}
+
+		if collapsed {
+			if err = r.bindIdempotencyKey(ctx, tx, req, countedID); err != nil {
+				return models.CreatedView{}, err
+			}
+
+			return models.CreatedView{ID: countedID, Collapsed: true}, nil
+		}
+	}
+
+	return models.CreatedView{ID: viewID}, nil
+}
+
+// claimIdempotencyKey binds the key to viewID, or returns the view the key is already bound to.
+func (r *ViewRepository) claimIdempotencyKey(ctx context.Context, tx pgx.Tx, req domain.CreateView,
+	viewID uuid.UUID) (uuid.UUID, bool, error) {
+	q := `DELETE FROM view_idempotency_keys WHERE company_id = $1 AND idempotency_key = $2 AND created_at < $3`
+
+	if _, err := tx.Exec(ctx, q, req.CompanyID, req.IdempotencyKey, time.Now().Add(-r.idempotencyTTL)); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to expire idempotency key: %w", err)
+	}
+
+	q = `INSERT INTO view_idempotency_keys (company_id, idempotency_key, view_id) VALUES ($1, $2, $3)
+		 ON CONFLICT (company_id, idempotency_key) DO NOTHING`
+
+	tag, err := tx.Exec(ctx, q, req.CompanyID, req.IdempotencyKey, viewID)
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	if tag.RowsAffected() > 0 {
+		return uuid.Nil, false, nil
+	}
+
+	var replayedID uuid.UUID
+
+	q = `SELECT view_id FROM view_idempotency_keys WHERE company_id = $1 AND idempotency_key = $2`
+
+	if err = tx.QueryRow(ctx, q, req.CompanyID, req.IdempotencyKey).Scan(&replayedID); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to get replayed view: %w", err)
+	}
+
+	return replayedID, true, nil
+}
+
+// claimDedupWindow marks viewID as the counted view of the pair unless another view was counted
+// within the window, in which case that view is returned.
+func (r *ViewRepository) claimDedupWindow(ctx context.Context, tx pgx.Tx, req domain.CreateView,
+	viewID uuid.UUID) (uuid.UUID, bool, error) {
+	q := `INSERT INTO view_dedup (resume_id, company_id, view_id) VALUES ($1, $2, $3)
+		 ON CONFLICT (resume_id, company_id) DO UPDATE SET view_id = EXCLUDED.view_id, counted_at = NOW()
+		 WHERE view_dedup.counted_at <= $4`
+
+	tag, err := tx.Exec(ctx, q, req.ResumeID, req.CompanyID, viewID, time.Now().Add(-req.DedupWindow))
+	if err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to claim dedup window: %w", err)
+	}
 
-	q := `INSERT INTO views (resume_id, company_id) VALUES ($1, $2) RETURNING id`
+	if tag.RowsAffected() > 0 {
+		return uuid.Nil, false, nil
+	}
+
+	var countedID uuid.UUID
+
+	q = `SELECT view_id FROM view_dedup WHERE resume_id = $1 AND company_id = $2`
+
+	if err = tx.QueryRow(ctx, q, req.ResumeID, req.CompanyID).Scan(&countedID); err != nil {
+		return uuid.Nil, false, fmt.Errorf("failed to get counted view: %w", err)
+	}
+
+	return countedID, true, nil
+}
+
+// bindIdempotencyKey points a freshly claimed idempotency key at the view the request collapsed into.
+func (r *ViewRepository) bindIdempotencyKey(ctx context.Context, tx pgx.Tx, req domain.CreateView,
+	countedID uuid.UUID) error {
+	if req.IdempotencyKey == "" {
+		return nil
+	}
+
+	q := `UPDATE view_idempotency_keys SET view_id = $3 WHERE company_id = $1 AND idempotency_key = $2`
+
+	if _, err := tx.Exec(ctx, q, req.CompanyID, req.IdempotencyKey, countedID); err != nil {
+		return fmt.Errorf("failed to bind idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ViewRepository) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.DeleteExpiredIdempotencyKeys")
+	defer span.End()
+
+	q := `DELETE FROM view_idempotency_keys WHERE created_at < $1`
+
+	tag, err := r.db.Exec(ctx, q, time.Now().Add(-r.idempotencyTTL))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// ReplayedViews returns the views already recorded under the idempotency keys of reqs, by index in reqs.
+// Requests without a key and keys past their TTL are left out. It only reads, so a request missing from
+// the result may still be replayed by CreateView when the key was claimed meanwhile.
+func (r *ViewRepository) ReplayedViews(ctx context.Context, reqs []domain.CreateView) (map[int]uuid.UUID, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ReplayedViews")
+	defer span.End()
+
+	positions := make([]int, 0, len(reqs))
+	companyIDs := make([]string, 0, len(reqs))
+	keys := make([]string, 0, len(reqs))
+
+	for i, req := range reqs {
+		if req.IdempotencyKey == "" {
+			continue
+		}
+
+		positions = append(positions, i)
+		companyIDs = append(companyIDs, req.CompanyID)
+		keys = append(keys, req.IdempotencyKey)
+	}
+
+	replayed := make(map[int]uuid.UUID)
 
-	err := r.db.QueryRow(ctx, q, resumeID, companyID).
-		Scan(&id)
+	if len(keys) == 0 {
+		return replayed, nil
+	}
+
+	q := `SELECT r.position, k.view_id
+		 FROM unnest($1::INT[], $2::UUID[], $3::TEXT[]) AS r (position, company_id, idempotency_key)
+		 JOIN view_idempotency_keys k ON k.company_id = r.company_id AND k.idempotency_key = r.idempotency_key
+		 WHERE k.created_at >= $4`
 
+	rows, err := r.db.Query(ctx, q, positions, companyIDs, keys, time.Now().Add(-r.idempotencyTTL))
 	if err != nil {
-		return uuid.Nil, fmt.Errorf("failed create view: %w", err)
+		return nil, fmt.Errorf("failed to look up idempotency keys: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			position int
+			viewID   uuid.UUID
+		)
+
+		if err = rows.Scan(&position, &viewID); err != nil {
+			return nil, fmt.Errorf("failed to scan replayed view: %w", err)
+		}
+
+		replayed[position] = viewID
 	}
 
-	return id, nil
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to look up idempotency keys: %w", err)
+	}
+
+	return replayed, nil
 }
 
-func (r *ViewRepository) ListResumeView(ctx context.Context, cursor, resumeID string) (models.ViewList, error) {
+// ListResumeView returns a page of views in keyset order. The page is read one row past PageSize to
+// tell whether a next page exists, so the last page comes without a cursor.
+func (r *ViewRepository) ListResumeView(ctx context.Context, req domain.ListViews) (models.ViewList, error) {
 	ctx, span := r.tracer.Start(ctx, "viewRepository.ListResumeView")
 	defer span.End()
 
@@ -54,8 +438,8 @@ func (r *ViewRepository) ListResumeView(ctx context.Context, cursor, resumeID st
 		err      error
 	)
 
-	if cursor != "" {
-		viewedAt, viewID, err = pagination.DecodeCursor(cursor)
+	if req.Cursor != "" {
+		viewedAt, viewID, err = pagination.DecodeCursor(req.Cursor, req.Sort)
 		if err != nil {
 			return models.ViewList{}, customerrors.ErrInvalidCursor
 		}
@@ -63,26 +447,96 @@ func (r *ViewRepository) ListResumeView(ctx context.Context, cursor, resumeID st
 
 	var total int
 
-	q := "SELECT COUNT(*) FROM views WHERE resume_id = $1"
+	q := "SELECT total FROM view_totals WHERE resume_id = $1"
+
+	err = r.db.QueryRow(ctx, q, req.ResumeID).Scan(&total)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return models.ViewList{}, fmt.Errorf("failed to count views: %w", err)
+	}
 
-	err = r.db.QueryRow(ctx, q, resumeID).Scan(&total)
-	if err != nil && errors.Is(err, pgx.ErrNoRows) || total == 0 {
+	// view_totals leaves out suspicious views, so a resume viewed only by suspicious companies has none.
+	if total == 0 && !req.IncludeSuspicious {
 		return models.ViewList{}, customerrors.ErrNotFound
 	}
 
-	if err != nil {
-		return models.ViewList{}, fmt.Errorf("failed to count views: %w", err)
+	conditions := []string{"resume_id = $1"}
+	args := []any{req.ResumeID}
+
+	if !req.IncludeSuspicious {
+		conditions = append(conditions, "NOT suspicious")
 	}
 
-	q = `SELECT id, resume_id, company_id, viewed_at FROM views WHERE (viewed_at, id) > ($1, $2) 
-		 AND resume_id = $3 ORDER BY viewed_at DESC, id LIMIT $4`
+	unfiltered := len(conditions)
 
-	rows, err := r.db.Query(ctx, q, viewedAt, viewID, resumeID, paginationLimit)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
 
-	if err != nil && errors.Is(err, pgx.ErrNoRows) || total == 0 {
-		return models.ViewList{}, customerrors.ErrNotFound
+	if !req.From.IsZero() {
+		addCondition("viewed_at >= $%d", req.From)
+	}
+
+	if !req.To.IsZero() {
+		addCondition("viewed_at < $%d", req.To)
+	}
+
+	if req.CompanyID != "" {
+		addCondition("company_id = $%d", req.CompanyID)
+	}
+
+	if req.Viewer.Source != "" {
+		addCondition("source = $%d", req.Viewer.Source)
 	}
 
+	if req.Viewer.ViewerUserID != "" {
+		addCondition("viewer_user_id = $%d", req.Viewer.ViewerUserID)
+	}
+
+	if req.Viewer.UserAgent != "" {
+		addCondition("user_agent = $%d", req.Viewer.UserAgent)
+	}
+
+	if req.Viewer.Referrer != "" {
+		addCondition("referrer = $%d", req.Viewer.Referrer)
+	}
+
+	if len(req.Viewer.Metadata) > 0 {
+		addCondition("metadata @> $%d", req.Viewer.Metadata)
+	}
+
+	// Only placeholders and fixed keywords are formatted into the queries below, values go through args.
+	filter := strings.Join(conditions, " AND ")
+
+	// view_totals only knows the unfiltered total of the views that are not suspicious.
+	if len(conditions) > unfiltered || req.IncludeSuspicious {
+		q = "SELECT COUNT(*) FROM views WHERE " + filter //nolint:gosec
+
+		if err = r.db.QueryRow(ctx, q, args...).Scan(&total); err != nil {
+			return models.ViewList{}, fmt.Errorf("failed to count views: %w", err)
+		}
+
+		if total == 0 && len(conditions) == unfiltered {
+			return models.ViewList{}, customerrors.ErrNotFound
+		}
+	}
+
+	comparison, direction := ">", "ASC"
+	if req.Sort == domain.SortNewestFirst {
+		comparison, direction = "<", "DESC"
+	}
+
+	if req.Cursor != "" {
+		args = append(args, viewedAt, viewID)
+		filter += fmt.Sprintf(" AND (viewed_at, id) %s ($%d, $%d)", comparison, len(args)-1, len(args))
+	}
+
+	args = append(args, req.PageSize+1)
+
+	q = fmt.Sprintf(`SELECT %s FROM views WHERE %s
+		 ORDER BY viewed_at %s, id %s LIMIT $%d`, viewColumns, filter, direction, direction, len(args)) //nolint:gosec
+
+	rows, err := r.db.Query(ctx, q, args...)
 	if err != nil {
 		return models.ViewList{}, fmt.Errorf("failed to list views: %w", err)
 	}
@@ -94,8 +548,10 @@ func (r *ViewRepository) ListResumeView(ctx context.Context, cursor, resumeID st
 	}
 
 	var nextCursor string
-	if len(views) > 0 {
-		nextCursor = pagination.EncodeCursor(views[len(views)-1].ViewedAt, views[len(views)-1].ID.String())
+	if len(views) > req.PageSize {
+		views = views[:req.PageSize]
+		last := views[len(views)-1]
+		nextCursor = pagination.EncodeCursor(last.ViewedAt, last.ID.String(), req.Sort)
 	}
 
 	return models.ViewList{
@@ -104,3 +560,101 @@ func (r *ViewRepository) ListResumeView(ctx context.Context, cursor, resumeID st
 		Total:  total,
 	}, nil
 }
+
+// ListCompanyViews returns the resumes a company has viewed, most recently viewed first, leaving out
+// suspicious views. Pages are read from the view_company_resumes rollup and keyed on (last_viewed_at,
+// resume_id), which view_company_resumes_company_id_last_viewed_at_idx serves per company.
+func (r *ViewRepository) ListCompanyViews(ctx context.Context, req domain.ListCompanyViews) (models.CompanyViewList,
+	error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.ListCompanyViews")
+	defer span.End()
+
+	var (
+		lastViewedAt time.Time
+		resumeID     string
+		err          error
+	)
+
+	if req.Cursor != "" {
+		lastViewedAt, resumeID, err = pagination.DecodeResumeCursor(req.Cursor)
+		if err != nil {
+			return models.CompanyViewList{}, customerrors.ErrInvalidCursor
+		}
+	}
+
+	q := `SELECT resume_id, first_viewed_at, last_viewed_at, views AS count FROM view_company_resumes
+		 WHERE company_id = $1 AND ($3::text = '' OR (last_viewed_at, resume_id) < ($2, $3))
+		 ORDER BY last_viewed_at DESC, resume_id DESC LIMIT $4`
+
+	rows, err := r.db.Query(ctx, q, req.CompanyID, lastViewedAt, resumeID, req.PageSize+1)
+	if err != nil {
+		return models.CompanyViewList{}, fmt.Errorf("failed to list company views: %w", err)
+	}
+
+	resumes, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.ViewedResume])
+	if err != nil {
+		return models.CompanyViewList{}, fmt.Errorf("failed to list company views: %w", err)
+	}
+
+	var nextCursor string
+	if len(resumes) > req.PageSize {
+		resumes = resumes[:req.PageSize]
+		last := resumes[len(resumes)-1]
+		nextCursor = pagination.EncodeResumeCursor(last.LastViewedAt, last.ResumeID)
+	}
+
+	return models.CompanyViewList{
+		Cursor:  nextCursor,
+		Resumes: resumes,
+	}, nil
+}
+
+func (r *ViewRepository) GetResumeViewStats(ctx context.Context, req domain.ViewStats) (models.ViewStats, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.GetResumeViewStats")
+	defer span.End()
+
+	var stats models.ViewStats
+
+	q := `SELECT COUNT(*), COUNT(DISTINCT company_id) FROM views
+		 WHERE resume_id = $1 AND viewed_at >= $2 AND viewed_at < $3 AND ($4 OR NOT suspicious)`
+
+	err := r.db.QueryRow(ctx, q, req.ResumeID, req.From, req.To, req.IncludeSuspicious).
+		Scan(&stats.Total, &stats.UniqueCompanies)
+	if err != nil {
+		return models.ViewStats{}, fmt.Errorf("failed to count views: %w", err)
+	}
+
+	// generate_series yields empty buckets too, so the series has no gaps.
+	q = `SELECT b.bucket, COUNT(v.id) AS count
+		 FROM generate_series(date_trunc($1, $3::timestamptz), $4::timestamptz, ('1 ' || $1)::interval) AS b(bucket)
+		 LEFT JOIN views v ON v.resume_id = $2 AND v.viewed_at >= $3 AND v.viewed_at < $4
+		 AND date_trunc($1, v.viewed_at) = b.bucket AND ($5 OR NOT v.suspicious)
+		 WHERE b.bucket < $4
+		 GROUP BY b.bucket ORDER BY b.bucket`
+
+	rows, err := r.db.Query(ctx, q, string(req.Interval), req.ResumeID, req.From, req.To, req.IncludeSuspicious)
+	if err != nil {
+		return models.ViewStats{}, fmt.Errorf("failed to bucket views: %w", err)
+	}
+
+	stats.Buckets, err = pgx.CollectRows(rows, pgx.RowToStructByName[models.ViewBucket])
+	if err != nil {
+		return models.ViewStats{}, fmt.Errorf("failed to bucket views: %w", err)
+	}
+
+	q = `SELECT company_id, COUNT(*) AS count, MAX(viewed_at) AS last_viewed_at FROM views
+		 WHERE resume_id = $1 AND viewed_at >= $2 AND viewed_at < $3 AND ($5 OR NOT suspicious)
+		 GROUP BY company_id ORDER BY count DESC, last_viewed_at DESC LIMIT $4`
+
+	rows, err = r.db.Query(ctx, q, req.ResumeID, req.From, req.To, req.TopCompanies, req.IncludeSuspicious)
+	if err != nil {
+		return models.ViewStats{}, fmt.Errorf("failed to get top companies: %w", err)
+	}
+
+	stats.TopCompanies, err = pgx.CollectRows(rows, pgx.RowToStructByName[models.CompanyViews])
+	if err != nil {
+		return models.ViewStats{}, fmt.Errorf("failed to get top companies: %w", err)
+	}
+
+	return stats, nil
+}
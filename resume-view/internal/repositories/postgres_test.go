@@ -0,0 +1,1153 @@
+//go:build integration
+
+package repositories
+
+import (
+	"context"
+	"encoding/hex"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	_ "github.com/flashlabs/rootpath"
+	"github.com/goccy/go-json"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type ViewRepositorySuite struct {
+	suite.Suite
+	ctx       context.Context
+	db        *pgxpool.Pool
+	repo      *ViewRepository
+	container *postgres.PostgresContainer
+}
+
+func (v *ViewRepositorySuite) SetupSuite() {
+	v.ctx = context.Background()
+
+	container, connURI := SetupPostgresContainer(v.ctx, v.T())
+	dbPool, err := pgxpool.New(v.ctx, connURI)
+	require.NoError(v.T(), err)
+
+	v.db = dbPool
+	v.repo = NewViewRepository(dbPool, noop.NewTracerProvider().Tracer("test"), time.Hour)
+	v.container = container
+}
+
+func (v *ViewRepositorySuite) TearDownSuite() {
+	err := v.container.Terminate(v.ctx)
+	if err != nil {
+		v.T().Fatalf("could not terminate postgres container: %v", err.Error())
+	}
+}
+
+func (v *ViewRepositorySuite) insertView(resumeID string, companyID uuid.UUID, viewedAt time.Time) {
+	q := `INSERT INTO views (resume_id, company_id, viewed_at) VALUES ($1, $2, $3)`
+
+	_, err := v.db.Exec(v.ctx, q, resumeID, companyID, viewedAt)
+	require.NoError(v.T(), err)
+}
+
+// newResumeID returns a random id shaped like the resume ObjectIDs the service accepts.
+func newResumeID() string {
+	id := uuid.New()
+
+	return hex.EncodeToString(id[:])[:24]
+}
+
+func (v *ViewRepositorySuite) TestGetResumeViewStats() {
+	resumeID := newResumeID()
+	firstCompany, secondCompany, thirdCompany := uuid.New(), uuid.New(), uuid.New()
+	day := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	v.insertView(resumeID, firstCompany, day.Add(time.Hour))
+	v.insertView(resumeID, firstCompany, day.Add(2*time.Hour))
+	v.insertView(resumeID, firstCompany, day.Add(26*time.Hour))
+	v.insertView(resumeID, secondCompany, day.Add(25*time.Hour))
+	v.insertView(resumeID, thirdCompany, day.Add(72*time.Hour))
+	v.insertView(resumeID, thirdCompany, day.Add(-time.Hour))
+	v.insertView(newResumeID(), firstCompany, day.Add(time.Hour))
+
+	tests := []struct {
+		name            string
+		request         domain.ViewStats
+		buckets         []int
+		total           int
+		uniqueCompanies int
+		topCompanies    []uuid.UUID
+	}{
+		{
+			name: "Daily buckets",
+			request: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         day,
+				To:           day.Add(4 * 24 * time.Hour),
+				Interval:     domain.StatsIntervalDay,
+				TopCompanies: 10,
+			},
+			buckets:         []int{2, 2, 0, 1},
+			total:           5,
+			uniqueCompanies: 3,
+			topCompanies:    []uuid.UUID{firstCompany, thirdCompany, secondCompany},
+		},
+		{
+			name: "Hourly buckets with top company limit",
+			request: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         day,
+				To:           day.Add(3 * time.Hour),
+				Interval:     domain.StatsIntervalHour,
+				TopCompanies: 1,
+			},
+			buckets:         []int{0, 1, 1},
+			total:           2,
+			uniqueCompanies: 1,
+			topCompanies:    []uuid.UUID{firstCompany},
+		},
+		{
+			name: "Weekly bucket",
+			request: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         day,
+				To:           day.Add(7 * 24 * time.Hour),
+				Interval:     domain.StatsIntervalWeek,
+				TopCompanies: 10,
+			},
+			buckets:         []int{5},
+			total:           5,
+			uniqueCompanies: 3,
+			topCompanies:    []uuid.UUID{firstCompany, thirdCompany, secondCompany},
+		},
+		{
+			name: "Unknown resume",
+			request: domain.ViewStats{
+				ResumeID:     newResumeID(),
+				From:         day,
+				To:           day.Add(2 * 24 * time.Hour),
+				Interval:     domain.StatsIntervalDay,
+				TopCompanies: 10,
+			},
+			buckets: []int{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		v.Run(tt.name, func() {
+			stats, err := v.repo.GetResumeViewStats(v.ctx, tt.request)
+			require.NoError(v.T(), err)
+
+			counts := make([]int, 0, len(stats.Buckets))
+			for _, bucket := range stats.Buckets {
+				counts = append(counts, bucket.Count)
+			}
+
+			companies := make([]uuid.UUID, 0, len(stats.TopCompanies))
+			for _, company := range stats.TopCompanies {
+				companies = append(companies, company.CompanyID)
+			}
+
+			assert.Equal(v.T(), tt.buckets, counts)
+			assert.Equal(v.T(), tt.total, stats.Total)
+			assert.Equal(v.T(), tt.uniqueCompanies, stats.UniqueCompanies)
+			assert.Equal(v.T(), tt.topCompanies, companies)
+		})
+	}
+}
+
+func (v *ViewRepositorySuite) TestRollups() {
+	_, err := v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	resumeID := newResumeID()
+
+	for i := 0; i < 3; i++ {
+		_, err = v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+		require.NoError(v.T(), err)
+	}
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 3, list.Total)
+
+	mismatches, err := v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+
+	_, err = v.db.Exec(v.ctx, `UPDATE view_totals SET total = 1 WHERE resume_id = $1`, resumeID)
+	require.NoError(v.T(), err)
+
+	mismatches, err = v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	require.Len(v.T(), mismatches, 1)
+	assert.Equal(v.T(), resumeID, mismatches[0].ResumeID)
+	assert.Nil(v.T(), mismatches[0].Day)
+	assert.Equal(v.T(), 3, mismatches[0].Raw)
+	assert.Equal(v.T(), 1, mismatches[0].Rollup)
+
+	_, err = v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	mismatches, err = v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+}
+
+func (v *ViewRepositorySuite) TestReplayedViews() {
+	resumeID := newResumeID()
+	companyID := uuid.NewString()
+
+	view, err := v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "replayed-1",
+	})
+	require.NoError(v.T(), err)
+
+	replayed, err := v.repo.ReplayedViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: companyID},
+		{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "replayed-1"},
+		{ResumeID: resumeID, CompanyID: uuid.NewString(), IdempotencyKey: "replayed-1"},
+		{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "replayed-2"},
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), map[int]uuid.UUID{1: view.ID}, replayed)
+}
+
+func (v *ViewRepositorySuite) TestCreateViews() {
+	resumeID := newResumeID()
+	companyID := uuid.NewString()
+
+	results, err := v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "batch-1"},
+		{ResumeID: resumeID, CompanyID: uuid.NewString()},
+		{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "batch-1"},
+		{ResumeID: resumeID, CompanyID: companyID, DedupWindow: time.Hour},
+	})
+	require.NoError(v.T(), err)
+	require.Len(v.T(), results, 4)
+
+	assert.True(v.T(), results[0].Counted())
+	assert.True(v.T(), results[1].Counted())
+	assert.True(v.T(), results[2].Replayed)
+	assert.Equal(v.T(), results[0].ID, results[2].ID)
+	assert.True(v.T(), results[3].Counted())
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 3, list.Total)
+	assert.Len(v.T(), list.Views, 3)
+}
+
+func (v *ViewRepositorySuite) TestCreateViewsLockOrder() {
+	first, second := newResumeID(), newResumeID()
+	companyID := uuid.NewString()
+
+	forward := []domain.CreateView{
+		{ResumeID: first, CompanyID: companyID, DedupWindow: time.Nanosecond},
+		{ResumeID: second, CompanyID: companyID, DedupWindow: time.Nanosecond},
+	}
+	backward := []domain.CreateView{forward[1], forward[0]}
+
+	const rounds = 20
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, 2*rounds)
+
+	for range rounds {
+		for _, batch := range [][]domain.CreateView{forward, backward} {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				_, err := v.repo.CreateViews(v.ctx, batch)
+				errs <- err
+			}()
+		}
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(v.T(), err)
+	}
+}
+
+func (v *ViewRepositorySuite) TestViewerContext() {
+	resumeID := newResumeID()
+	viewer := domain.ViewerContext{
+		Source:       domain.ViewSourceSearch,
+		ViewerUserID: "user",
+		UserAgent:    "Mozilla/5.0",
+		Referrer:     "https://example.com/search?q=go",
+		Metadata:     map[string]string{"campaign": "spring", "slot": "2"},
+	}
+
+	created, err := v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID:  resumeID,
+		CompanyID: uuid.NewString(),
+		Viewer:    viewer,
+	})
+	require.NoError(v.T(), err)
+
+	results, err := v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: uuid.NewString(), Viewer: domain.ViewerContext{Source: domain.ViewSourceAPI}},
+		{ResumeID: resumeID, CompanyID: uuid.NewString()},
+	})
+	require.NoError(v.T(), err)
+	require.Len(v.T(), results, 2)
+
+	// Rows written before the viewer context existed read back with the column defaults.
+	v.insertView(resumeID, uuid.New(), time.Now())
+
+	_, err = v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortOldestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	require.Len(v.T(), list.Views, 4)
+
+	assert.Equal(v.T(), created.ID, list.Views[0].ID)
+	assert.Equal(v.T(), viewer.Source, list.Views[0].Source)
+	assert.Equal(v.T(), viewer.ViewerUserID, list.Views[0].ViewerUserID)
+	assert.Equal(v.T(), viewer.UserAgent, list.Views[0].UserAgent)
+	assert.Equal(v.T(), viewer.Referrer, list.Views[0].Referrer)
+	assert.Equal(v.T(), viewer.Metadata, list.Views[0].Metadata)
+	assert.Empty(v.T(), list.Views[3].Source)
+	assert.Empty(v.T(), list.Views[3].Metadata)
+
+	tests := []struct {
+		name   string
+		viewer domain.ViewerContext
+		views  int
+	}{
+		{name: "Source", viewer: domain.ViewerContext{Source: domain.ViewSourceAPI}, views: 1},
+		{name: "Viewer user id", viewer: domain.ViewerContext{ViewerUserID: "user"}, views: 1},
+		{name: "User agent", viewer: domain.ViewerContext{UserAgent: "Mozilla/5.0"}, views: 1},
+		{name: "Referrer", viewer: domain.ViewerContext{Referrer: viewer.Referrer}, views: 1},
+		{name: "Metadata subset", viewer: domain.ViewerContext{Metadata: map[string]string{"slot": "2"}}, views: 1},
+		{name: "Metadata mismatch", viewer: domain.ViewerContext{Metadata: map[string]string{"slot": "3"}}, views: 0},
+	}
+
+	for _, tt := range tests {
+		v.Run(tt.name, func() {
+			filtered, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+				ResumeID: resumeID,
+				Viewer:   tt.viewer,
+				Sort:     domain.SortNewestFirst,
+				PageSize: 20,
+			})
+			require.NoError(v.T(), err)
+
+			assert.Len(v.T(), filtered.Views, tt.views)
+			assert.Equal(v.T(), tt.views, filtered.Total)
+		})
+	}
+}
+
+func (v *ViewRepositorySuite) TestListResumeView() {
+	resumeID := newResumeID()
+	companyID := uuid.New()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	// Pairs of views share a timestamp so pages have to break ties on id.
+	for i := 0; i < 10; i++ {
+		viewCompanyID := uuid.New()
+		if i%3 == 0 {
+			viewCompanyID = companyID
+		}
+
+		v.insertView(resumeID, viewCompanyID, start.Add(time.Duration(i/2)*time.Hour))
+	}
+
+	_, err := v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	readAll := func(req domain.ListViews) []models.View {
+		var views []models.View
+
+		for {
+			list, err := v.repo.ListResumeView(v.ctx, req)
+			require.NoError(v.T(), err)
+			require.LessOrEqual(v.T(), len(list.Views), req.PageSize)
+
+			views = append(views, list.Views...)
+
+			if list.Cursor == "" {
+				return views
+			}
+
+			req.Cursor = list.Cursor
+		}
+	}
+
+	newest := readAll(domain.ListViews{ResumeID: resumeID, Sort: domain.SortNewestFirst, PageSize: 3})
+	oldest := readAll(domain.ListViews{ResumeID: resumeID, Sort: domain.SortOldestFirst, PageSize: 3})
+
+	require.Len(v.T(), newest, 10)
+	require.Len(v.T(), oldest, 10)
+
+	for i := range newest {
+		assert.Equal(v.T(), newest[i].ID, oldest[len(oldest)-1-i].ID)
+
+		if i > 0 {
+			assert.False(v.T(), newest[i].ViewedAt.After(newest[i-1].ViewedAt))
+		}
+	}
+
+	filtered, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID:  resumeID,
+		CompanyID: companyID.String(),
+		From:      start.Add(time.Hour),
+		To:        start.Add(5 * time.Hour),
+		Sort:      domain.SortOldestFirst,
+		PageSize:  20,
+	})
+	require.NoError(v.T(), err)
+
+	// Views 3, 6 and 9 belong to the company, all of them within [1h, 5h).
+	assert.Equal(v.T(), 3, filtered.Total)
+	assert.Len(v.T(), filtered.Views, 3)
+	assert.Empty(v.T(), filtered.Cursor)
+
+	for _, view := range filtered.Views {
+		assert.Equal(v.T(), companyID, view.CompanyID)
+	}
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 3,
+	})
+	require.NoError(v.T(), err)
+
+	_, err = v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Cursor:   list.Cursor,
+		Sort:     domain.SortOldestFirst,
+		PageSize: 3,
+	})
+	assert.ErrorIs(v.T(), err, customerrors.ErrInvalidCursor)
+}
+
+func (v *ViewRepositorySuite) TestListCompanyViews() {
+	companyID := uuid.New()
+	start := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	resumeIDs := make([]string, 0, 5)
+
+	// Resume i is viewed at hours i and i+10, so the most recently viewed resume is the last one.
+	for i := 0; i < 5; i++ {
+		resumeID := newResumeID()
+		resumeIDs = append(resumeIDs, resumeID)
+
+		v.insertView(resumeID, companyID, start.Add(time.Duration(i)*time.Hour))
+		v.insertView(resumeID, companyID, start.Add(time.Duration(i+10)*time.Hour))
+		v.insertView(resumeID, uuid.New(), start.Add(48*time.Hour))
+	}
+
+	// Suspicious views are left out, so the resume viewed only suspiciously is not listed.
+	q := `INSERT INTO views (resume_id, company_id, viewed_at, suspicious) VALUES ($1, $2, $3, TRUE)`
+
+	for _, resumeID := range []string{resumeIDs[0], newResumeID()} {
+		_, err := v.db.Exec(v.ctx, q, resumeID, companyID, start.Add(24*time.Hour))
+		require.NoError(v.T(), err)
+	}
+
+	_, err := v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	req := domain.ListCompanyViews{CompanyID: companyID.String(), PageSize: 2}
+
+	var resumes []models.ViewedResume
+
+	for {
+		list, err := v.repo.ListCompanyViews(v.ctx, req)
+		require.NoError(v.T(), err)
+		require.LessOrEqual(v.T(), len(list.Resumes), req.PageSize)
+
+		resumes = append(resumes, list.Resumes...)
+
+		if list.Cursor == "" {
+			break
+		}
+
+		req.Cursor = list.Cursor
+	}
+
+	require.Len(v.T(), resumes, 5)
+
+	for i, resume := range resumes {
+		hour := time.Duration(4 - i)
+
+		assert.Equal(v.T(), resumeIDs[4-i], resume.ResumeID)
+		assert.True(v.T(), start.Add(hour*time.Hour).Equal(resume.FirstViewedAt))
+		assert.True(v.T(), start.Add((hour+10)*time.Hour).Equal(resume.LastViewedAt))
+		assert.Equal(v.T(), 2, resume.Count)
+	}
+
+	// A new view moves its resume to the front of the list.
+	_, err = v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeIDs[0], CompanyID: companyID.String()})
+	require.NoError(v.T(), err)
+
+	list, err := v.repo.ListCompanyViews(v.ctx, domain.ListCompanyViews{CompanyID: companyID.String(), PageSize: 1})
+	require.NoError(v.T(), err)
+	require.Len(v.T(), list.Resumes, 1)
+	assert.Equal(v.T(), resumeIDs[0], list.Resumes[0].ResumeID)
+	assert.True(v.T(), start.Equal(list.Resumes[0].FirstViewedAt))
+	assert.Equal(v.T(), 3, list.Resumes[0].Count)
+
+	list, err = v.repo.ListCompanyViews(v.ctx, domain.ListCompanyViews{CompanyID: uuid.NewString(), PageSize: 2})
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), list.Resumes)
+	assert.Empty(v.T(), list.Cursor)
+
+	mismatches, err := v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+}
+
+func (v *ViewRepositorySuite) TestPartitions() {
+	from := time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	created, err := v.repo.CreatePartitions(v.ctx, from, from.AddDate(0, 1, 0))
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), []string{"views_2020_01", "views_2020_02"}, created)
+
+	created, err = v.repo.CreatePartitions(v.ctx, from, from.AddDate(0, 1, 0))
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), created)
+
+	resumeID := newResumeID()
+	v.insertView(resumeID, uuid.New(), from)
+	v.insertView(resumeID, uuid.New(), from.AddDate(0, 1, 0))
+
+	_, err = v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	partitions, err := v.repo.ListPartitions(v.ctx)
+	require.NoError(v.T(), err)
+
+	var january models.Partition
+
+	for _, p := range partitions {
+		if p.Name == "views_2020_01" {
+			january = p
+		}
+	}
+
+	require.Equal(v.T(), "views_2020_01", january.Name)
+	assert.True(v.T(), january.From.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	var exported []models.View
+
+	count, err := v.repo.ExportPartition(v.ctx, january, func(view models.View) error {
+		exported = append(exported, view)
+
+		return nil
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), int64(1), count)
+	require.Len(v.T(), exported, 1)
+	assert.Equal(v.T(), resumeID, exported[0].ResumeID)
+
+	require.NoError(v.T(), v.repo.RemovePartition(v.ctx, january, false))
+
+	partitions, err = v.repo.ListPartitions(v.ctx)
+	require.NoError(v.T(), err)
+
+	for _, p := range partitions {
+		assert.NotEqual(v.T(), "views_2020_01", p.Name)
+	}
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, list.Total)
+	assert.Len(v.T(), list.Views, 1)
+
+	mismatches, err := v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+}
+
+func (v *ViewRepositorySuite) TestRetentionLock() {
+	locked, err := v.repo.WithRetentionLock(v.ctx, false, func(ctx context.Context) error {
+		// The lock belongs to the session, so another connection cannot take it meanwhile.
+		locked, err := v.repo.WithRetentionLock(ctx, false, func(context.Context) error {
+			v.T().Fatal("ran while the lock was held")
+
+			return nil
+		})
+		require.NoError(v.T(), err)
+		assert.False(v.T(), locked)
+
+		return assert.AnError
+	})
+	require.ErrorIs(v.T(), err, assert.AnError)
+	assert.True(v.T(), locked)
+
+	// The lock is released along with the error of fn.
+	locked, err = v.repo.WithRetentionLock(v.ctx, true, func(context.Context) error { return nil })
+	require.NoError(v.T(), err)
+	assert.True(v.T(), locked)
+}
+
+func (v *ViewRepositorySuite) TestDefaultPartition() {
+	resumeID := newResumeID()
+	june := time.Date(2019, 6, 10, 0, 0, 0, 0, time.UTC)
+	expired := time.Date(2018, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	// Neither month has a partition yet, so both views land in the default partition.
+	v.insertView(resumeID, uuid.New(), june)
+	v.insertView(resumeID, uuid.New(), expired)
+
+	_, err := v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	created, err := v.repo.CreatePartitions(v.ctx, june, june)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), []string{"views_2019_06"}, created)
+
+	var moved int
+
+	q := `SELECT COUNT(*) FROM views_2019_06 WHERE resume_id = $1`
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, q, resumeID).Scan(&moved))
+	assert.Equal(v.T(), 1, moved)
+
+	cutoff := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var exported []models.View
+
+	count, err := v.repo.ExportDefaultPartition(v.ctx, cutoff, func(view models.View) error {
+		if view.ResumeID == resumeID {
+			exported = append(exported, view)
+		}
+
+		return nil
+	})
+	require.NoError(v.T(), err)
+	assert.Positive(v.T(), count)
+	require.Len(v.T(), exported, 1)
+	assert.True(v.T(), expired.Equal(exported[0].ViewedAt))
+
+	count, err = v.repo.ExpireDefaultPartition(v.ctx, cutoff)
+	require.NoError(v.T(), err)
+	assert.Positive(v.T(), count)
+
+	list, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, list.Total)
+	require.Len(v.T(), list.Views, 1)
+	assert.True(v.T(), june.Equal(list.Views[0].ViewedAt))
+
+	mismatches, err := v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+}
+
+func (v *ViewRepositorySuite) TestOutbox() {
+	resumeID := newResumeID()
+	companyID := uuid.New()
+
+	first, err := v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeID, CompanyID: companyID.String()})
+	require.NoError(v.T(), err)
+
+	second, err := v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: uuid.NewString()},
+	})
+	require.NoError(v.T(), err)
+
+	publish := func(events *[]models.ViewedEvent) func(context.Context, []models.OutboxEvent) error {
+		return func(_ context.Context, batch []models.OutboxEvent) error {
+			for _, e := range batch {
+				var event models.ViewedEvent
+				require.NoError(v.T(), json.Unmarshal(e.Payload, &event))
+				assert.Equal(v.T(), e.ResumeID, event.ResumeID)
+
+				*events = append(*events, event)
+			}
+
+			return nil
+		}
+	}
+
+	_, err = v.repo.RelayOutbox(v.ctx, 100, time.Minute, func(_ context.Context, _ []models.OutboxEvent) error {
+		return assert.AnError
+	})
+	require.ErrorIs(v.T(), err, assert.AnError)
+
+	var events []models.ViewedEvent
+
+	for {
+		n, err := v.repo.RelayOutbox(v.ctx, 1, time.Minute, publish(&events))
+		require.NoError(v.T(), err)
+
+		if n == 0 {
+			break
+		}
+	}
+
+	var ours []models.ViewedEvent
+
+	for _, event := range events {
+		if event.ResumeID == resumeID {
+			ours = append(ours, event)
+		}
+	}
+
+	require.Len(v.T(), ours, 2)
+	assert.Equal(v.T(), first.ID, ours[0].ViewID)
+	assert.Equal(v.T(), companyID.String(), ours[0].CompanyID)
+	assert.Equal(v.T(), models.ViewedEventVersion, ours[0].Version)
+	assert.Equal(v.T(), second[0].ID, ours[1].ViewID)
+
+	var pending int
+
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, `SELECT COUNT(*) FROM view_outbox`).Scan(&pending))
+	assert.Zero(v.T(), pending)
+}
+
+func (v *ViewRepositorySuite) TestOutboxLease() {
+	_, err := v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: newResumeID(), CompanyID: uuid.NewString()})
+	require.NoError(v.T(), err)
+
+	leased := func() int {
+		var n int
+
+		q := `SELECT COUNT(*) FROM view_outbox WHERE locked_until > NOW()`
+		require.NoError(v.T(), v.db.QueryRow(v.ctx, q).Scan(&n))
+
+		return n
+	}
+
+	// While a batch is published its lease is committed, and no other relay leases a batch of its own.
+	_, err = v.repo.RelayOutbox(v.ctx, 100, time.Minute, func(ctx context.Context, events []models.OutboxEvent) error {
+		assert.Equal(v.T(), len(events), leased())
+
+		n, err := v.repo.RelayOutbox(ctx, 100, time.Minute, func(context.Context, []models.OutboxEvent) error {
+			v.T().Fatal("leased a batch while another lease was live")
+
+			return nil
+		})
+		require.NoError(v.T(), err)
+		assert.Zero(v.T(), n)
+
+		return assert.AnError
+	})
+	require.ErrorIs(v.T(), err, assert.AnError)
+
+	// The failed batch is released, so the next run publishes it.
+	assert.Zero(v.T(), leased())
+
+	for {
+		n, err := v.repo.RelayOutbox(v.ctx, 100, time.Minute, func(context.Context, []models.OutboxEvent) error {
+			return nil
+		})
+		require.NoError(v.T(), err)
+
+		if n == 0 {
+			break
+		}
+	}
+
+	var pending int
+
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, `SELECT COUNT(*) FROM view_outbox`).Scan(&pending))
+	assert.Zero(v.T(), pending)
+}
+
+func (v *ViewRepositorySuite) TestNotifications() {
+	resumeID := newResumeID()
+	first, second := uuid.New(), uuid.New()
+
+	_, err := v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeID, CompanyID: first.String()})
+	require.NoError(v.T(), err)
+
+	_, err = v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: first.String()},
+		{ResumeID: resumeID, CompanyID: second.String()},
+		{ResumeID: resumeID, CompanyID: second.String()},
+	})
+	require.NoError(v.T(), err)
+
+	type payload struct {
+		CompanyID    uuid.UUID `json:"company_id"`
+		Views        int64     `json:"views"`
+		NewCompanies int64     `json:"new_companies"`
+	}
+
+	relay := func() map[string][]payload {
+		received := make(map[string][]payload)
+
+		_, err := v.repo.RelayNotifications(v.ctx, 100, 2, time.Minute,
+			func(_ context.Context, notification models.Notification) error {
+				if notification.ResumeID != resumeID {
+					return nil
+				}
+
+				var p payload
+				require.NoError(v.T(), json.Unmarshal(notification.Payload, &p))
+
+				received[notification.Kind] = append(received[notification.Kind], p)
+
+				return nil
+			})
+		require.NoError(v.T(), err)
+
+		return received
+	}
+
+	delivered, err := v.repo.RelayNotifications(v.ctx, 100, 2, time.Minute,
+		func(_ context.Context, _ models.Notification) error {
+			return assert.AnError
+		})
+	require.NoError(v.T(), err)
+	assert.Zero(v.T(), delivered)
+
+	var attempts int
+
+	q := `SELECT MIN(attempts) FROM view_notifications WHERE resume_id = $1`
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, q, resumeID).Scan(&attempts))
+	assert.Equal(v.T(), 1, attempts)
+
+	received := relay()
+	assert.ElementsMatch(v.T(), []payload{{CompanyID: first}, {CompanyID: second}},
+		received[models.NotificationNewCompany])
+	assert.Empty(v.T(), received[models.NotificationDigest])
+
+	closed, err := v.repo.CloseDigests(v.ctx, time.Hour)
+	require.NoError(v.T(), err)
+	assert.Zero(v.T(), closed)
+
+	_, err = v.repo.CloseDigests(v.ctx, 0)
+	require.NoError(v.T(), err)
+
+	received = relay()
+	assert.Equal(v.T(), []payload{{Views: 4, NewCompanies: 2}}, received[models.NotificationDigest])
+	assert.Empty(v.T(), received[models.NotificationNewCompany])
+}
+
+func (v *ViewRepositorySuite) TestNotificationLease() {
+	resumeID := newResumeID()
+
+	_, err := v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+	require.NoError(v.T(), err)
+
+	ours := func(notify func() error) func(context.Context, models.Notification) error {
+		return func(_ context.Context, notification models.Notification) error {
+			if notification.ResumeID != resumeID {
+				return nil
+			}
+
+			return notify()
+		}
+	}
+
+	var seen int
+
+	// While one relay is delivering, the leased notification is neither locked nor handed to another relay.
+	delivered, err := v.repo.RelayNotifications(v.ctx, 100, 10, time.Minute, ours(func() error {
+		_, err := v.repo.RelayNotifications(v.ctx, 100, 10, time.Minute, ours(func() error {
+			seen++
+
+			return nil
+		}))
+		require.NoError(v.T(), err)
+
+		return assert.AnError
+	}))
+	require.NoError(v.T(), err)
+	assert.Zero(v.T(), delivered)
+	assert.Zero(v.T(), seen)
+
+	// A failed delivery releases the lease, so the next run retries it right away.
+	_, err = v.repo.RelayNotifications(v.ctx, 100, 10, time.Minute, ours(func() error {
+		seen++
+
+		return nil
+	}))
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, seen)
+}
+
+func (v *ViewRepositorySuite) TestDeadLetterNotifications() {
+	resumeID := newResumeID()
+
+	_, err := v.repo.CreateView(v.ctx, domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+	require.NoError(v.T(), err)
+
+	_, err = v.repo.RelayNotifications(v.ctx, 100, 1, time.Minute,
+		func(_ context.Context, notification models.Notification) error {
+			if notification.ResumeID != resumeID {
+				return nil
+			}
+
+			return assert.AnError
+		})
+	require.NoError(v.T(), err)
+
+	dead, err := v.repo.DeadLetterNotifications(v.ctx, 1)
+	require.NoError(v.T(), err)
+	assert.GreaterOrEqual(v.T(), dead, int64(1))
+
+	count := func(table string) int {
+		var n int
+
+		q := `SELECT COUNT(*) FROM ` + table + ` WHERE resume_id = $1`
+		require.NoError(v.T(), v.db.QueryRow(v.ctx, q, resumeID).Scan(&n))
+
+		return n
+	}
+
+	assert.Zero(v.T(), count("view_notifications"))
+	assert.Equal(v.T(), 1, count("view_notifications_dead"))
+}
+
+func (v *ViewRepositorySuite) TestEraseViews() {
+	first, second := newResumeID(), newResumeID()
+	company, other := uuid.NewString(), uuid.NewString()
+
+	_, err := v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: first, CompanyID: company, IdempotencyKey: "erase-key"},
+		{ResumeID: first, CompanyID: other},
+		{ResumeID: second, CompanyID: company},
+	})
+	require.NoError(v.T(), err)
+
+	var exported []models.View
+
+	n, err := v.repo.ExportViews(v.ctx, domain.ViewSubject{CompanyID: company}, func(view models.View) error {
+		exported = append(exported, view)
+
+		return nil
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), int64(2), n)
+	assert.Len(v.T(), exported, 2)
+
+	erasure, err := v.repo.EraseViews(v.ctx, domain.EraseViews{
+		Subject:     domain.ViewSubject{CompanyID: company},
+		RequestedBy: "admin",
+		Reason:      "company request",
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), int64(2), erasure.Erased)
+
+	count := func(q string, args ...any) int64 {
+		var n int64
+		require.NoError(v.T(), v.db.QueryRow(v.ctx, q, args...).Scan(&n))
+
+		return n
+	}
+
+	assert.Zero(v.T(), count(`SELECT COUNT(*) FROM views WHERE company_id = $1`, company))
+	assert.Equal(v.T(), int64(1), count(`SELECT total FROM view_totals WHERE resume_id = $1`, first))
+	assert.Zero(v.T(), count(`SELECT total FROM view_totals WHERE resume_id = $1`, second))
+	assert.Equal(v.T(), int64(1), count(`SELECT SUM(count) FROM view_daily_counts WHERE resume_id = $1`, first))
+	assert.Zero(v.T(), count(`SELECT COUNT(*) FROM view_companies WHERE company_id = $1`, company))
+	assert.Zero(v.T(), count(`SELECT COUNT(*) FROM view_idempotency_keys WHERE company_id = $1`, company))
+	assert.Zero(v.T(), count(`SELECT COUNT(*) FROM view_outbox WHERE payload->>'company_id' = $1`, company))
+	assert.Equal(v.T(), int64(1), count(`SELECT COUNT(*) FROM view_erasures WHERE id = $1 AND company_id = $2
+		AND requested_by = 'admin'`, erasure.ID, company))
+
+	erasure, err = v.repo.EraseViews(v.ctx, domain.EraseViews{
+		Subject:     domain.ViewSubject{ResumeID: first},
+		RequestedBy: "admin",
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), int64(1), erasure.Erased)
+
+	for _, table := range []string{"views", "view_totals", "view_daily_counts", "view_companies", "view_digests",
+		"view_notifications", "view_notifications_dead", "view_outbox"} {
+		assert.Zero(v.T(), count(`SELECT COUNT(*) FROM `+table+` WHERE resume_id = $1`, first), table)
+	}
+}
+
+func (v *ViewRepositorySuite) TestEraseDetachedPartition() {
+	april := time.Date(2017, 4, 10, 0, 0, 0, 0, time.UTC)
+	resumeID := newResumeID()
+
+	_, err := v.repo.CreatePartitions(v.ctx, april, april)
+	require.NoError(v.T(), err)
+
+	v.insertView(resumeID, uuid.New(), april)
+
+	from := time.Date(2017, 4, 1, 0, 0, 0, 0, time.UTC)
+	partition := models.Partition{Name: "views_2017_04", From: from, To: from.AddDate(0, 1, 0)}
+	require.NoError(v.T(), v.repo.RemovePartition(v.ctx, partition, true))
+
+	erasure, err := v.repo.EraseViews(v.ctx, domain.EraseViews{
+		Subject:     domain.ViewSubject{ResumeID: resumeID},
+		RequestedBy: "admin",
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), int64(1), erasure.Erased)
+
+	var left int
+
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, `SELECT COUNT(*) FROM views_2017_04`).Scan(&left))
+	assert.Zero(v.T(), left)
+}
+
+func (v *ViewRepositorySuite) TestSuspiciousViews() {
+	resumeID := newResumeID()
+
+	_, err := v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID:   resumeID,
+		CompanyID:  uuid.NewString(),
+		Suspicious: true,
+	})
+	require.NoError(v.T(), err)
+
+	_, err = v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: uuid.NewString()},
+		{ResumeID: resumeID, CompanyID: uuid.NewString(), Suspicious: true},
+	})
+	require.NoError(v.T(), err)
+
+	list := func(resumeID string, includeSuspicious bool) (models.ViewList, error) {
+		return v.repo.ListResumeView(v.ctx, domain.ListViews{
+			ResumeID:          resumeID,
+			Sort:              domain.SortNewestFirst,
+			PageSize:          20,
+			IncludeSuspicious: includeSuspicious,
+		})
+	}
+
+	views, err := list(resumeID, false)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, views.Total)
+	require.Len(v.T(), views.Views, 1)
+	assert.False(v.T(), views.Views[0].Suspicious)
+
+	views, err = list(resumeID, true)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 3, views.Total)
+	require.Len(v.T(), views.Views, 3)
+	assert.True(v.T(), views.Views[0].Suspicious)
+
+	stats := domain.ViewStats{
+		ResumeID:     resumeID,
+		From:         time.Now().Add(-time.Hour),
+		To:           time.Now().Add(time.Hour),
+		Interval:     domain.StatsIntervalDay,
+		TopCompanies: 10,
+	}
+
+	counted, err := v.repo.GetResumeViewStats(v.ctx, stats)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, counted.Total)
+	assert.Len(v.T(), counted.TopCompanies, 1)
+
+	stats.IncludeSuspicious = true
+
+	counted, err = v.repo.GetResumeViewStats(v.ctx, stats)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 3, counted.Total)
+	assert.Len(v.T(), counted.TopCompanies, 3)
+
+	var events int
+
+	q := `SELECT COUNT(*) FROM view_outbox WHERE resume_id = $1`
+	require.NoError(v.T(), v.db.QueryRow(v.ctx, q, resumeID).Scan(&events))
+	assert.Equal(v.T(), 1, events, "suspicious views are not published")
+
+	mismatches, err := v.repo.CheckRollups(v.ctx)
+	require.NoError(v.T(), err)
+	assert.Empty(v.T(), mismatches)
+
+	_, err = v.repo.RebuildRollups(v.ctx)
+	require.NoError(v.T(), err)
+
+	views, err = list(resumeID, false)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, views.Total)
+
+	flaggedOnly := newResumeID()
+
+	_, err = v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID:   flaggedOnly,
+		CompanyID:  uuid.NewString(),
+		Suspicious: true,
+	})
+	require.NoError(v.T(), err)
+
+	_, err = list(flaggedOnly, false)
+	require.ErrorIs(v.T(), err, customerrors.ErrNotFound)
+
+	views, err = list(flaggedOnly, true)
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, views.Total)
+}
+
+func (v *ViewRepositorySuite) TestSuspiciousViewDedup() {
+	resumeID := newResumeID()
+	companyID := uuid.NewString()
+
+	suspicious, err := v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID:    resumeID,
+		CompanyID:   companyID,
+		DedupWindow: time.Hour,
+		Suspicious:  true,
+	})
+	require.NoError(v.T(), err)
+
+	clean, err := v.repo.CreateView(v.ctx, domain.CreateView{
+		ResumeID:    resumeID,
+		CompanyID:   companyID,
+		DedupWindow: time.Hour,
+	})
+	require.NoError(v.T(), err)
+	assert.True(v.T(), clean.Counted())
+	assert.NotEqual(v.T(), suspicious.ID, clean.ID)
+
+	results, err := v.repo.CreateViews(v.ctx, []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: companyID, DedupWindow: time.Hour, Suspicious: true},
+		{ResumeID: resumeID, CompanyID: companyID, DedupWindow: time.Hour},
+	})
+	require.NoError(v.T(), err)
+	assert.True(v.T(), results[1].Collapsed)
+	assert.Equal(v.T(), clean.ID, results[1].ID)
+
+	views, err := v.repo.ListResumeView(v.ctx, domain.ListViews{
+		ResumeID: resumeID,
+		Sort:     domain.SortNewestFirst,
+		PageSize: 20,
+	})
+	require.NoError(v.T(), err)
+	assert.Equal(v.T(), 1, views.Total)
+}
+
+func TestViewRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ViewRepositorySuite))
+}
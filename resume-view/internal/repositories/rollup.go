@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/jackc/pgx/v5"
+)
+
+// incrementRollups counts new views in the per-resume and per-day aggregates. Days are UTC.
+func (r *ViewRepository) incrementRollups(ctx context.Context, tx pgx.Tx, resumeID string, viewedAt time.Time,
+	count int) error {
+	q := `INSERT INTO view_totals (resume_id, total) VALUES ($1, $2)
+		 ON CONFLICT (resume_id) DO UPDATE SET total = view_totals.total + EXCLUDED.total, updated_at = NOW()`
+
+	if _, err := tx.Exec(ctx, q, resumeID, count); err != nil {
+		return fmt.Errorf("failed to increment view total: %w", err)
+	}
+
+	q = `INSERT INTO view_daily_counts (resume_id, day, count)
+		 VALUES ($1, ($2::timestamptz AT TIME ZONE 'UTC')::date, $3)
+		 ON CONFLICT (resume_id, day) DO UPDATE SET count = view_daily_counts.count + EXCLUDED.count`
+
+	if _, err := tx.Exec(ctx, q, resumeID, viewedAt, count); err != nil {
+		return fmt.Errorf("failed to increment daily view count: %w", err)
+	}
+
+	return nil
+}
+
+// incrementCompanyRollups counts new views in the per-company aggregates ListCompanyViews pages through. Like
+// addToOutbox it must run after incrementRollups, which serializes the writers of a resume.
+func (r *ViewRepository) incrementCompanyRollups(ctx context.Context, tx pgx.Tx, events ...models.ViewedEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	companyIDs := make([]string, 0, len(events))
+	resumeIDs := make([]string, 0, len(events))
+	viewedAt := make([]time.Time, 0, len(events))
+
+	for _, event := range events {
+		companyIDs = append(companyIDs, event.CompanyID)
+		resumeIDs = append(resumeIDs, event.ResumeID)
+		viewedAt = append(viewedAt, event.ViewedAt)
+	}
+
+	q := `INSERT INTO view_company_resumes AS c (company_id, resume_id, views, first_viewed_at, last_viewed_at)
+		 SELECT company_id, resume_id, COUNT(*), MIN(viewed_at), MAX(viewed_at)
+		 FROM unnest($1::UUID[], $2::CHAR(24)[], $3::TIMESTAMPTZ[]) AS v (company_id, resume_id, viewed_at)
+		 GROUP BY company_id, resume_id ORDER BY company_id, resume_id
+		 ON CONFLICT (company_id, resume_id) DO UPDATE SET
+			views = c.views + EXCLUDED.views,
+			first_viewed_at = LEAST(c.first_viewed_at, EXCLUDED.first_viewed_at),
+			last_viewed_at = GREATEST(c.last_viewed_at, EXCLUDED.last_viewed_at)`
+
+	if _, err := tx.Exec(ctx, q, companyIDs, resumeIDs, viewedAt); err != nil {
+		return fmt.Errorf("failed to increment company view counts: %w", err)
+	}
+
+	return nil
+}
+
+// trimCompanyRollups runs after views viewed before before were subtracted from the per-company aggregates.
+// It deletes the aggregates left without views and moves the first view of the others to the oldest view
+// that is left.
+func (r *ViewRepository) trimCompanyRollups(ctx context.Context, tx pgx.Tx, before time.Time) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM view_company_resumes WHERE views <= 0`); err != nil {
+		return fmt.Errorf("failed to delete company view counts: %w", err)
+	}
+
+	q := `UPDATE view_company_resumes c SET first_viewed_at = COALESCE((SELECT MIN(v.viewed_at) FROM views v
+			WHERE v.company_id = c.company_id AND v.resume_id = c.resume_id AND NOT v.suspicious), c.first_viewed_at)
+		 WHERE (c.company_id, c.resume_id) IN (SELECT company_id, resume_id FROM view_company_resumes
+			WHERE first_viewed_at < $1 ORDER BY company_id, resume_id FOR UPDATE)`
+
+	if _, err := tx.Exec(ctx, q, before); err != nil {
+		return fmt.Errorf("failed to update company first views: %w", err)
+	}
+
+	return nil
+}
+
+// RebuildRollups recomputes all aggregates from the views that are not suspicious. Writers are blocked
+// while it runs, so the rollups are exactly consistent with the raw table when it commits.
+func (r *ViewRepository) RebuildRollups(ctx context.Context) (int64, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.RebuildRollups")
+	defer span.End()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("could not start transaction: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	queries := []string{
+		`LOCK TABLE views IN SHARE MODE`,
+		`DELETE FROM view_totals`,
+		`DELETE FROM view_daily_counts`,
+		`DELETE FROM view_company_resumes`,
+		`INSERT INTO view_daily_counts (resume_id, day, count)
+		 SELECT resume_id, (viewed_at AT TIME ZONE 'UTC')::date, COUNT(*) FROM views WHERE NOT suspicious
+		 GROUP BY 1, 2`,
+		`INSERT INTO view_company_resumes (company_id, resume_id, views, first_viewed_at, last_viewed_at)
+		 SELECT company_id, resume_id, COUNT(*), MIN(viewed_at), MAX(viewed_at) FROM views WHERE NOT suspicious
+		 GROUP BY company_id, resume_id`,
+	}
+
+	for _, q := range queries {
+		if _, err = tx.Exec(ctx, q); err != nil {
+			return 0, fmt.Errorf("failed to rebuild rollups: %w", err)
+		}
+	}
+
+	q := `INSERT INTO view_totals (resume_id, total)
+		 SELECT resume_id, COUNT(*) FROM views WHERE NOT suspicious GROUP BY resume_id`
+
+	tag, err := tx.Exec(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild rollups: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("could not commit transaction: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// CheckRollups compares the aggregates with the views table and returns every row that differs.
+func (r *ViewRepository) CheckRollups(ctx context.Context) ([]models.RollupMismatch, error) {
+	ctx, span := r.tracer.Start(ctx, "viewRepository.CheckRollups")
+	defer span.End()
+
+	q := `SELECT COALESCE(v.resume_id, t.resume_id) AS resume_id, NULL::date AS day, NULL::text AS company_id,
+		 COALESCE(v.count, 0) AS raw, COALESCE(t.total, 0) AS rollup
+		 FROM (SELECT resume_id, COUNT(*) AS count FROM views WHERE NOT suspicious GROUP BY resume_id) v
+		 FULL OUTER JOIN view_totals t ON t.resume_id = v.resume_id
+		 WHERE COALESCE(v.count, 0) <> COALESCE(t.total, 0)
+		 UNION ALL
+		 SELECT COALESCE(v.resume_id, d.resume_id), COALESCE(v.day, d.day), NULL,
+		 COALESCE(v.count, 0), COALESCE(d.count, 0)
+		 FROM (SELECT resume_id, (viewed_at AT TIME ZONE 'UTC')::date AS day, COUNT(*) AS count
+		 FROM views WHERE NOT suspicious GROUP BY 1, 2) v
+		 FULL OUTER JOIN view_daily_counts d ON d.resume_id = v.resume_id AND d.day = v.day
+		 WHERE COALESCE(v.count, 0) <> COALESCE(d.count, 0)
+		 UNION ALL
+		 SELECT COALESCE(v.resume_id, c.resume_id), NULL, COALESCE(v.company_id, c.company_id)::text,
+		 COALESCE(v.count, 0), COALESCE(c.views, 0)
+		 FROM (SELECT company_id, resume_id, COUNT(*) AS count FROM views WHERE NOT suspicious GROUP BY 1, 2) v
+		 FULL OUTER JOIN view_company_resumes c ON c.company_id = v.company_id AND c.resume_id = v.resume_id
+		 WHERE COALESCE(v.count, 0) <> COALESCE(c.views, 0)
+		 ORDER BY resume_id, day NULLS FIRST, company_id NULLS FIRST`
+
+	rows, err := r.db.Query(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rollups: %w", err)
+	}
+
+	mismatches, err := pgx.CollectRows(rows, pgx.RowToStructByName[models.RollupMismatch])
+	if err != nil {
+		return nil, fmt.Errorf("failed to check rollups: %w", err)
+	}
+
+	return mismatches, nil
+}
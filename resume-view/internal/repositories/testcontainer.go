@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func runMigrations(t testing.TB, connURI string) {
+	m, err := migrate.New(
+		"file://migrations",
+		connURI)
+
+	require.NoError(t, err)
+
+	defer m.Close()
+
+	err = m.Up()
+
+	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		require.NoError(t, err)
+	}
+}
+
+func SetupPostgresContainer(ctx context.Context, t testing.TB) (*postgres.PostgresContainer, string) {
+	postgresContainer, err := postgres.RunContainer(ctx,
+		testcontainers.WithImage("postgres:latest"),
+		postgres.WithDatabase("views"),
+		postgres.WithUsername("postgres"),
+		postgres.WithPassword("vercello"),
+		testcontainers.WithWaitStrategy(
+			wait.
+				ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(3*time.Second),
+		),
+	)
+	require.NoError(t, err)
+
+	connURI, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	runMigrations(t, connURI)
+
+	return postgresContainer, connURI
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/archive"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/export"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// EraseViews permanently deletes the views of a resume or a company, with their rollups and everything
+// pending delivery, and returns the audit record of the erasure. With an archive dir, the views are erased
+// from the database and then from the retention archives while holding the retention lock, so no retention
+// run archives them in between. A failed redaction fails the erasure; retrying it redacts the archives
+// again.
+func (v *ViewService) EraseViews(ctx context.Context, req domain.EraseViews) (models.Erasure, error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.EraseViews")
+	defer span.End()
+
+	if err := validateEraseViews(req); err != nil {
+		return models.Erasure{}, err
+	}
+
+	var (
+		erasure  models.Erasure
+		redacted int64
+		err      error
+	)
+
+	if v.archiveDir == "" {
+		erasure, err = v.repo.EraseViews(ctx, req)
+	} else {
+		_, err = v.repo.WithRetentionLock(ctx, true, func(ctx context.Context) error {
+			var err error
+
+			if erasure, err = v.repo.EraseViews(ctx, req); err != nil {
+				return err
+			}
+
+			redacted, err = v.redactArchives(ctx, req.Subject)
+
+			return err
+		})
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.Erasure{}, fmt.Errorf("failed to erase views: %w", err)
+	}
+
+	v.log.Infof("erasure %s erased %d views and %d archived views", erasure.ID, erasure.Erased, redacted)
+
+	return erasure, nil
+}
+
+// redactArchives removes the views of the subject from every retention archive and returns how many
+// were removed. It stops between archives once ctx is done.
+func (v *ViewService) redactArchives(ctx context.Context, subject domain.ViewSubject) (int64, error) {
+	paths, err := filepath.Glob(filepath.Join(v.archiveDir, "*"+archiveExtension))
+	if err != nil {
+		return 0, fmt.Errorf("failed to list archives: %w", err)
+	}
+
+	var redacted int64
+
+	for _, path := range paths {
+		if err = ctx.Err(); err != nil {
+			return redacted, fmt.Errorf("failed to redact archives: %w", err)
+		}
+
+		n, err := archive.Filter(path, func(record []byte) (bool, error) {
+			var view models.View
+
+			if err := json.Unmarshal(record, &view); err != nil {
+				return false, fmt.Errorf("failed to decode archived view: %w", err)
+			}
+
+			if subject.ResumeID != "" {
+				return view.ResumeID == subject.ResumeID, nil
+			}
+
+			return strings.EqualFold(view.CompanyID.String(), subject.CompanyID), nil
+		})
+		if err != nil {
+			return redacted, fmt.Errorf("failed to redact %s: %w", path, err)
+		}
+
+		redacted += n
+	}
+
+	return redacted, nil
+}
+
+// ExportViews encodes every view of a resume or a company in the requested format and passes the output
+// to send in chunks of about export.DefaultChunkSize bytes. It returns how many views were exported.
+func (v *ViewService) ExportViews(ctx context.Context, req domain.ExportViews,
+	send func(chunk []byte) error) (int64, error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.ExportViews")
+	defer span.End()
+
+	if err := validateExportViews(req); err != nil {
+		return 0, err
+	}
+
+	encoder, err := export.NewEncoder(req.Format, export.DefaultChunkSize, send)
+	if err != nil {
+		return 0, fmt.Errorf("failed to export views: %w", err)
+	}
+
+	exported, err := v.repo.ExportViews(ctx, req.Subject, encoder.Encode)
+	if err == nil {
+		err = encoder.Close()
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return exported, fmt.Errorf("failed to export views: %w", err)
+	}
+
+	return exported, nil
+}
@@ -0,0 +1,205 @@
+//go:build !integration
+
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/archive"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeErasureRepository struct {
+	ViewRepository
+	views       []models.View
+	err         error
+	erased      []domain.EraseViews
+	locked      bool
+	erasedAfter bool
+}
+
+func (r *fakeErasureRepository) EraseViews(_ context.Context, req domain.EraseViews) (models.Erasure, error) {
+	r.erased = append(r.erased, req)
+	r.erasedAfter = r.locked
+
+	return models.Erasure{ID: uuid.New(), Erased: int64(len(r.views))}, r.err
+}
+
+func (r *fakeErasureRepository) ExportViews(_ context.Context, _ domain.ViewSubject,
+	fn func(view models.View) error) (int64, error) {
+	for i, view := range r.views {
+		if err := fn(view); err != nil {
+			return int64(i), err
+		}
+	}
+
+	return int64(len(r.views)), r.err
+}
+
+func (r *fakeErasureRepository) WithRetentionLock(ctx context.Context, _ bool,
+	fn func(ctx context.Context) error) (bool, error) {
+	r.locked = true
+	defer func() { r.locked = false }()
+
+	return true, fn(ctx)
+}
+
+func writeTestArchive(t *testing.T, path string, views ...models.View) {
+	t.Helper()
+
+	w, err := archive.Create(path)
+	require.NoError(t, err)
+
+	for _, view := range views {
+		require.NoError(t, w.Write(view))
+	}
+
+	require.NoError(t, w.Close())
+}
+
+func TestViewService_EraseViews(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Erases a resume", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeErasureRepository{views: make([]models.View, 3)}
+		req := domain.EraseViews{
+			Subject:     domain.ViewSubject{ResumeID: "6630e5f1a6b1f2c3d4e5f6a7"},
+			RequestedBy: "admin",
+			Reason:      "owner request",
+		}
+
+		erasure, err := newTestViewService(repo, &fakeViewMetrics{}).EraseViews(context.Background(), req)
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(3), erasure.Erased)
+		assert.Equal(t, []domain.EraseViews{req}, repo.erased)
+	})
+
+	t.Run("Redacts the archives", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		companyID := uuid.New()
+		erased := models.View{ID: uuid.New(), ResumeID: "6630e5f1a6b1f2c3d4e5f6a7", CompanyID: companyID}
+		kept := models.View{ID: uuid.New(), ResumeID: "6630e5f1a6b1f2c3d4e5f6a7", CompanyID: uuid.New()}
+
+		writeTestArchive(t, filepath.Join(dir, "views_2024_01.ndjson.gz"), erased, kept)
+
+		repo := &fakeErasureRepository{}
+		srv := newTestViewService(repo, &fakeViewMetrics{}, WithArchiveDir(dir))
+
+		_, err := srv.EraseViews(context.Background(), domain.EraseViews{
+			Subject:     domain.ViewSubject{CompanyID: strings.ToUpper(companyID.String())},
+			RequestedBy: "admin",
+		})
+		require.NoError(t, err)
+
+		archived := readArchive(t, filepath.Join(dir, "views_2024_01.ndjson.gz"))
+		require.Len(t, archived, 1)
+		assert.Equal(t, kept.ID, archived[0].ID)
+		assert.Len(t, repo.erased, 1)
+		assert.True(t, repo.erasedAfter, "views must be erased under the retention lock")
+	})
+
+	t.Run("Cancelled erasure stops redacting", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		view := models.View{ID: uuid.New(), ResumeID: "6630e5f1a6b1f2c3d4e5f6a7", CompanyID: uuid.New()}
+		writeTestArchive(t, filepath.Join(dir, "views_2024_01.ndjson.gz"), view)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		srv := newTestViewService(&fakeErasureRepository{}, &fakeViewMetrics{}, WithArchiveDir(dir))
+
+		_, err := srv.EraseViews(ctx, domain.EraseViews{
+			Subject:     domain.ViewSubject{ResumeID: view.ResumeID},
+			RequestedBy: "admin",
+		})
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, readArchive(t, filepath.Join(dir, "views_2024_01.ndjson.gz")), 1)
+	})
+
+	t.Run("Erasure fails when an archive cannot be redacted", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "views_2024_01.ndjson.gz"), []byte("not gzip"), 0o600))
+
+		repo := &fakeErasureRepository{}
+		srv := newTestViewService(repo, &fakeViewMetrics{}, WithArchiveDir(dir))
+
+		_, err := srv.EraseViews(context.Background(), domain.EraseViews{
+			Subject:     domain.ViewSubject{ResumeID: "6630e5f1a6b1f2c3d4e5f6a7"},
+			RequestedBy: "admin",
+		})
+		require.Error(t, err)
+		assert.Len(t, repo.erased, 1)
+	})
+
+	t.Run("Invalid subject is not erased", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeErasureRepository{}
+
+		_, err := newTestViewService(repo, &fakeViewMetrics{}).EraseViews(context.Background(), domain.EraseViews{})
+
+		require.ErrorIs(t, err, customerrors.ErrInvalidSubject)
+		assert.Empty(t, repo.erased)
+	})
+}
+
+func TestViewService_ExportViews(t *testing.T) {
+	t.Parallel()
+
+	subject := domain.ViewSubject{CompanyID: uuid.NewString()}
+
+	t.Run("CSV export", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeErasureRepository{views: []models.View{{ID: uuid.New()}, {ID: uuid.New()}}}
+
+		var out strings.Builder
+
+		exported, err := newTestViewService(repo, &fakeViewMetrics{}).ExportViews(context.Background(),
+			domain.ExportViews{Subject: subject, Format: domain.ExportFormatCSV}, func(chunk []byte) error {
+				out.Write(chunk)
+
+				return nil
+			})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(2), exported)
+
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "id,resume_id,company_id,viewed_at,source,viewer_user_id,user_agent,referrer,metadata,"+
+			"suspicious", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], repo.views[0].ID.String()))
+	})
+
+	t.Run("Repository failure", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeErasureRepository{err: assert.AnError}
+
+		_, err := newTestViewService(repo, &fakeViewMetrics{}).ExportViews(context.Background(),
+			domain.ExportViews{Subject: subject, Format: domain.ExportFormatNDJSON}, func(_ []byte) error {
+				return nil
+			})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
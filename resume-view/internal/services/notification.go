@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type NotificationRepository interface {
+	CloseDigests(ctx context.Context, interval time.Duration) (int64, error)
+	RelayNotifications(ctx context.Context, limit, maxAttempts int, lease time.Duration,
+		notify func(ctx context.Context, notification models.Notification) error) (int, error)
+	DeadLetterNotifications(ctx context.Context, maxAttempts int) (int64, error)
+}
+
+type NotificationMetrics interface {
+	AddDeadNotifications(n int)
+}
+
+// Notifier delivers a notification to the owner of its resume.
+type Notifier interface {
+	Notify(ctx context.Context, notification models.Notification) error
+}
+
+type NotificationConfig struct {
+	// DigestInterval is how often at most a resume owner gets a digest of the views of their resume.
+	DigestInterval time.Duration
+	// BatchSize is how many notifications are leased at once.
+	BatchSize int
+	// Lease is how long a batch is held by a replica before others may retry it. It must outlast the
+	// delivery of a batch, retries included.
+	Lease time.Duration
+	// MaxAttempts is how many failed deliveries a notification gets before it is moved to the dead letter
+	// table.
+	MaxAttempts int
+}
+
+// NotificationService delivers the alerts about companies viewing a resume for the first time and the
+// periodic view digests that ViewRepository queues along with the views.
+type NotificationService struct {
+	log      *zap.SugaredLogger
+	tracer   trace.Tracer
+	repo     NotificationRepository
+	notifier Notifier
+	metric   NotificationMetrics
+	cfg      NotificationConfig
+}
+
+func NewNotificationService(log *zap.SugaredLogger, tracer trace.Tracer, repo NotificationRepository,
+	notifier Notifier, metric NotificationMetrics, cfg NotificationConfig) *NotificationService {
+	return &NotificationService{log: log, tracer: tracer, repo: repo, notifier: notifier, metric: metric, cfg: cfg}
+}
+
+// Deliver queues the digests that are due, then delivers pending notifications until a batch comes back
+// short, and returns how many were delivered. Failed deliveries are logged and retried on the next run,
+// until MaxAttempts of them move the notification to the dead letter table.
+func (n *NotificationService) Deliver(ctx context.Context) (int, error) {
+	ctx, span := n.tracer.Start(ctx, "notificationService.Deliver")
+	defer span.End()
+
+	if _, err := n.repo.CloseDigests(ctx, n.cfg.DigestInterval); err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, fmt.Errorf("failed to close digests: %w", err)
+	}
+
+	var total int
+
+	for {
+		delivered, err := n.repo.RelayNotifications(ctx, n.cfg.BatchSize, n.cfg.MaxAttempts, n.cfg.Lease, n.notify)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return total, fmt.Errorf("failed to relay notifications: %w", err)
+		}
+
+		total += delivered
+
+		if delivered < n.cfg.BatchSize {
+			break
+		}
+	}
+
+	dead, err := n.repo.DeadLetterNotifications(ctx, n.cfg.MaxAttempts)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return total, fmt.Errorf("failed to dead-letter notifications: %w", err)
+	}
+
+	if dead > 0 {
+		n.log.Warnf("moved %d notifications to the dead letter table after %d failed deliveries", dead,
+			n.cfg.MaxAttempts)
+		n.metric.AddDeadNotifications(int(dead))
+	}
+
+	return total, nil
+}
+
+func (n *NotificationService) notify(ctx context.Context, notification models.Notification) error {
+	if err := n.notifier.Notify(ctx, notification); err != nil {
+		n.log.Warnf("failed to deliver notification %d of resume %s, attempt %d: %v",
+			notification.ID, notification.ResumeID, notification.Attempts+1, err)
+
+		return fmt.Errorf("failed to notify: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+//go:build !integration
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/notify"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type fakeNotificationRepository struct {
+	pending     []models.Notification
+	closeErr    error
+	closedAfter time.Duration
+	dead        []models.Notification
+}
+
+func (r *fakeNotificationRepository) CloseDigests(_ context.Context, interval time.Duration) (int64, error) {
+	r.closedAfter = interval
+
+	return 0, r.closeErr
+}
+
+func (r *fakeNotificationRepository) RelayNotifications(ctx context.Context, limit, maxAttempts int,
+	_ time.Duration, notify func(ctx context.Context, notification models.Notification) error) (int, error) {
+	var delivered int
+
+	kept := r.pending[:0]
+
+	for i, notification := range r.pending {
+		if i >= limit || notification.Attempts >= maxAttempts {
+			kept = append(kept, notification)
+
+			continue
+		}
+
+		if notify(ctx, notification) != nil {
+			notification.Attempts++
+			kept = append(kept, notification)
+
+			continue
+		}
+
+		delivered++
+	}
+
+	r.pending = kept
+
+	return delivered, nil
+}
+
+func (r *fakeNotificationRepository) DeadLetterNotifications(_ context.Context, maxAttempts int) (int64, error) {
+	var dead int64
+
+	kept := r.pending[:0]
+
+	for _, notification := range r.pending {
+		if notification.Attempts >= maxAttempts {
+			r.dead = append(r.dead, notification)
+			dead++
+
+			continue
+		}
+
+		kept = append(kept, notification)
+	}
+
+	r.pending = kept
+
+	return dead, nil
+}
+
+type fakeNotificationMetrics struct {
+	dead int
+}
+
+func (m *fakeNotificationMetrics) AddDeadNotifications(n int) {
+	m.dead += n
+}
+
+type fakeNotifier struct {
+	failing   map[int64]bool
+	delivered []int64
+}
+
+func (n *fakeNotifier) Notify(_ context.Context, notification models.Notification) error {
+	if n.failing[notification.ID] {
+		return assert.AnError
+	}
+
+	n.delivered = append(n.delivered, notification.ID)
+
+	return nil
+}
+
+func TestNotificationService_Deliver(t *testing.T) {
+	t.Parallel()
+
+	pending := func(n int) []models.Notification {
+		notifications := make([]models.Notification, 0, n)
+		for i := range n {
+			notifications = append(notifications, models.Notification{ID: int64(i + 1)})
+		}
+
+		return notifications
+	}
+
+	cfg := NotificationConfig{DigestInterval: time.Hour, BatchSize: 2, MaxAttempts: 3}
+
+	t.Run("Drains pending notifications", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeNotificationRepository{pending: pending(5)}
+		notifier := &fakeNotifier{}
+		service := NewNotificationService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo,
+			notifier, &fakeNotificationMetrics{}, cfg)
+
+		delivered, err := service.Deliver(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 5, delivered)
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, notifier.delivered)
+		assert.Equal(t, time.Hour, repo.closedAfter)
+		assert.Empty(t, repo.pending)
+	})
+
+	t.Run("Failed notifications are kept", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeNotificationRepository{pending: pending(2)}
+		notifier := &fakeNotifier{failing: map[int64]bool{1: true}}
+		service := NewNotificationService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo,
+			notifier, &fakeNotificationMetrics{}, cfg)
+
+		delivered, err := service.Deliver(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, delivered)
+		assert.Equal(t, []models.Notification{{ID: 1, Attempts: 1}}, repo.pending)
+	})
+
+	t.Run("Exhausted notifications are dead-lettered", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeNotificationRepository{pending: []models.Notification{{ID: 1, Attempts: 2}, {ID: 2}}}
+		notifier := &fakeNotifier{failing: map[int64]bool{1: true, 2: true}}
+		metrics := &fakeNotificationMetrics{}
+		service := NewNotificationService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo,
+			notifier, metrics, cfg)
+
+		delivered, err := service.Deliver(context.Background())
+		require.NoError(t, err)
+
+		assert.Zero(t, delivered)
+		assert.Equal(t, []models.Notification{{ID: 1, Attempts: 3}}, repo.dead)
+		assert.Equal(t, []models.Notification{{ID: 2, Attempts: 1}}, repo.pending)
+		assert.Equal(t, 1, metrics.dead)
+	})
+
+	t.Run("Log notifier", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeNotificationRepository{pending: pending(3)}
+		service := NewNotificationService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo,
+			notify.NewLogNotifier(zap.NewNop().Sugar()), &fakeNotificationMetrics{}, cfg)
+
+		delivered, err := service.Deliver(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, delivered)
+	})
+
+	t.Run("Close digests failure", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeNotificationRepository{pending: pending(1), closeErr: assert.AnError}
+		notifier := &fakeNotifier{}
+		service := NewNotificationService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo,
+			notifier, &fakeNotificationMetrics{}, cfg)
+
+		_, err := service.Deliver(context.Background())
+
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, notifier.delivered)
+	})
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type OutboxRepository interface {
+	RelayOutbox(ctx context.Context, limit int, lease time.Duration,
+		publish func(ctx context.Context, events []models.OutboxEvent) error) (int, error)
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, events []models.OutboxEvent) error
+}
+
+// OutboxRelay delivers the "resume viewed" events stored by ViewRepository to a publisher. Events are
+// deleted only after they were published, so delivery is at least once.
+type OutboxRelay struct {
+	tracer    trace.Tracer
+	repo      OutboxRepository
+	publisher EventPublisher
+	batchSize int
+	lease     time.Duration
+}
+
+// NewOutboxRelay relays batches of batchSize events, each leased for lease while it is published.
+func NewOutboxRelay(tracer trace.Tracer, repo OutboxRepository, publisher EventPublisher, batchSize int,
+	lease time.Duration) *OutboxRelay {
+	return &OutboxRelay{tracer: tracer, repo: repo, publisher: publisher, batchSize: batchSize, lease: lease}
+}
+
+// Relay publishes pending events in batches until the outbox is drained or another replica is relaying,
+// and returns how many it published.
+func (o *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	ctx, span := o.tracer.Start(ctx, "outboxRelay.Relay")
+	defer span.End()
+
+	var total int
+
+	for {
+		published, err := o.repo.RelayOutbox(ctx, o.batchSize, o.lease, o.publisher.Publish)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			return total, fmt.Errorf("failed to relay outbox: %w", err)
+		}
+
+		total += published
+
+		if published < o.batchSize {
+			return total, nil
+		}
+	}
+}
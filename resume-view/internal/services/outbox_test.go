@@ -0,0 +1,98 @@
+//go:build !integration
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type fakeOutboxRepository struct {
+	pending []models.OutboxEvent
+	calls   int
+}
+
+func (r *fakeOutboxRepository) RelayOutbox(ctx context.Context, limit int, _ time.Duration,
+	publish func(ctx context.Context, events []models.OutboxEvent) error) (int, error) {
+	r.calls++
+
+	batch := r.pending[:min(limit, len(r.pending))]
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := publish(ctx, batch); err != nil {
+		return 0, err
+	}
+
+	r.pending = r.pending[len(batch):]
+
+	return len(batch), nil
+}
+
+type fakePublisher struct {
+	err       error
+	published []int64
+}
+
+func (p *fakePublisher) Publish(_ context.Context, events []models.OutboxEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	for _, event := range events {
+		p.published = append(p.published, event.ID)
+	}
+
+	return nil
+}
+
+func TestOutboxRelay_Relay(t *testing.T) {
+	t.Parallel()
+
+	pending := func(n int) []models.OutboxEvent {
+		events := make([]models.OutboxEvent, 0, n)
+		for i := range n {
+			events = append(events, models.OutboxEvent{ID: int64(i + 1)})
+		}
+
+		return events
+	}
+
+	tests := []struct {
+		name      string
+		pending   int
+		err       error
+		published []int64
+		calls     int
+		left      int
+	}{
+		{name: "Empty outbox", calls: 1},
+		{name: "Partial batch", pending: 2, published: []int64{1, 2}, calls: 1},
+		{name: "Drains full batches", pending: 6, published: []int64{1, 2, 3, 4, 5, 6}, calls: 3},
+		{name: "Publish failure keeps events", pending: 2, err: assert.AnError, calls: 1, left: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeOutboxRepository{pending: pending(tt.pending)}
+			publisher := &fakePublisher{err: tt.err}
+			relay := NewOutboxRelay(noop.NewTracerProvider().Tracer("test"), repo, publisher, 3, time.Minute)
+
+			published, err := relay.Relay(context.Background())
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, len(tt.published), published)
+			assert.Equal(t, tt.published, publisher.published)
+			assert.Equal(t, tt.calls, repo.calls)
+			assert.Len(t, repo.pending, tt.left)
+		})
+	}
+}
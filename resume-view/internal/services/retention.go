@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/archive"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type PartitionRepository interface {
+	CreatePartitions(ctx context.Context, from, to time.Time) ([]string, error)
+	ListPartitions(ctx context.Context) ([]models.Partition, error)
+	ExportPartition(ctx context.Context, partition models.Partition, fn func(view models.View) error) (int64, error)
+	RemovePartition(ctx context.Context, partition models.Partition, detachOnly bool) error
+	ExportDefaultPartition(ctx context.Context, before time.Time, fn func(view models.View) error) (int64, error)
+	ExpireDefaultPartition(ctx context.Context, before time.Time) (int64, error)
+	WithRetentionLock(ctx context.Context, wait bool, fn func(ctx context.Context) error) (bool, error)
+}
+
+const (
+	archiveExtension = ".ndjson.gz"
+
+	// Archives of the default partition are named after the cutoff of their run, such as
+	// views_default_20240220T120000Z.ndjson.gz.
+	defaultArchivePrefix = "views_default_"
+	defaultArchiveLayout = "20060102T150405Z"
+)
+
+type RetentionConfig struct {
+	// Period is how long views are kept. Zero keeps them forever.
+	Period time.Duration
+	// PremakeMonths is how many months ahead of the current one get a partition.
+	PremakeMonths int
+	// ArchiveDir receives a gzip NDJSON export of every partition before it is removed, and of the expired
+	// views of the default partition. Empty skips the export. It must be storage shared by every replica,
+	// which erasures redact as well.
+	ArchiveDir string
+	// DetachOnly leaves expired partitions in the database as standalone tables instead of dropping them.
+	DetachOnly bool
+}
+
+type RetentionService struct {
+	log    *zap.SugaredLogger
+	tracer trace.Tracer
+	repo   PartitionRepository
+	cfg    RetentionConfig
+	now    func() time.Time
+}
+
+func NewRetentionService(log *zap.SugaredLogger, tracer trace.Tracer, repo PartitionRepository,
+	cfg RetentionConfig) *RetentionService {
+	return &RetentionService{log: log, tracer: tracer, repo: repo, cfg: cfg, now: time.Now}
+}
+
+// Run creates the partitions of the current and upcoming months, then archives and removes every
+// partition that ended before the retention period, and the views of the default partition older than
+// it. Views are always written with the current time, so expired views no longer change while they are
+// exported. A single run goes on at a time across replicas; while another one holds the retention lock,
+// Run does nothing and reports Skipped.
+func (s *RetentionService) Run(ctx context.Context) (models.RetentionReport, error) {
+	ctx, span := s.tracer.Start(ctx, "retentionService.Run")
+	defer span.End()
+
+	var report models.RetentionReport
+
+	locked, err := s.repo.WithRetentionLock(ctx, false, func(ctx context.Context) error {
+		var err error
+
+		report, err = s.run(ctx)
+
+		return err
+	})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return report, fmt.Errorf("failed to run retention: %w", err)
+	}
+
+	report.Skipped = !locked
+
+	return report, nil
+}
+
+func (s *RetentionService) run(ctx context.Context) (models.RetentionReport, error) {
+	var report models.RetentionReport
+
+	now := s.now()
+
+	created, err := s.repo.CreatePartitions(ctx, now, now.AddDate(0, s.cfg.PremakeMonths, 0))
+	report.Created = created
+
+	if err != nil {
+		return report, fmt.Errorf("failed to create partitions: %w", err)
+	}
+
+	if s.cfg.Period <= 0 {
+		return report, nil
+	}
+
+	partitions, err := s.repo.ListPartitions(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list partitions: %w", err)
+	}
+
+	cutoff := now.Add(-s.cfg.Period)
+
+	for _, partition := range partitions {
+		if partition.To.After(cutoff) {
+			continue
+		}
+
+		if s.cfg.ArchiveDir != "" {
+			archived, err := s.archive(ctx, partition)
+			if err != nil {
+				return report, err
+			}
+
+			if archived > 0 {
+				report.Archived = append(report.Archived, partition.Name)
+			}
+		}
+
+		if err = s.repo.RemovePartition(ctx, partition, s.cfg.DetachOnly); err != nil {
+			return report, fmt.Errorf("failed to remove partition %s: %w", partition.Name, err)
+		}
+
+		report.Removed = append(report.Removed, partition.Name)
+	}
+
+	if err = s.expireDefault(ctx, cutoff, &report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// expireDefault archives and deletes the views of the default partition viewed before cutoff. Each run
+// archives to a file of its own, named after its cutoff.
+func (s *RetentionService) expireDefault(ctx context.Context, cutoff time.Time, report *models.RetentionReport) error {
+	if s.cfg.ArchiveDir != "" {
+		name := defaultArchivePrefix + cutoff.UTC().Format(defaultArchiveLayout)
+
+		archived, err := s.export(ctx, name, func(fn func(view models.View) error) (int64, error) {
+			return s.repo.ExportDefaultPartition(ctx, cutoff, fn)
+		})
+		if err != nil {
+			return err
+		}
+
+		if archived > 0 {
+			report.Archived = append(report.Archived, name)
+		}
+	}
+
+	expired, err := s.repo.ExpireDefaultPartition(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to expire default partition: %w", err)
+	}
+
+	report.Expired = expired
+
+	return nil
+}
+
+func (s *RetentionService) archive(ctx context.Context, partition models.Partition) (int64, error) {
+	return s.export(ctx, partition.Name, func(fn func(view models.View) error) (int64, error) {
+		return s.repo.ExportPartition(ctx, partition, fn)
+	})
+}
+
+// export writes the views exported by exportFn to the archive called name. An empty export leaves no
+// archive behind.
+func (s *RetentionService) export(ctx context.Context, name string,
+	exportFn func(fn func(view models.View) error) (int64, error)) (int64, error) {
+	path := filepath.Join(s.cfg.ArchiveDir, name+archiveExtension)
+
+	w, err := archive.Create(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+
+	exported, err := exportFn(func(view models.View) error {
+		return w.Write(view)
+	})
+	if err != nil {
+		w.Abort()
+
+		return 0, fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+
+	if exported == 0 {
+		w.Abort()
+
+		return 0, nil
+	}
+
+	if err = w.Close(); err != nil {
+		return 0, fmt.Errorf("failed to archive %s: %w", name, err)
+	}
+
+	s.log.Infof("archived %d views of %s to %s", exported, name, path)
+
+	return exported, nil
+}
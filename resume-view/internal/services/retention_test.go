@@ -0,0 +1,304 @@
+//go:build !integration
+
+package services
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type fakePartitionRepository struct {
+	partitions  []models.Partition
+	views       map[string][]models.View
+	exportErr   error
+	createdFrom time.Time
+	createdTo   time.Time
+	removed     []string
+	detachOnly  bool
+	defaults    []models.View
+	expiredAt   time.Time
+	lockHeld    bool
+}
+
+func (r *fakePartitionRepository) CreatePartitions(_ context.Context, from, to time.Time) ([]string, error) {
+	r.createdFrom, r.createdTo = from, to
+
+	return []string{"views_2024_06"}, nil
+}
+
+func (r *fakePartitionRepository) ListPartitions(context.Context) ([]models.Partition, error) {
+	return r.partitions, nil
+}
+
+func (r *fakePartitionRepository) ExportPartition(_ context.Context, partition models.Partition,
+	fn func(view models.View) error) (int64, error) {
+	if r.exportErr != nil {
+		return 0, r.exportErr
+	}
+
+	for _, view := range r.views[partition.Name] {
+		if err := fn(view); err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(r.views[partition.Name])), nil
+}
+
+func (r *fakePartitionRepository) RemovePartition(_ context.Context, partition models.Partition,
+	detachOnly bool) error {
+	r.removed = append(r.removed, partition.Name)
+	r.detachOnly = detachOnly
+
+	return nil
+}
+
+func (r *fakePartitionRepository) ExportDefaultPartition(_ context.Context, before time.Time,
+	fn func(view models.View) error) (int64, error) {
+	var exported int64
+
+	for _, view := range r.defaults {
+		if !view.ViewedAt.Before(before) {
+			continue
+		}
+
+		if err := fn(view); err != nil {
+			return 0, err
+		}
+
+		exported++
+	}
+
+	return exported, nil
+}
+
+func (r *fakePartitionRepository) ExpireDefaultPartition(_ context.Context, before time.Time) (int64, error) {
+	r.expiredAt = before
+
+	kept := r.defaults[:0]
+
+	for _, view := range r.defaults {
+		if view.ViewedAt.Before(before) {
+			continue
+		}
+
+		kept = append(kept, view)
+	}
+
+	expired := int64(len(r.defaults) - len(kept))
+	r.defaults = kept
+
+	return expired, nil
+}
+
+func monthPartition(year int, month time.Month) models.Partition {
+	from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+
+	return models.Partition{Name: from.Format("views_2006_01"), From: from, To: from.AddDate(0, 1, 0)}
+}
+
+func (r *fakePartitionRepository) WithRetentionLock(ctx context.Context, _ bool,
+	fn func(ctx context.Context) error) (bool, error) {
+	if r.lockHeld {
+		return false, nil
+	}
+
+	return true, fn(ctx)
+}
+
+func newTestRetentionService(repo PartitionRepository, cfg RetentionConfig, now time.Time) *RetentionService {
+	s := NewRetentionService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo, cfg)
+	s.now = func() time.Time { return now }
+
+	return s
+}
+
+func readArchive(t *testing.T, path string) []models.View {
+	t.Helper()
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	var views []models.View
+
+	scanner := bufio.NewScanner(gz)
+	for scanner.Scan() {
+		var view models.View
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &view))
+
+		views = append(views, view)
+	}
+
+	require.NoError(t, scanner.Err())
+
+	return views
+}
+
+func TestRetentionService_Run(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 5, 20, 12, 0, 0, 0, time.UTC)
+	view := models.View{
+		ID:        uuid.New(),
+		ResumeID:  "6630e5f1a6b1f2c3d4e5f6a7",
+		CompanyID: uuid.New(),
+		ViewedAt:  time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+	}
+
+	newRepo := func() *fakePartitionRepository {
+		return &fakePartitionRepository{
+			partitions: []models.Partition{
+				monthPartition(2024, time.January),
+				monthPartition(2024, time.February),
+				monthPartition(2024, time.March),
+				monthPartition(2024, time.May),
+			},
+			views: map[string][]models.View{"views_2024_01": {view}},
+		}
+	}
+
+	t.Run("Expired partitions are archived and dropped", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		repo := newRepo()
+		srv := newTestRetentionService(repo, RetentionConfig{
+			Period:        90 * 24 * time.Hour,
+			PremakeMonths: 2,
+			ArchiveDir:    dir,
+		}, now)
+
+		report, err := srv.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, now, repo.createdFrom)
+		assert.Equal(t, now.AddDate(0, 2, 0), repo.createdTo)
+
+		// The cutoff is 2024-02-20, only January has fully ended before it.
+		assert.Equal(t, []string{"views_2024_06"}, report.Created)
+		assert.Equal(t, []string{"views_2024_01"}, report.Archived)
+		assert.Equal(t, []string{"views_2024_01"}, report.Removed)
+		assert.Equal(t, []string{"views_2024_01"}, repo.removed)
+		assert.False(t, repo.detachOnly)
+
+		archived := readArchive(t, filepath.Join(dir, "views_2024_01.ndjson.gz"))
+		require.Len(t, archived, 1)
+		assert.Equal(t, view.ID, archived[0].ID)
+		assert.True(t, view.ViewedAt.Equal(archived[0].ViewedAt))
+	})
+
+	t.Run("Expired views of the default partition are archived and deleted", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		repo := newRepo()
+		repo.partitions = nil
+		recent := view
+		recent.ID = uuid.New()
+		recent.ViewedAt = now.Add(-time.Hour)
+		repo.defaults = []models.View{view, recent}
+
+		srv := newTestRetentionService(repo, RetentionConfig{Period: 90 * 24 * time.Hour, ArchiveDir: dir}, now)
+
+		report, err := srv.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), report.Expired)
+		assert.Equal(t, []string{"views_default_20240220T120000Z"}, report.Archived)
+		assert.True(t, repo.expiredAt.Equal(now.Add(-90*24*time.Hour)))
+		require.Len(t, repo.defaults, 1)
+		assert.Equal(t, recent.ID, repo.defaults[0].ID)
+
+		archived := readArchive(t, filepath.Join(dir, "views_default_20240220T120000Z.ndjson.gz"))
+		require.Len(t, archived, 1)
+		assert.Equal(t, view.ID, archived[0].ID)
+
+		// Nothing is left to expire, so the next run writes no archive.
+		report, err = srv.Run(context.Background())
+		require.NoError(t, err)
+		assert.Zero(t, report.Expired)
+		assert.Empty(t, report.Archived)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("Detach only without archive", func(t *testing.T) {
+		t.Parallel()
+
+		repo := newRepo()
+		srv := newTestRetentionService(repo, RetentionConfig{Period: 60 * 24 * time.Hour, DetachOnly: true}, now)
+
+		report, err := srv.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, report.Archived)
+		assert.Equal(t, []string{"views_2024_01", "views_2024_02"}, report.Removed)
+		assert.True(t, repo.detachOnly)
+	})
+
+	t.Run("Zero period keeps everything", func(t *testing.T) {
+		t.Parallel()
+
+		repo := newRepo()
+		srv := newTestRetentionService(repo, RetentionConfig{}, now)
+
+		report, err := srv.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"views_2024_06"}, report.Created)
+		assert.Empty(t, repo.removed)
+	})
+
+	t.Run("Skipped while another replica runs retention", func(t *testing.T) {
+		t.Parallel()
+
+		repo := newRepo()
+		repo.lockHeld = true
+		srv := newTestRetentionService(repo, RetentionConfig{Period: 60 * 24 * time.Hour}, now)
+
+		report, err := srv.Run(context.Background())
+		require.NoError(t, err)
+
+		assert.True(t, report.Skipped)
+		assert.Empty(t, report.Created)
+		assert.Empty(t, repo.removed)
+		assert.True(t, repo.createdFrom.IsZero())
+	})
+
+	t.Run("Partition is kept when the export fails", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		repo := newRepo()
+		repo.exportErr = assert.AnError
+		srv := newTestRetentionService(repo, RetentionConfig{Period: 90 * 24 * time.Hour, ArchiveDir: dir}, now)
+
+		_, err := srv.Run(context.Background())
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, repo.removed)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
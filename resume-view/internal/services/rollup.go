@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type RollupRepository interface {
+	RebuildRollups(ctx context.Context) (int64, error)
+	CheckRollups(ctx context.Context) ([]models.RollupMismatch, error)
+}
+
+type RollupService struct {
+	log    *zap.SugaredLogger
+	tracer trace.Tracer
+	repo   RollupRepository
+}
+
+func NewRollupService(log *zap.SugaredLogger, tracer trace.Tracer, repo RollupRepository) *RollupService {
+	return &RollupService{log: log, tracer: tracer, repo: repo}
+}
+
+// Backfill rebuilds the rollups from the views table and returns the number of resumes counted.
+func (s *RollupService) Backfill(ctx context.Context) (int64, error) {
+	ctx, span := s.tracer.Start(ctx, "rollupService.Backfill")
+	defer span.End()
+
+	resumes, err := s.repo.RebuildRollups(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return 0, fmt.Errorf("failed to backfill rollups: %w", err)
+	}
+
+	return resumes, nil
+}
+
+func (s *RollupService) Check(ctx context.Context) ([]models.RollupMismatch, error) {
+	ctx, span := s.tracer.Start(ctx, "rollupService.Check")
+	defer span.End()
+
+	mismatches, err := s.repo.CheckRollups(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, fmt.Errorf("failed to check rollups: %w", err)
+	}
+
+	for _, m := range mismatches {
+		if m.CompanyID != nil {
+			s.log.Warnf("rollup mismatch for resume %s and company %s: raw %d, rollup %d",
+				m.ResumeID, *m.CompanyID, m.Raw, m.Rollup)
+
+			continue
+		}
+
+		if m.Day != nil {
+			s.log.Warnf("rollup mismatch for resume %s on %s: raw %d, rollup %d",
+				m.ResumeID, m.Day.Format(time.DateOnly), m.Raw, m.Rollup)
+
+			continue
+		}
+
+		s.log.Warnf("rollup mismatch for resume %s: raw %d, rollup %d", m.ResumeID, m.Raw, m.Rollup)
+	}
+
+	return mismatches, nil
+}
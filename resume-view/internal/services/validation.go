@@ -0,0 +1,180 @@
+package services
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/url"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/google/uuid"
+)
+
+// resumeIDLength is the length of a hex encoded resume ObjectID, which views store as CHAR(24).
+const resumeIDLength = 24
+
+// validator collects field violations so a request reports all of its invalid fields at once.
+type validator struct {
+	violations []customerrors.FieldViolation
+}
+
+func (v *validator) check(ok bool, field, description string, err error) {
+	if ok {
+		return
+	}
+
+	v.violations = append(v.violations, customerrors.FieldViolation{Field: field, Description: description, Err: err})
+}
+
+func (v *validator) resumeID(resumeID string) {
+	v.check(isResumeID(resumeID), "resume_id",
+		fmt.Sprintf("must be %d hexadecimal characters", resumeIDLength), customerrors.ErrInvalidResumeID)
+}
+
+func (v *validator) companyID(companyID string) {
+	_, err := uuid.Parse(companyID)
+	v.check(err == nil, "company_id", "must be a UUID", customerrors.ErrInvalidCompanyID)
+}
+
+// subject requires exactly one of the resume and company ids.
+func (v *validator) subject(subject domain.ViewSubject) {
+	switch {
+	case subject.ResumeID != "" && subject.CompanyID != "":
+		v.check(false, "company_id", "must be empty when resume_id is set", customerrors.ErrInvalidSubject)
+	case subject.ResumeID != "":
+		v.resumeID(subject.ResumeID)
+	case subject.CompanyID != "":
+		v.companyID(subject.CompanyID)
+	default:
+		v.check(false, "resume_id", "either resume_id or company_id must be set", customerrors.ErrInvalidSubject)
+	}
+}
+
+func (v *validator) viewer(viewer domain.ViewerContext) {
+	if viewer.Source != "" {
+		v.check(viewer.Source.Valid(), "source", "must be direct, search, recommendation, notification or api",
+			customerrors.ErrInvalidViewerContext)
+	}
+
+	v.check(len(viewer.ViewerUserID) <= maxViewerUserIDLength, "viewer_user_id",
+		fmt.Sprintf("must be at most %d bytes", maxViewerUserIDLength), customerrors.ErrInvalidViewerContext)
+	v.check(len(viewer.UserAgent) <= maxUserAgentLength, "user_agent",
+		fmt.Sprintf("must be at most %d bytes", maxUserAgentLength), customerrors.ErrInvalidViewerContext)
+
+	if viewer.Referrer != "" {
+		referrer, err := url.Parse(viewer.Referrer)
+		v.check(err == nil && referrer.IsAbs() && len(viewer.Referrer) <= maxReferrerLength, "referrer",
+			fmt.Sprintf("must be an absolute URL of at most %d bytes", maxReferrerLength),
+			customerrors.ErrInvalidViewerContext)
+	}
+
+	v.check(len(viewer.Metadata) <= maxMetadataEntries, "metadata",
+		fmt.Sprintf("must have at most %d entries", maxMetadataEntries), customerrors.ErrInvalidViewerContext)
+
+	for key, value := range viewer.Metadata {
+		if key == "" || len(key) > maxMetadataKeyLength || len(value) > maxMetadataValueLength {
+			v.check(false, "metadata", fmt.Sprintf("keys must be 1 to %d bytes and values at most %d bytes",
+				maxMetadataKeyLength, maxMetadataValueLength), customerrors.ErrInvalidViewerContext)
+
+			break
+		}
+	}
+}
+
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	return &customerrors.ValidationError{Violations: v.violations}
+}
+
+func isResumeID(resumeID string) bool {
+	if len(resumeID) != resumeIDLength {
+		return false
+	}
+
+	_, err := hex.DecodeString(resumeID)
+
+	return err == nil
+}
+
+func validateCreateView(req domain.CreateView) error {
+	var v validator
+
+	v.resumeID(req.ResumeID)
+
+	v.companyID(req.CompanyID)
+	v.check(len(req.IdempotencyKey) <= maxIdempotencyKeyLength, "idempotency_key",
+		fmt.Sprintf("must be at most %d bytes", maxIdempotencyKeyLength), customerrors.ErrInvalidIdempotencyKey)
+	v.viewer(req.Viewer)
+
+	return v.err()
+}
+
+func validateResumeID(resumeID string) error {
+	var v validator
+
+	v.resumeID(resumeID)
+
+	return v.err()
+}
+
+func validateListViews(req domain.ListViews) error {
+	var v validator
+
+	v.resumeID(req.ResumeID)
+
+	if req.CompanyID != "" {
+		v.companyID(req.CompanyID)
+	}
+
+	v.viewer(req.Viewer)
+
+	if !req.From.IsZero() && !req.To.IsZero() {
+		v.check(req.From.Before(req.To), "from", "must be before to", customerrors.ErrInvalidTimeRange)
+	}
+
+	v.check(req.Sort == domain.SortNewestFirst || req.Sort == domain.SortOldestFirst, "sort",
+		"must be newest or oldest first", customerrors.ErrInvalidSortOrder)
+
+	return v.err()
+}
+
+func validateCompanyID(companyID string) error {
+	var v validator
+
+	v.companyID(companyID)
+
+	return v.err()
+}
+
+// validateViewStats expects the defaults of GetResumeViewStats to be applied already.
+func validateViewStats(req domain.ViewStats) error {
+	var v validator
+
+	v.resumeID(req.ResumeID)
+	v.check(req.From.Before(req.To), "from", "must be before to", customerrors.ErrInvalidStatsRange)
+	v.check(req.To.Sub(req.From)/req.Interval.Duration() <= maxStatsBuckets, "interval",
+		fmt.Sprintf("must split the range into at most %d buckets", maxStatsBuckets), customerrors.ErrInvalidStatsRange)
+
+	return v.err()
+}
+
+func validateEraseViews(req domain.EraseViews) error {
+	var v validator
+
+	v.subject(req.Subject)
+
+	return v.err()
+}
+
+func validateExportViews(req domain.ExportViews) error {
+	var v validator
+
+	v.subject(req.Subject)
+	v.check(req.Format == domain.ExportFormatNDJSON || req.Format == domain.ExportFormatCSV, "format",
+		"must be ndjson or csv", customerrors.ErrInvalidExportFormat)
+
+	return v.err()
+}
@@ -0,0 +1,274 @@
+//go:build !integration
+
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCreateView(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	companyID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		request domain.CreateView
+		fields  []string
+		wantErr []error
+	}{
+		{
+			name:    "Valid",
+			request: domain.CreateView{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "key"},
+		},
+		{
+			name:    "Empty resume id",
+			request: domain.CreateView{CompanyID: companyID},
+			fields:  []string{"resume_id"},
+			wantErr: []error{customerrors.ErrInvalidResumeID},
+		},
+		{
+			name:    "Short resume id",
+			request: domain.CreateView{ResumeID: resumeID[:23], CompanyID: companyID},
+			fields:  []string{"resume_id"},
+			wantErr: []error{customerrors.ErrInvalidResumeID},
+		},
+		{
+			name:    "Long resume id",
+			request: domain.CreateView{ResumeID: resumeID + "0", CompanyID: companyID},
+			fields:  []string{"resume_id"},
+			wantErr: []error{customerrors.ErrInvalidResumeID},
+		},
+		{
+			name:    "Non hex resume id",
+			request: domain.CreateView{ResumeID: "zz30e5f1a6b1f2c3d4e5f6a7", CompanyID: companyID},
+			fields:  []string{"resume_id"},
+			wantErr: []error{customerrors.ErrInvalidResumeID},
+		},
+		{
+			name:    "Malformed company id",
+			request: domain.CreateView{ResumeID: resumeID, CompanyID: "company"},
+			fields:  []string{"company_id"},
+			wantErr: []error{customerrors.ErrInvalidCompanyID},
+		},
+		{
+			name: "Too long idempotency key",
+			request: domain.CreateView{
+				ResumeID:       resumeID,
+				CompanyID:      companyID,
+				IdempotencyKey: strings.Repeat("k", maxIdempotencyKeyLength+1),
+			},
+			fields:  []string{"idempotency_key"},
+			wantErr: []error{customerrors.ErrInvalidIdempotencyKey},
+		},
+		{
+			name: "Viewer context",
+			request: domain.CreateView{ResumeID: resumeID, CompanyID: companyID, Viewer: domain.ViewerContext{
+				Source:       domain.ViewSourceSearch,
+				ViewerUserID: "user",
+				UserAgent:    "Mozilla/5.0",
+				Referrer:     "https://example.com/search?q=go",
+				Metadata:     map[string]string{"campaign": "spring"},
+			}},
+		},
+		{
+			name: "Invalid viewer context",
+			request: domain.CreateView{ResumeID: resumeID, CompanyID: companyID, Viewer: domain.ViewerContext{
+				Source:       "billboard",
+				ViewerUserID: strings.Repeat("u", maxViewerUserIDLength+1),
+				UserAgent:    strings.Repeat("a", maxUserAgentLength+1),
+				Referrer:     "/search",
+				Metadata:     map[string]string{"": "empty key"},
+			}},
+			fields: []string{"source", "viewer_user_id", "user_agent", "referrer", "metadata"},
+			wantErr: []error{
+				customerrors.ErrInvalidViewerContext, customerrors.ErrInvalidViewerContext,
+				customerrors.ErrInvalidViewerContext, customerrors.ErrInvalidViewerContext,
+				customerrors.ErrInvalidViewerContext,
+			},
+		},
+		{
+			name:    "Every invalid field is reported",
+			request: domain.CreateView{ResumeID: "resume"},
+			fields:  []string{"resume_id", "company_id"},
+			wantErr: []error{customerrors.ErrInvalidResumeID, customerrors.ErrInvalidCompanyID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateCreateView(tt.request)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationErr *customerrors.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+
+			fields := make([]string, 0, len(validationErr.Violations))
+			for _, v := range validationErr.Violations {
+				fields = append(fields, v.Field)
+			}
+
+			assert.Equal(t, tt.fields, fields)
+
+			for _, wantErr := range tt.wantErr {
+				assert.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateViewStats(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	to := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request domain.ViewStats
+		fields  []string
+	}{
+		{
+			name:    "Valid",
+			request: domain.ViewStats{ResumeID: resumeID, From: to.Add(-time.Hour), To: to},
+		},
+		{
+			name:    "Invalid resume id",
+			request: domain.ViewStats{ResumeID: "resume", From: to.Add(-time.Hour), To: to},
+			fields:  []string{"resume_id"},
+		},
+		{
+			name:    "Empty range",
+			request: domain.ViewStats{ResumeID: resumeID, From: to, To: to},
+			fields:  []string{"from"},
+		},
+		{
+			name: "Too many buckets",
+			request: domain.ViewStats{
+				ResumeID: resumeID,
+				From:     to.Add(-(maxStatsBuckets + 1) * time.Hour),
+				To:       to,
+				Interval: domain.StatsIntervalHour,
+			},
+			fields: []string{"interval"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateViewStats(tt.request)
+			if tt.fields == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationErr *customerrors.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+
+			for i, v := range validationErr.Violations {
+				assert.Equal(t, tt.fields[i], v.Field)
+			}
+
+			assert.Len(t, validationErr.Violations, len(tt.fields))
+		})
+	}
+}
+
+func TestValidateExportViews(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	companyID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		request domain.ExportViews
+		fields  []string
+		wantErr error
+	}{
+		{
+			name:    "Resume",
+			request: domain.ExportViews{Subject: domain.ViewSubject{ResumeID: resumeID}, Format: domain.ExportFormatCSV},
+		},
+		{
+			name: "Company",
+			request: domain.ExportViews{
+				Subject: domain.ViewSubject{CompanyID: companyID},
+				Format:  domain.ExportFormatNDJSON,
+			},
+		},
+		{
+			name:    "No subject",
+			request: domain.ExportViews{Format: domain.ExportFormatCSV},
+			fields:  []string{"resume_id"},
+			wantErr: customerrors.ErrInvalidSubject,
+		},
+		{
+			name: "Both subjects",
+			request: domain.ExportViews{
+				Subject: domain.ViewSubject{ResumeID: resumeID, CompanyID: companyID},
+				Format:  domain.ExportFormatCSV,
+			},
+			fields:  []string{"company_id"},
+			wantErr: customerrors.ErrInvalidSubject,
+		},
+		{
+			name: "Malformed company id",
+			request: domain.ExportViews{
+				Subject: domain.ViewSubject{CompanyID: "company"},
+				Format:  domain.ExportFormatCSV,
+			},
+			fields:  []string{"company_id"},
+			wantErr: customerrors.ErrInvalidCompanyID,
+		},
+		{
+			name:    "Unknown format",
+			request: domain.ExportViews{Subject: domain.ViewSubject{ResumeID: resumeID}, Format: "xml"},
+			fields:  []string{"format"},
+			wantErr: customerrors.ErrInvalidExportFormat,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := validateExportViews(tt.request)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+
+				return
+			}
+
+			var validationErr *customerrors.ValidationError
+			require.True(t, errors.As(err, &validationErr))
+
+			fields := make([]string, 0, len(validationErr.Violations))
+			for _, v := range validationErr.Violations {
+				fields = append(fields, v.Field)
+			}
+
+			assert.Equal(t, tt.fields, fields)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -3,7 +3,13 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/abuse"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/ratelimit"
 	"github.com/Verce11o/resume-view/resume-view/internal/models"
 	"github.com/google/uuid"
 	"go.opentelemetry.io/otel/codes"
@@ -11,48 +17,495 @@ import (
 	"go.uber.org/zap"
 )
 
+const (
+	maxIdempotencyKeyLength = 128
+	maxViewerUserIDLength   = 128
+	maxUserAgentLength      = 512
+	maxReferrerLength       = 2048
+	maxMetadataEntries      = 16
+	maxMetadataKeyLength    = 64
+	maxMetadataValueLength  = 256
+	MaxBatchSize            = 500
+
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	defaultStatsRange   = 30 * 24 * time.Hour
+	maxStatsBuckets     = 1000
+	defaultTopCompanies = 10
+	maxTopCompanies     = 100
+)
+
+// Outcomes of a view request, as reported to ViewMetrics.
+const (
+	ViewOutcomeCounted     = "counted"
+	ViewOutcomeCollapsed   = "collapsed"
+	ViewOutcomeReplayed    = "replayed"
+	ViewOutcomeRateLimited = "rate_limited"
+)
+
 type ViewRepository interface {
-	CreateView(ctx context.Context, resumeID, companyID string) (uuid.UUID, error)
-	ListResumeView(ctx context.Context, cursor, resumeID string) (models.ViewList, error)
+	CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error)
+	CreateViews(ctx context.Context, reqs []domain.CreateView) ([]models.CreatedView, error)
+	ReplayedViews(ctx context.Context, reqs []domain.CreateView) (map[int]uuid.UUID, error)
+	ListResumeView(ctx context.Context, req domain.ListViews) (models.ViewList, error)
+	ListCompanyViews(ctx context.Context, req domain.ListCompanyViews) (models.CompanyViewList, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	GetResumeViewStats(ctx context.Context, req domain.ViewStats) (models.ViewStats, error)
+	EraseViews(ctx context.Context, req domain.EraseViews) (models.Erasure, error)
+	ExportViews(ctx context.Context, subject domain.ViewSubject, fn func(view models.View) error) (int64, error)
+	WithRetentionLock(ctx context.Context, wait bool, fn func(ctx context.Context) error) (bool, error)
 }
 
 type ViewMetrics interface {
-	Inc(resumeID string)
+	IncView(outcome string)
+	IncSuspiciousView(rule string)
+}
+
+// RateLimiter grants up to n of the requested tokens of key.
+type RateLimiter interface {
+	Allow(ctx context.Context, key string, n int) (ratelimit.Result, error)
+}
+
+// AbuseDetector returns the abuse rules each of a company's views breaks, counting the views before it.
+// Checking records nothing: only the views the repository counted are recorded, so replays and collapsed
+// views do not push a company over its limits.
+type AbuseDetector interface {
+	Check(ctx context.Context, companyID string, views []abuse.View) ([][]string, error)
+	Record(ctx context.Context, companyID string, view abuse.View) error
 }
 
 type ViewService struct {
-	log        *zap.SugaredLogger
-	tracer     trace.Tracer
-	repo       ViewRepository
-	viewMetric ViewMetrics
+	log         *zap.SugaredLogger
+	tracer      trace.Tracer
+	repo        ViewRepository
+	viewMetric  ViewMetrics
+	dedupWindow time.Duration
+	feed        *feed.Hub
+	limiter     RateLimiter
+	detector    AbuseDetector
+	archiveDir  string
+}
+
+type Option func(*ViewService)
+
+// WithDedupWindow counts at most one view per company per resume within the window.
+func WithDedupWindow(window time.Duration) Option {
+	return func(v *ViewService) {
+		v.dedupWindow = window
+	}
+}
+
+// WithFeed publishes counted views to hub instead of a hub with the default limits.
+func WithFeed(hub *feed.Hub) Option {
+	return func(v *ViewService) {
+		v.feed = hub
+	}
+}
+
+// WithRateLimiter limits how many views each company may record. Without it views are not limited.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(v *ViewService) {
+		v.limiter = limiter
+	}
+}
+
+// WithAbuseDetector stores the views breaking an abuse rule as suspicious, leaving them out of totals,
+// stats and the live feed. Without it no view is suspicious.
+func WithAbuseDetector(detector AbuseDetector) Option {
+	return func(v *ViewService) {
+		v.detector = detector
+	}
+}
+
+// WithArchiveDir makes erasures redact the retention archives in dir as well. Every replica must mount the
+// same dir, since retention runs on any of them and an erasure only redacts the dir of the replica serving it.
+func WithArchiveDir(dir string) Option {
+	return func(v *ViewService) {
+		v.archiveDir = dir
+	}
 }
 
-func NewViewService(log *zap.SugaredLogger, tracer trace.Tracer, repo ViewRepository, metric ViewMetrics) *ViewService {
-	return &ViewService{log: log, tracer: tracer, repo: repo, viewMetric: metric}
+func NewViewService(log *zap.SugaredLogger, tracer trace.Tracer, repo ViewRepository, metric ViewMetrics,
+	opts ...Option) *ViewService {
+	v := &ViewService{
+		log:        log,
+		tracer:     tracer,
+		repo:       repo,
+		viewMetric: metric,
+		feed:       feed.NewHub(feed.DefaultBufferSize, feed.DefaultMaxSubscribers),
+	}
+
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	return v
 }
 
-func (v *ViewService) CreateView(ctx context.Context, resumeID, companyID string) (uuid.UUID, error) {
+// CreateView records a view. Replays of an already used idempotency key return the original view,
+// and views inside the dedup window are collapsed into the last counted one. Neither is counted again.
+// Views of a company over its rate limit are rejected with a customerrors.RateLimitError, and views
+// breaking an abuse rule are stored as suspicious. Replays are looked up first, so they neither spend the
+// rate limit nor get rejected by it.
+func (v *ViewService) CreateView(ctx context.Context, req domain.CreateView) (models.CreatedView, error) {
 	ctx, span := v.tracer.Start(ctx, "viewService.CreateView")
 	defer span.End()
 
-	viewID, err := v.repo.CreateView(ctx, resumeID, companyID)
+	if err := validateCreateView(req); err != nil {
+		return models.CreatedView{}, err
+	}
+
+	replayed, err := v.repo.ReplayedViews(ctx, []domain.CreateView{req})
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.CreatedView{}, fmt.Errorf("failed to create view: %w", err)
+	}
+
+	if viewID, ok := replayed[0]; ok {
+		v.viewMetric.IncView(ViewOutcomeReplayed)
+
+		return models.CreatedView{ID: viewID, Replayed: true}, nil
+	}
+
+	if allowed, retryAfter := v.allow(ctx, req.CompanyID, 1); allowed == 0 {
+		v.viewMetric.IncView(ViewOutcomeRateLimited)
+
+		return models.CreatedView{}, &customerrors.RateLimitError{RetryAfter: retryAfter}
+	}
+
+	req.DedupWindow = v.dedupWindow
+	broken := v.checkAbuse(ctx, []domain.CreateView{req})[0]
+	req.Suspicious = len(broken) > 0
+
+	view, err := v.repo.CreateView(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.CreatedView{}, fmt.Errorf("failed to create view: %w", err)
+	}
+
+	v.viewMetric.IncView(viewOutcome(view))
+
+	if !view.Counted() {
+		v.log.Debugf("view %s not counted, replayed: %t, collapsed: %t", view.ID, view.Replayed, view.Collapsed)
+
+		return view, nil
+	}
+
+	v.recordAbuse(ctx, req)
+
+	if req.Suspicious {
+		v.flag(view, broken)
+
+		return view, nil
+	}
+
+	v.feed.Publish(newView(req, view))
+
+	return view, nil
+}
+
+// BatchCreateViews records up to MaxBatchSize views at once. Invalid and rate limited items are reported
+// in their result and do not prevent the rest of the batch from being written. Like in CreateView, replays
+// are not rate limited.
+func (v *ViewService) BatchCreateViews(ctx context.Context,
+	reqs []domain.CreateView) ([]models.CreateViewResult, error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.BatchCreateViews")
+	defer span.End()
+
+	if len(reqs) > MaxBatchSize {
+		return nil, customerrors.ErrBatchTooLarge
+	}
+
+	results := make([]models.CreateViewResult, len(reqs))
+	valid := make([]domain.CreateView, 0, len(reqs))
+	positions := make([]int, 0, len(reqs))
+
+	for i, req := range reqs {
+		if err := validateCreateView(req); err != nil {
+			results[i].Err = err
+
+			continue
+		}
+
+		req.DedupWindow = v.dedupWindow
+		valid = append(valid, req)
+		positions = append(positions, i)
+	}
+
+	valid, positions, err := v.replayBatch(ctx, valid, positions, results)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, fmt.Errorf("failed to create views: %w", err)
+	}
+
+	valid, positions = v.limitBatch(ctx, valid, positions, results)
+
+	if len(valid) == 0 {
+		return results, nil
+	}
+
+	broken := v.checkAbuse(ctx, valid)
+	for i := range valid {
+		valid[i].Suspicious = len(broken[i]) > 0
+	}
+
+	views, err := v.repo.CreateViews(ctx, valid)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return nil, fmt.Errorf("failed to create views: %w", err)
+	}
+
+	for i, view := range views {
+		results[positions[i]].View = view
+		v.viewMetric.IncView(viewOutcome(view))
+
+		if !view.Counted() {
+			continue
+		}
+
+		v.recordAbuse(ctx, valid[i])
+
+		if valid[i].Suspicious {
+			v.flag(view, broken[i])
+		} else {
+			v.feed.Publish(newView(valid[i], view))
+		}
+	}
+
+	return results, nil
+}
+
+// replayBatch drops the items whose idempotency key was already used, reporting the original view in
+// results.
+func (v *ViewService) replayBatch(ctx context.Context, reqs []domain.CreateView, positions []int,
+	results []models.CreateViewResult) ([]domain.CreateView, []int, error) {
+	replayed, err := v.repo.ReplayedViews(ctx, reqs)
+	if err != nil || len(replayed) == 0 {
+		return reqs, positions, err //nolint:wrapcheck // wrapped by BatchCreateViews
+	}
+
+	pending := reqs[:0]
+	pendingPositions := positions[:0]
+
+	for i, req := range reqs {
+		if viewID, ok := replayed[i]; ok {
+			v.viewMetric.IncView(ViewOutcomeReplayed)
+			results[positions[i]].View = models.CreatedView{ID: viewID, Replayed: true}
+
+			continue
+		}
+
+		pending = append(pending, req)
+		pendingPositions = append(pendingPositions, positions[i])
+	}
+
+	return pending, pendingPositions, nil
+}
+
+// limitBatch drops the views over their company's rate limit, reporting them in results. Within a company
+// the earliest items of the batch are the ones let through.
+func (v *ViewService) limitBatch(ctx context.Context, reqs []domain.CreateView, positions []int,
+	results []models.CreateViewResult) ([]domain.CreateView, []int) {
+	if v.limiter == nil {
+		return reqs, positions
+	}
+
+	requested := make(map[string]int)
+	for _, req := range reqs {
+		requested[req.CompanyID]++
+	}
+
+	granted := make(map[string]int, len(requested))
+	retryAfter := make(map[string]time.Duration, len(requested))
+
+	for companyID, n := range requested {
+		granted[companyID], retryAfter[companyID] = v.allow(ctx, companyID, n)
+	}
+
+	allowed := reqs[:0]
+	allowedPositions := positions[:0]
+
+	for i, req := range reqs {
+		if granted[req.CompanyID] == 0 {
+			v.viewMetric.IncView(ViewOutcomeRateLimited)
+			results[positions[i]].Err = &customerrors.RateLimitError{RetryAfter: retryAfter[req.CompanyID]}
+
+			continue
+		}
+
+		granted[req.CompanyID]--
+		allowed = append(allowed, req)
+		allowedPositions = append(allowedPositions, positions[i])
+	}
+
+	return allowed, allowedPositions
+}
+
+// allow takes n tokens of the company's rate limit and returns how many were granted. If the limiter
+// fails, the views are let through rather than lost.
+func (v *ViewService) allow(ctx context.Context, companyID string, n int) (int, time.Duration) {
+	if v.limiter == nil {
+		return n, 0
+	}
+
+	result, err := v.limiter.Allow(ctx, companyID, n)
+	if err != nil {
+		v.log.Warnf("failed to check rate limit of company %s, allowing: %v", companyID, err)
+
+		return n, 0
+	}
+
+	return result.Allowed, result.RetryAfter
+}
+
+// checkAbuse returns the abuse rules each view breaks, checking the views of a company in the order they
+// come. If the detector fails, the company's views are not flagged rather than hidden from the resume owner.
+func (v *ViewService) checkAbuse(ctx context.Context, reqs []domain.CreateView) [][]string {
+	broken := make([][]string, len(reqs))
+	if v.detector == nil {
+		return broken
+	}
+
+	positions := make(map[string][]int)
+	for i, req := range reqs {
+		positions[req.CompanyID] = append(positions[req.CompanyID], i)
+	}
+
+	for companyID, indexes := range positions {
+		views := make([]abuse.View, len(indexes))
+		for j, i := range indexes {
+			views[j] = abuse.View{ResumeID: reqs[i].ResumeID, Key: reqs[i].IdempotencyKey}
+		}
+
+		companyBroken, err := v.detector.Check(ctx, companyID, views)
+		if err != nil {
+			v.log.Warnf("failed to check abuse rules of company %s, not flagging: %v", companyID, err)
+
+			continue
+		}
+
+		for j, i := range indexes {
+			broken[i] = companyBroken[j]
+		}
+	}
+
+	return broken
+}
+
+// recordAbuse feeds a counted view to the abuse detector. A failure only lets the company's next views
+// through unflagged, so it is logged.
+func (v *ViewService) recordAbuse(ctx context.Context, req domain.CreateView) {
+	if v.detector == nil {
+		return
+	}
+
+	view := abuse.View{ResumeID: req.ResumeID, Key: req.IdempotencyKey}
+	if err := v.detector.Record(ctx, req.CompanyID, view); err != nil {
+		v.log.Warnf("failed to record view of company %s for abuse rules: %v", req.CompanyID, err)
+	}
+}
+
+// flag reports a stored suspicious view with the abuse rules it broke.
+func (v *ViewService) flag(view models.CreatedView, broken []string) {
+	v.log.Infof("view %s flagged as suspicious, broken rules: %v", view.ID, broken)
+
+	for _, rule := range broken {
+		v.viewMetric.IncSuspiciousView(rule)
+	}
+}
+
+// WatchResumeViews subscribes to the views of a resume counted from now on, whether they arrive over
+// gRPC or Kafka. The caller must close the subscription.
+func (v *ViewService) WatchResumeViews(ctx context.Context, resumeID string) (*feed.Subscription, error) {
+	_, span := v.tracer.Start(ctx, "viewService.WatchResumeViews")
+	defer span.End()
+
+	if err := validateResumeID(resumeID); err != nil {
+		return nil, err
+	}
+
+	sub, err := v.feed.Subscribe(resumeID)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 
-		return uuid.Nil, fmt.Errorf("failed to create view: %w", err)
+		return nil, fmt.Errorf("failed to subscribe to resume views: %w", err)
+	}
+
+	return sub, nil
+}
+
+func viewOutcome(view models.CreatedView) string {
+	switch {
+	case view.Replayed:
+		return ViewOutcomeReplayed
+	case view.Collapsed:
+		return ViewOutcomeCollapsed
+	default:
+		return ViewOutcomeCounted
+	}
+}
+
+// newView builds the feed entry of a counted view. The company id was checked by validateCreateView.
+func newView(req domain.CreateView, view models.CreatedView) models.View {
+	return models.View{
+		ID:           view.ID,
+		ResumeID:     req.ResumeID,
+		CompanyID:    uuid.MustParse(req.CompanyID),
+		ViewedAt:     view.ViewedAt,
+		Source:       req.Viewer.Source,
+		ViewerUserID: req.Viewer.ViewerUserID,
+		UserAgent:    req.Viewer.UserAgent,
+		Referrer:     req.Viewer.Referrer,
+		Metadata:     req.Viewer.Metadata,
 	}
+}
+
+func (v *ViewService) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.DeleteExpiredIdempotencyKeys")
+	defer span.End()
 
-	v.viewMetric.Inc(resumeID)
+	deleted, err := v.repo.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 
-	return viewID, nil
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return deleted, nil
 }
 
-func (v *ViewService) ListResumeView(ctx context.Context, cursor, resumeID string) (models.ViewList, error) {
+// ListResumeView pages through the views of a resume, newest first unless asked otherwise. The page size
+// defaults to 20 and is capped at 100.
+func (v *ViewService) ListResumeView(ctx context.Context, req domain.ListViews) (models.ViewList, error) {
 	ctx, span := v.tracer.Start(ctx, "viewService.ListResumeView")
 	defer span.End()
 
-	viewList, err := v.repo.ListResumeView(ctx, cursor, resumeID)
+	if req.Sort == "" {
+		req.Sort = domain.SortNewestFirst
+	}
+
+	if req.PageSize <= 0 {
+		req.PageSize = defaultPageSize
+	}
+
+	req.PageSize = min(req.PageSize, maxPageSize)
+
+	if err := validateListViews(req); err != nil {
+		return models.ViewList{}, err
+	}
+
+	viewList, err := v.repo.ListResumeView(ctx, req)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -62,3 +515,69 @@ func (v *ViewService) ListResumeView(ctx context.Context, cursor, resumeID strin
 
 	return viewList, nil
 }
+
+// ListCompanyViews pages through the resumes a company has viewed, most recently viewed first.
+func (v *ViewService) ListCompanyViews(ctx context.Context, req domain.ListCompanyViews) (models.CompanyViewList,
+	error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.ListCompanyViews")
+	defer span.End()
+
+	if req.PageSize <= 0 {
+		req.PageSize = defaultPageSize
+	}
+
+	req.PageSize = min(req.PageSize, maxPageSize)
+
+	if err := validateCompanyID(req.CompanyID); err != nil {
+		return models.CompanyViewList{}, err
+	}
+
+	list, err := v.repo.ListCompanyViews(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.CompanyViewList{}, fmt.Errorf("failed to list company views: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetResumeViewStats aggregates views of a resume over [From, To). Zero values fall back to the last
+// 30 days bucketed by day and the top 10 companies.
+func (v *ViewService) GetResumeViewStats(ctx context.Context, req domain.ViewStats) (models.ViewStats, error) {
+	ctx, span := v.tracer.Start(ctx, "viewService.GetResumeViewStats")
+	defer span.End()
+
+	if req.To.IsZero() {
+		req.To = time.Now()
+	}
+
+	if req.From.IsZero() {
+		req.From = req.To.Add(-defaultStatsRange)
+	}
+
+	if req.Interval == "" {
+		req.Interval = domain.StatsIntervalDay
+	}
+
+	if req.TopCompanies <= 0 {
+		req.TopCompanies = defaultTopCompanies
+	}
+
+	req.TopCompanies = min(req.TopCompanies, maxTopCompanies)
+
+	if err := validateViewStats(req); err != nil {
+		return models.ViewStats{}, err
+	}
+
+	stats, err := v.repo.GetResumeViewStats(ctx, req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return models.ViewStats{}, fmt.Errorf("failed to get resume view stats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,737 @@
+//go:build !integration
+
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Verce11o/resume-view/resume-view/internal/domain"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/abuse"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/customerrors"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/feed"
+	"github.com/Verce11o/resume-view/resume-view/internal/lib/ratelimit"
+	"github.com/Verce11o/resume-view/resume-view/internal/models"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+)
+
+type fakeViewRepository struct {
+	ViewRepository
+	created         models.CreatedView
+	err             error
+	calls           int
+	last            domain.CreateView
+	lastBatch       []domain.CreateView
+	lastStats       domain.ViewStats
+	lastList        domain.ListViews
+	lastCompanyList domain.ListCompanyViews
+	replayed        map[string]uuid.UUID
+}
+
+func (r *fakeViewRepository) ReplayedViews(_ context.Context, reqs []domain.CreateView) (map[int]uuid.UUID, error) {
+	replayed := make(map[int]uuid.UUID)
+
+	for i, req := range reqs {
+		if viewID, ok := r.replayed[req.IdempotencyKey]; ok && req.IdempotencyKey != "" {
+			replayed[i] = viewID
+		}
+	}
+
+	return replayed, nil
+}
+
+func (r *fakeViewRepository) ListCompanyViews(_ context.Context,
+	req domain.ListCompanyViews) (models.CompanyViewList, error) {
+	r.calls++
+	r.lastCompanyList = req
+
+	return models.CompanyViewList{}, r.err
+}
+
+func (r *fakeViewRepository) ListResumeView(_ context.Context, req domain.ListViews) (models.ViewList, error) {
+	r.calls++
+	r.lastList = req
+
+	return models.ViewList{}, r.err
+}
+
+func (r *fakeViewRepository) CreateView(_ context.Context, req domain.CreateView) (models.CreatedView, error) {
+	r.calls++
+	r.last = req
+
+	return r.created, r.err
+}
+
+func (r *fakeViewRepository) CreateViews(_ context.Context, reqs []domain.CreateView) ([]models.CreatedView, error) {
+	r.calls++
+	r.lastBatch = reqs
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	views := make([]models.CreatedView, 0, len(reqs))
+	for i := range reqs {
+		views = append(views, models.CreatedView{ID: uuid.New(), Collapsed: i%2 == 1})
+	}
+
+	return views, nil
+}
+
+func (r *fakeViewRepository) GetResumeViewStats(_ context.Context, req domain.ViewStats) (models.ViewStats, error) {
+	r.calls++
+	r.lastStats = req
+
+	return models.ViewStats{}, r.err
+}
+
+type fakeViewMetrics struct {
+	counts     map[string]int
+	suspicious map[string]int
+}
+
+func (m *fakeViewMetrics) IncView(outcome string) {
+	if m.counts == nil {
+		m.counts = make(map[string]int)
+	}
+
+	m.counts[outcome]++
+}
+
+func (m *fakeViewMetrics) IncSuspiciousView(rule string) {
+	if m.suspicious == nil {
+		m.suspicious = make(map[string]int)
+	}
+
+	m.suspicious[rule]++
+}
+
+func newTestViewService(repo ViewRepository, metrics ViewMetrics, opts ...Option) *ViewService {
+	return NewViewService(zap.NewNop().Sugar(), noop.NewTracerProvider().Tracer("test"), repo, metrics, opts...)
+}
+
+func TestViewService_CreateView(t *testing.T) {
+	t.Parallel()
+
+	viewID := uuid.New()
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+
+	tests := []struct {
+		name      string
+		request   domain.CreateView
+		repo      *fakeViewRepository
+		response  models.CreatedView
+		repoCalls int
+		outcomes  map[string]int
+		wantErr   error
+	}{
+		{
+			name:      "New view is counted",
+			request:   domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString(), IdempotencyKey: "key"},
+			repo:      &fakeViewRepository{created: models.CreatedView{ID: viewID}},
+			response:  models.CreatedView{ID: viewID},
+			repoCalls: 1,
+			outcomes:  map[string]int{ViewOutcomeCounted: 1},
+		},
+		{
+			name:      "Replayed view is not counted",
+			request:   domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString(), IdempotencyKey: "key"},
+			repo:      &fakeViewRepository{created: models.CreatedView{ID: viewID, Replayed: true}},
+			response:  models.CreatedView{ID: viewID, Replayed: true},
+			repoCalls: 1,
+			outcomes:  map[string]int{ViewOutcomeReplayed: 1},
+		},
+		{
+			name:      "Collapsed view is not counted",
+			request:   domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()},
+			repo:      &fakeViewRepository{created: models.CreatedView{ID: viewID, Collapsed: true}},
+			response:  models.CreatedView{ID: viewID, Collapsed: true},
+			repoCalls: 1,
+			outcomes:  map[string]int{ViewOutcomeCollapsed: 1},
+		},
+		{
+			name: "Too long idempotency key",
+			request: domain.CreateView{
+				ResumeID:       resumeID,
+				CompanyID:      uuid.NewString(),
+				IdempotencyKey: strings.Repeat("k", maxIdempotencyKeyLength+1),
+			},
+			repo:    &fakeViewRepository{},
+			wantErr: customerrors.ErrInvalidIdempotencyKey,
+		},
+		{
+			name:      "Repository error",
+			request:   domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()},
+			repo:      &fakeViewRepository{err: assert.AnError},
+			repoCalls: 1,
+			wantErr:   assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			metrics := &fakeViewMetrics{}
+			srv := newTestViewService(tt.repo, metrics, WithDedupWindow(30*time.Minute))
+
+			resp, err := srv.CreateView(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.response, resp)
+			assert.Equal(t, tt.repoCalls, tt.repo.calls)
+			assert.Equal(t, tt.outcomes, metrics.counts)
+
+			if tt.repoCalls > 0 {
+				assert.Equal(t, 30*time.Minute, tt.repo.last.DedupWindow)
+			}
+		})
+	}
+}
+
+func TestViewService_GetResumeViewStats(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	to := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request domain.ViewStats
+		want    domain.ViewStats
+		wantErr error
+	}{
+		{
+			name:    "Defaults",
+			request: domain.ViewStats{ResumeID: resumeID, To: to},
+			want: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         to.Add(-defaultStatsRange),
+				To:           to,
+				Interval:     domain.StatsIntervalDay,
+				TopCompanies: defaultTopCompanies,
+			},
+		},
+		{
+			name: "Top companies are capped",
+			request: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         to.Add(-time.Hour),
+				To:           to,
+				Interval:     domain.StatsIntervalHour,
+				TopCompanies: maxTopCompanies + 1,
+			},
+			want: domain.ViewStats{
+				ResumeID:     resumeID,
+				From:         to.Add(-time.Hour),
+				To:           to,
+				Interval:     domain.StatsIntervalHour,
+				TopCompanies: maxTopCompanies,
+			},
+		},
+		{
+			name:    "Inverted range",
+			request: domain.ViewStats{ResumeID: resumeID, From: to, To: to.Add(-time.Hour)},
+			wantErr: customerrors.ErrInvalidStatsRange,
+		},
+		{
+			name: "Too many buckets",
+			request: domain.ViewStats{
+				ResumeID: resumeID,
+				From:     to.Add(-(maxStatsBuckets + 1) * time.Hour),
+				To:       to,
+				Interval: domain.StatsIntervalHour,
+			},
+			wantErr: customerrors.ErrInvalidStatsRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeViewRepository{}
+			srv := newTestViewService(repo, &fakeViewMetrics{})
+
+			_, err := srv.GetResumeViewStats(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, repo.lastStats)
+		})
+	}
+}
+
+func TestViewService_BatchCreateViews(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	valid := domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()}
+
+	t.Run("Invalid items are reported without failing the batch", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeViewRepository{}
+		metrics := &fakeViewMetrics{}
+		srv := newTestViewService(repo, metrics)
+
+		results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{
+			valid,
+			{ResumeID: resumeID, CompanyID: "not-a-uuid"},
+			valid,
+			{ResumeID: "", CompanyID: uuid.NewString()},
+		})
+
+		assert.NoError(t, err)
+		assert.Len(t, results, 4)
+		assert.Len(t, repo.lastBatch, 2)
+
+		assert.NoError(t, results[0].Err)
+		assert.NotEqual(t, uuid.Nil, results[0].View.ID)
+		assert.ErrorIs(t, results[1].Err, customerrors.ErrInvalidCompanyID)
+		assert.NoError(t, results[2].Err)
+		assert.True(t, results[2].View.Collapsed)
+		assert.ErrorIs(t, results[3].Err, customerrors.ErrInvalidResumeID)
+
+		assert.Equal(t, map[string]int{ViewOutcomeCounted: 1, ViewOutcomeCollapsed: 1}, metrics.counts)
+	})
+
+	t.Run("All items invalid", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeViewRepository{}
+		srv := newTestViewService(repo, &fakeViewMetrics{})
+
+		results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{{ResumeID: resumeID}})
+
+		assert.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, customerrors.ErrInvalidCompanyID)
+		assert.Zero(t, repo.calls)
+	})
+
+	t.Run("Batch too large", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestViewService(&fakeViewRepository{}, &fakeViewMetrics{})
+
+		_, err := srv.BatchCreateViews(context.Background(), make([]domain.CreateView, MaxBatchSize+1))
+
+		assert.ErrorIs(t, err, customerrors.ErrBatchTooLarge)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newTestViewService(&fakeViewRepository{err: assert.AnError}, &fakeViewMetrics{})
+
+		_, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{valid})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestViewService_WatchResumeViews(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	companyID := uuid.New()
+	viewedAt := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+
+	repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New(), ViewedAt: viewedAt}}
+	srv := newTestViewService(repo, &fakeViewMetrics{}, WithFeed(feed.NewHub(4, 4)))
+
+	_, err := srv.WatchResumeViews(context.Background(), "")
+	assert.ErrorIs(t, err, customerrors.ErrInvalidResumeID)
+
+	sub, err := srv.WatchResumeViews(context.Background(), resumeID)
+	require.NoError(t, err)
+
+	defer sub.Close()
+
+	_, err = srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: companyID.String()})
+	require.NoError(t, err)
+
+	assert.Equal(t, models.View{
+		ID:        repo.created.ID,
+		ResumeID:  resumeID,
+		CompanyID: companyID,
+		ViewedAt:  viewedAt,
+	}, <-sub.Views())
+
+	repo.created.Collapsed = true
+
+	_, err = srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: companyID.String()})
+	require.NoError(t, err)
+
+	results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{
+		{ResumeID: resumeID, CompanyID: companyID.String()},
+		{ResumeID: resumeID, CompanyID: companyID.String()},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, results[0].View.ID, (<-sub.Views()).ID, "only the counted batch item is published")
+	assert.Empty(t, sub.Views())
+}
+
+func TestViewService_ListResumeView(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	companyID := uuid.NewString()
+	to := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request domain.ListViews
+		want    domain.ListViews
+		wantErr error
+	}{
+		{
+			name:    "Defaults",
+			request: domain.ListViews{ResumeID: resumeID},
+			want:    domain.ListViews{ResumeID: resumeID, Sort: domain.SortNewestFirst, PageSize: defaultPageSize},
+		},
+		{
+			name: "Filters are passed through",
+			request: domain.ListViews{
+				ResumeID:  resumeID,
+				CompanyID: companyID,
+				From:      to.Add(-time.Hour),
+				To:        to,
+				Sort:      domain.SortOldestFirst,
+				PageSize:  5,
+			},
+			want: domain.ListViews{
+				ResumeID:  resumeID,
+				CompanyID: companyID,
+				From:      to.Add(-time.Hour),
+				To:        to,
+				Sort:      domain.SortOldestFirst,
+				PageSize:  5,
+			},
+		},
+		{
+			name:    "Page size is capped",
+			request: domain.ListViews{ResumeID: resumeID, PageSize: maxPageSize + 1},
+			want:    domain.ListViews{ResumeID: resumeID, Sort: domain.SortNewestFirst, PageSize: maxPageSize},
+		},
+		{
+			name:    "Invalid resume id",
+			request: domain.ListViews{},
+			wantErr: customerrors.ErrInvalidResumeID,
+		},
+		{
+			name:    "Invalid company id",
+			request: domain.ListViews{ResumeID: resumeID, CompanyID: "company"},
+			wantErr: customerrors.ErrInvalidCompanyID,
+		},
+		{
+			name:    "Inverted range",
+			request: domain.ListViews{ResumeID: resumeID, From: to, To: to.Add(-time.Hour)},
+			wantErr: customerrors.ErrInvalidTimeRange,
+		},
+		{
+			name:    "Unknown sort order",
+			request: domain.ListViews{ResumeID: resumeID, Sort: "random"},
+			wantErr: customerrors.ErrInvalidSortOrder,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeViewRepository{}
+			srv := newTestViewService(repo, &fakeViewMetrics{})
+
+			_, err := srv.ListResumeView(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, repo.lastList)
+		})
+	}
+}
+
+func TestViewService_ListCompanyViews(t *testing.T) {
+	t.Parallel()
+
+	companyID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		request domain.ListCompanyViews
+		want    domain.ListCompanyViews
+		repoErr error
+		wantErr error
+	}{
+		{
+			name:    "Default page size",
+			request: domain.ListCompanyViews{CompanyID: companyID, Cursor: "cursor"},
+			want:    domain.ListCompanyViews{CompanyID: companyID, Cursor: "cursor", PageSize: defaultPageSize},
+		},
+		{
+			name:    "Page size is capped",
+			request: domain.ListCompanyViews{CompanyID: companyID, PageSize: maxPageSize + 1},
+			want:    domain.ListCompanyViews{CompanyID: companyID, PageSize: maxPageSize},
+		},
+		{
+			name:    "Invalid company id",
+			request: domain.ListCompanyViews{CompanyID: "company"},
+			wantErr: customerrors.ErrInvalidCompanyID,
+		},
+		{
+			name:    "Repository error",
+			request: domain.ListCompanyViews{CompanyID: companyID, PageSize: 5},
+			want:    domain.ListCompanyViews{CompanyID: companyID, PageSize: 5},
+			repoErr: customerrors.ErrInvalidCursor,
+			wantErr: customerrors.ErrInvalidCursor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := &fakeViewRepository{err: tt.repoErr}
+			srv := newTestViewService(repo, &fakeViewMetrics{})
+
+			_, err := srv.ListCompanyViews(context.Background(), tt.request)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, repo.lastCompanyList)
+		})
+	}
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(_ context.Context, _ string, _ int) (ratelimit.Result, error) {
+	return ratelimit.Result{}, assert.AnError
+}
+
+func TestViewService_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+	limit := ratelimit.Limit{Rate: 0.001, Burst: 2}
+
+	t.Run("Views over the limit are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := &fakeViewMetrics{}
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New()}}
+		limiter, err := ratelimit.NewMemoryLimiter(limit)
+		require.NoError(t, err)
+
+		srv := newTestViewService(repo, metrics, WithRateLimiter(limiter))
+
+		companyID := uuid.NewString()
+		req := domain.CreateView{ResumeID: resumeID, CompanyID: companyID}
+
+		for range limit.Burst {
+			_, err := srv.CreateView(context.Background(), req)
+			require.NoError(t, err)
+		}
+
+		_, err = srv.CreateView(context.Background(), req)
+
+		var rateLimitErr *customerrors.RateLimitError
+		require.ErrorAs(t, err, &rateLimitErr)
+		assert.ErrorIs(t, err, customerrors.ErrRateLimited)
+		assert.Positive(t, rateLimitErr.RetryAfter)
+		assert.Equal(t, limit.Burst, repo.calls)
+
+		_, err = srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]int{ViewOutcomeCounted: 3, ViewOutcomeRateLimited: 1}, metrics.counts)
+	})
+
+	t.Run("Batch keeps the earliest views of each company", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := &fakeViewMetrics{}
+		repo := &fakeViewRepository{}
+		limiter, err := ratelimit.NewMemoryLimiter(limit)
+		require.NoError(t, err)
+
+		srv := newTestViewService(repo, metrics, WithRateLimiter(limiter))
+
+		limited := uuid.NewString()
+		other := uuid.NewString()
+
+		results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{
+			{ResumeID: resumeID, CompanyID: limited},
+			{ResumeID: resumeID, CompanyID: other},
+			{ResumeID: resumeID, CompanyID: limited},
+			{ResumeID: resumeID, CompanyID: limited},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, repo.lastBatch, 3)
+		assert.NoError(t, results[0].Err)
+		assert.NoError(t, results[1].Err)
+		assert.NoError(t, results[2].Err)
+		assert.ErrorIs(t, results[3].Err, customerrors.ErrRateLimited)
+		assert.Equal(t, 1, metrics.counts[ViewOutcomeRateLimited])
+	})
+
+	t.Run("Replays are not rate limited", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := &fakeViewMetrics{}
+		viewID := uuid.New()
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New()}, replayed: map[string]uuid.UUID{
+			"retried": viewID,
+		}}
+		limiter, err := ratelimit.NewMemoryLimiter(limit)
+		require.NoError(t, err)
+
+		srv := newTestViewService(repo, metrics, WithRateLimiter(limiter))
+
+		companyID := uuid.NewString()
+
+		for range limit.Burst {
+			_, err := srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: companyID})
+			require.NoError(t, err)
+		}
+
+		retried := domain.CreateView{ResumeID: resumeID, CompanyID: companyID, IdempotencyKey: "retried"}
+
+		view, err := srv.CreateView(context.Background(), retried)
+		require.NoError(t, err)
+		assert.Equal(t, models.CreatedView{ID: viewID, Replayed: true}, view)
+
+		results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{
+			retried,
+			{ResumeID: resumeID, CompanyID: companyID},
+		})
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, models.CreatedView{ID: viewID, Replayed: true}, results[0].View)
+		assert.ErrorIs(t, results[1].Err, customerrors.ErrRateLimited)
+
+		assert.Equal(t, limit.Burst, repo.calls)
+		assert.Equal(t, map[string]int{
+			ViewOutcomeCounted:     limit.Burst,
+			ViewOutcomeReplayed:    2,
+			ViewOutcomeRateLimited: 1,
+		}, metrics.counts)
+	})
+
+	t.Run("Limiter failure lets views through", func(t *testing.T) {
+		t.Parallel()
+
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New()}}
+		srv := newTestViewService(repo, &fakeViewMetrics{}, WithRateLimiter(failingLimiter{}))
+
+		_, err := srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, repo.calls)
+	})
+}
+
+type failingDetector struct{}
+
+func (failingDetector) Check(context.Context, string, []abuse.View) ([][]string, error) {
+	return nil, assert.AnError
+}
+
+func (failingDetector) Record(context.Context, string, abuse.View) error {
+	return assert.AnError
+}
+
+func TestViewService_AbuseDetection(t *testing.T) {
+	t.Parallel()
+
+	resumeID := "6630e5f1a6b1f2c3d4e5f6a7"
+
+	t.Run("Flagged views are stored as suspicious and not published", func(t *testing.T) {
+		t.Parallel()
+
+		blocked := uuid.NewString()
+		detector := abuse.NewDetector(abuse.NewMemoryWindows(), abuse.Rules{
+			MaxViews:         1,
+			ViewWindow:       time.Minute,
+			BlockedCompanies: []string{blocked},
+		})
+
+		metrics := &fakeViewMetrics{}
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New()}}
+		srv := newTestViewService(repo, metrics, WithAbuseDetector(detector), WithFeed(feed.NewHub(4, 4)))
+
+		sub, err := srv.WatchResumeViews(context.Background(), resumeID)
+		require.NoError(t, err)
+
+		defer sub.Close()
+
+		_, err = srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: blocked})
+		require.NoError(t, err)
+		assert.True(t, repo.last.Suspicious)
+
+		_, err = srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: blocked})
+		require.NoError(t, err)
+
+		companyID := uuid.NewString()
+		results, err := srv.BatchCreateViews(context.Background(), []domain.CreateView{
+			{ResumeID: resumeID, CompanyID: companyID},
+			{ResumeID: resumeID, CompanyID: companyID},
+			{ResumeID: resumeID, CompanyID: companyID},
+		})
+		require.NoError(t, err)
+
+		assert.False(t, repo.lastBatch[0].Suspicious)
+		assert.True(t, repo.lastBatch[1].Suspicious)
+		assert.True(t, repo.lastBatch[2].Suspicious)
+
+		assert.Equal(t, map[string]int{abuse.RuleBlockedCompany: 2, abuse.RuleViewRate: 2}, metrics.suspicious,
+			"the collapsed batch item is not counted")
+		assert.Equal(t, results[0].View.ID, (<-sub.Views()).ID)
+		assert.Empty(t, sub.Views(), "suspicious views are not published")
+	})
+
+	t.Run("Views that are not counted are not observed", func(t *testing.T) {
+		t.Parallel()
+
+		detector := abuse.NewDetector(abuse.NewMemoryWindows(), abuse.Rules{MaxViews: 1, ViewWindow: time.Minute})
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New(), Collapsed: true}}
+		srv := newTestViewService(repo, &fakeViewMetrics{}, WithAbuseDetector(detector))
+		req := domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()}
+
+		for range 3 {
+			_, err := srv.CreateView(context.Background(), req)
+			require.NoError(t, err)
+			assert.False(t, repo.last.Suspicious, "collapsed views are not observed")
+		}
+
+		repo.created.Collapsed = false
+
+		_, err := srv.CreateView(context.Background(), req)
+		require.NoError(t, err)
+		assert.False(t, repo.last.Suspicious)
+
+		_, err = srv.CreateView(context.Background(), req)
+		require.NoError(t, err)
+		assert.True(t, repo.last.Suspicious)
+	})
+
+	t.Run("Detector failure does not flag views", func(t *testing.T) {
+		t.Parallel()
+
+		metrics := &fakeViewMetrics{}
+		repo := &fakeViewRepository{created: models.CreatedView{ID: uuid.New()}}
+		srv := newTestViewService(repo, metrics, WithAbuseDetector(failingDetector{}))
+
+		_, err := srv.CreateView(context.Background(), domain.CreateView{ResumeID: resumeID, CompanyID: uuid.NewString()})
+
+		require.NoError(t, err)
+		assert.False(t, repo.last.Suspicious)
+		assert.Empty(t, metrics.suspicious)
+	})
+}
@@ -23,3 +23,26 @@ func New(ctx context.Context, cfg Config) (*kafka.Conn, error) {
 
 	return conn, nil
 }
+
+// Ping dials the broker on a fresh connection and fetches cluster metadata, so it also fails when a
+// broker accepts TCP connections but does not answer requests.
+func Ping(ctx context.Context, cfg Config) error {
+	conn, err := New(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			return fmt.Errorf("failed to set kafka deadline: %w", err)
+		}
+	}
+
+	if _, err = conn.Brokers(); err != nil {
+		return fmt.Errorf("failed to fetch kafka brokers: %w", err)
+	}
+
+	return nil
+}